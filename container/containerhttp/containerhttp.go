@@ -0,0 +1,256 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+// Package containerhttp reads and writes ValueContainers in HTTP handlers,
+// choosing the serialization format from the Content-Type header. Raw
+// bodies and multipart/form-data uploads are both accepted.
+//
+// JSON, XML and MessagePack are built in. CBOR has no built-in codec so that
+// this package does not pull in a CBOR dependency; install one with
+// RegisterCodec.
+package containerhttp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// Format identifies an HTTP body serialization format
+type Format int
+
+const (
+	// FormatJSON is application/json
+	FormatJSON Format = iota
+	// FormatXML is application/xml
+	FormatXML
+	// FormatMessagePack is application/msgpack
+	FormatMessagePack
+	// FormatCBOR is application/cbor (requires RegisterCodec)
+	FormatCBOR
+)
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatXML:
+		return "xml"
+	case FormatMessagePack:
+		return "msgpack"
+	case FormatCBOR:
+		return "cbor"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// ContentType returns the media type written by WriteResponse for the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	case FormatXML:
+		return "application/xml"
+	case FormatMessagePack:
+		return "application/msgpack"
+	case FormatCBOR:
+		return "application/cbor"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+var (
+	// ErrUnsupportedMediaType is returned when a Content-Type does not map to a Format
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrNoCodec is returned when a Format has no built-in or registered codec
+	ErrNoCodec = errors.New("no codec available for format")
+)
+
+// Codec encodes and decodes containers for a Format
+type Codec struct {
+	Decode func(data []byte) (*core.ValueContainer, error)
+	Encode func(w io.Writer, c *core.ValueContainer) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = make(map[Format]Codec)
+)
+
+// RegisterCodec installs a codec for format, replacing any built-in one.
+// Use it to add CBOR support without making it a dependency of this package.
+// Registering a Codec with nil functions removes the registration.
+func RegisterCodec(format Format, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if codec.Decode == nil || codec.Encode == nil {
+		delete(codecs, format)
+		return
+	}
+	codecs[format] = codec
+}
+
+// FormatFromContentType maps a Content-Type header value to a Format.
+// Parameters such as charset are ignored, and +json / +xml structured
+// syntax suffixes are recognised.
+func FormatFromContentType(contentType string) (Format, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, contentType)
+	}
+
+	switch {
+	case mediaType == "application/json", mediaType == "text/json",
+		strings.HasSuffix(mediaType, "+json"):
+		return FormatJSON, nil
+	case mediaType == "application/xml", mediaType == "text/xml",
+		strings.HasSuffix(mediaType, "+xml"):
+		return FormatXML, nil
+	case mediaType == "application/msgpack", mediaType == "application/x-msgpack",
+		mediaType == "application/vnd.msgpack":
+		return FormatMessagePack, nil
+	case mediaType == "application/cbor", strings.HasSuffix(mediaType, "+cbor"):
+		return FormatCBOR, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+	}
+}
+
+// FromRequest reads the request body and deserializes it with the format
+// selected by the Content-Type header.
+//
+// A multipart/form-data body is decoded from its first part (file or field)
+// whose own Content-Type maps to a Format; other parts are skipped.
+//
+// The built-in decoders rebuild typed values as well as the header (see
+// FromJSON, FromXML and FromMessagePack).
+func FromRequest(r *http.Request) (*core.ValueContainer, error) {
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == "multipart/form-data" {
+		return fromMultipart(r)
+	}
+
+	format, err := FormatFromContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	return decode(format, data)
+}
+
+// fromMultipart decodes the first part of a multipart/form-data request
+// whose Content-Type maps to a Format
+func fromMultipart(r *http.Request) (*core.ValueContainer, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("read multipart body: %w", err)
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: no multipart part with a container media type", ErrUnsupportedMediaType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read multipart body: %w", err)
+		}
+
+		format, err := FormatFromContentType(part.Header.Get("Content-Type"))
+		if err != nil {
+			part.Close()
+			continue
+		}
+		data, err := io.ReadAll(part)
+		part.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read multipart part %q: %w", part.FormName(), err)
+		}
+		return decode(format, data)
+	}
+}
+
+// decode deserializes data with the codec for format
+func decode(format Format, data []byte) (*core.ValueContainer, error) {
+	codec, err := lookupCodec(format)
+	if err != nil {
+		return nil, err
+	}
+	container, err := codec.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s body: %w", format, err)
+	}
+	return container, nil
+}
+
+// WriteResponse serializes c in the given format and writes it to w with the
+// matching Content-Type. The status code is left to the default (200).
+func WriteResponse(w http.ResponseWriter, c *core.ValueContainer, format Format) error {
+	codec, err := lookupCodec(format)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	return codec.Encode(w, c)
+}
+
+// lookupCodec returns the registered codec for format, or the built-in one
+func lookupCodec(format Format) (Codec, error) {
+	codecsMu.RLock()
+	codec, ok := codecs[format]
+	codecsMu.RUnlock()
+	if ok {
+		return codec, nil
+	}
+
+	switch format {
+	case FormatJSON:
+		return builtinCodec(core.FormatJSON), nil
+	case FormatXML:
+		return builtinCodec(core.FormatXML), nil
+	case FormatMessagePack:
+		return builtinCodec(core.FormatMessagePack), nil
+	default:
+		return Codec{}, fmt.Errorf("%w: %s", ErrNoCodec, format)
+	}
+}
+
+// builtinCodec returns a Codec backed by ValueContainer.Marshal and Unmarshal
+func builtinCodec(format core.SerializationFormat) Codec {
+	return Codec{
+		Decode: func(data []byte) (*core.ValueContainer, error) {
+			container := core.NewValueContainer()
+			if err := container.Unmarshal(data, format); err != nil {
+				return nil, err
+			}
+			return container, nil
+		},
+		Encode: func(w io.Writer, c *core.ValueContainer) error {
+			if format == core.FormatMessagePack {
+				return c.WriteMessagePackTo(w)
+			}
+			data, err := c.Marshal(format)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		},
+	}
+}
@@ -0,0 +1,43 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// ToBase64 serializes the container in the given format (see Marshal) and
+// encodes it as unpadded URL-safe base64, suitable for JWT claims and URL
+// parameters.
+func (c *ValueContainer) ToBase64(format SerializationFormat) (string, error) {
+	data, err := c.Marshal(format)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// FromBase64 decodes a base64 string and deserializes it in the given format
+// (see Unmarshal). Both the URL-safe and standard alphabets are accepted,
+// with or without padding.
+func FromBase64(s string, format SerializationFormat) (*ValueContainer, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode failed: %w", err)
+	}
+
+	container := NewValueContainer()
+	if err := container.Unmarshal(data, format); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
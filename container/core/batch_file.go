@@ -0,0 +1,111 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// batchFileMagic starts every file written by SaveContainers
+var batchFileMagic = [4]byte{'C', 'S', 'B', 'T'}
+
+// batchFileVersion is the layout version of the batch file header
+const batchFileVersion uint8 = 1
+
+// SaveContainers serializes every container in the given format and writes
+// them to filePath as one batch file.
+//
+// File layout: magic "CSBT" (4 bytes), header version (1 byte), format
+// (1 byte), container count (4 bytes LE), then per container its length
+// (4 bytes LE) followed by its serialization.
+func SaveContainers(filePath string, cs []*ValueContainer, format SerializationFormat) error {
+	if uint64(len(cs)) > math.MaxUint32 {
+		return fmt.Errorf("too many containers for a batch file: %d", len(cs))
+	}
+
+	return writeFile(filePath, func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		header := append(batchFileMagic[:], batchFileVersion, byte(format))
+		header = binary.LittleEndian.AppendUint32(header, uint32(len(cs)))
+		if _, err := bw.Write(header); err != nil {
+			return err
+		}
+
+		for i, c := range cs {
+			data, err := c.Marshal(format)
+			if err != nil {
+				return fmt.Errorf("container %d: %s serialization failed: %w", i, format, err)
+			}
+			if uint64(len(data)) > math.MaxUint32 {
+				return fmt.Errorf("container %d: serialization too large: %d bytes", i, len(data))
+			}
+			var length [4]byte
+			binary.LittleEndian.PutUint32(length[:], uint32(len(data)))
+			if _, err := bw.Write(length[:]); err != nil {
+				return err
+			}
+			if _, err := bw.Write(data); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	})
+}
+
+// LoadContainers loads every container from a file written by
+// SaveContainers, detecting the serialization format from the file header.
+// A file that ends before the declared count of containers fails with
+// ErrTruncatedData.
+func LoadContainers(filePath string) ([]*ValueContainer, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("file read failed: %w", err)
+	}
+
+	if len(data) < 10 || !bytes.Equal(data[:4], batchFileMagic[:]) {
+		return nil, ErrNotBatchFile
+	}
+	if data[4] != batchFileVersion {
+		return nil, fmt.Errorf("unsupported batch file version: %d", data[4])
+	}
+	format := SerializationFormat(data[5])
+	count := binary.LittleEndian.Uint32(data[6:10])
+	offset := 10
+
+	// Every container needs at least its length prefix, so a corrupt count
+	// cannot force a huge allocation
+	if uint64(count)*4 > uint64(len(data)-offset) {
+		return nil, fmt.Errorf("%w: %d containers declared", ErrTruncatedData, count)
+	}
+
+	cs := make([]*ValueContainer, 0, count)
+	for i := uint32(0); i < count; i++ {
+		if len(data)-offset < 4 {
+			return nil, fmt.Errorf("%w: container %d length", ErrTruncatedData, i)
+		}
+		length := uint64(binary.LittleEndian.Uint32(data[offset:]))
+		offset += 4
+		if length > uint64(len(data)-offset) {
+			return nil, fmt.Errorf("%w: container %d needs %d bytes", ErrTruncatedData, i, length)
+		}
+
+		c := NewValueContainer()
+		if err := c.Unmarshal(data[offset:offset+int(length)], format); err != nil {
+			return nil, fmt.Errorf("container %d: %s deserialization failed: %w", i, format, err)
+		}
+		offset += int(length)
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
@@ -0,0 +1,82 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+// maxPooledBufferSize is the largest scratch buffer returned to the pool;
+// bigger buffers are left to the garbage collector so that one huge
+// container does not pin its memory for the life of the process
+const maxPooledBufferSize = 64 << 10
+
+// scratchPool holds reusable byte slices for the binary serializers
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
+// getScratch returns an empty scratch buffer from the pool
+func getScratch() *[]byte {
+	buf := scratchPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+// putScratch returns a scratch buffer to the pool. The caller must not keep
+// any reference into it.
+func putScratch(buf *[]byte) {
+	if cap(*buf) > maxPooledBufferSize {
+		return
+	}
+	scratchPool.Put(buf)
+}
+
+// frameAppender is implemented by values whose frame is not simply the
+// header followed by Data(), such as containers and arrays, or whose Data()
+// is computed, such as maps, so that they can be serialized into a
+// caller-supplied buffer
+type frameAppender interface {
+	AppendFrame(dst []byte) ([]byte, error)
+}
+
+// AppendValueFrame appends the binary frame of v to dst and returns the
+// extended slice. Primitive values are written straight from Data()
+// without allocating an intermediate frame.
+func AppendValueFrame(dst []byte, v Value) ([]byte, error) {
+	if v.Type() == ContainerValue || v.Type() == ArrayValue || v.Type() == MapValue {
+		if fa, ok := v.(frameAppender); ok {
+			return fa.AppendFrame(dst)
+		}
+		frame, err := v.ToBytes()
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, frame...), nil
+	}
+
+	// [type:1][name_len:4][name][value_size:4][data]
+	name, data := v.Name(), v.Data()
+	dst = append(dst, byte(v.Type()))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(name)))
+	dst = append(dst, name...)
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(data)))
+	return append(dst, data...), nil
+}
+
+// FrameBytes returns the binary frame of v,
+// [type:1][name_len:4][name][value_size:4][payload], for any value. The
+// typed values' ToBytes return the same bytes; a plain BaseValue's ToBytes
+// returns only its payload.
+func FrameBytes(v Value) ([]byte, error) {
+	return AppendValueFrame(make([]byte, 0, v.SerializedSize()), v)
+}
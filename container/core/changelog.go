@@ -0,0 +1,206 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// ChangeKind classifies a Change between two container versions
+type ChangeKind int
+
+const (
+	// ChangeAdded marks a field present only in the new container
+	ChangeAdded ChangeKind = iota
+	// ChangeRemoved marks a field present only in the old container
+	ChangeRemoved
+	// ChangeModified marks a field whose type or value changed
+	ChangeModified
+)
+
+// String returns the display name of the change kind
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "Added"
+	case ChangeRemoved:
+		return "Removed"
+	case ChangeModified:
+		return "Modified"
+	default:
+		return "Unknown"
+	}
+}
+
+// Change is a single human-readable entry of a container changelog.
+// Old is empty for additions and New is empty for removals.
+type Change struct {
+	Kind    ChangeKind
+	Field   string // value name, suffixed with [n] for repeated names
+	Old     string
+	New     string
+	OldType ValueType
+	NewType ValueType
+}
+
+// String renders the change for display. Additions are prefixed with "+",
+// removals with "-" and modifications with "~"; the type is repeated on both
+// sides when it changed, e.g. `~ age: int 42 -> string "42"`.
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s %s", c.Field, c.NewType.TypeName(), c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s %s", c.Field, c.OldType.TypeName(), c.Old)
+	default:
+		if c.OldType != c.NewType {
+			return fmt.Sprintf("~ %s: %s %s -> %s %s", c.Field,
+				c.OldType.TypeName(), c.Old, c.NewType.TypeName(), c.New)
+		}
+		return fmt.Sprintf("~ %s: %s %s -> %s", c.Field, c.NewType.TypeName(), c.Old, c.New)
+	}
+}
+
+// Changelog lists the top-level values that differ between two versions of
+// a container, formatted for display (e.g. in an audit log).
+//
+// Values are matched by name; when a name repeats, occurrences are matched
+// by position. Removed and modified fields are reported in the order of the
+// old container, followed by added fields in the order of the new one.
+// A nil container is treated as empty.
+func Changelog(oldContainer, newContainer *ValueContainer) []Change {
+	oldValues := changelogValues(oldContainer)
+	newValues := changelogValues(newContainer)
+
+	newByField := make(map[string]Value, len(newValues))
+	for _, entry := range newValues {
+		newByField[entry.field] = entry.value
+	}
+	oldFields := make(map[string]bool, len(oldValues))
+
+	changes := make([]Change, 0)
+	for _, entry := range oldValues {
+		oldFields[entry.field] = true
+		newValue, ok := newByField[entry.field]
+		if !ok {
+			changes = append(changes, Change{
+				Kind:    ChangeRemoved,
+				Field:   entry.field,
+				Old:     displayValue(entry.value),
+				OldType: entry.value.Type(),
+			})
+			continue
+		}
+		if ValuesEqual(entry.value, newValue) {
+			continue
+		}
+		changes = append(changes, Change{
+			Kind:    ChangeModified,
+			Field:   entry.field,
+			Old:     displayValue(entry.value),
+			New:     displayValue(newValue),
+			OldType: entry.value.Type(),
+			NewType: newValue.Type(),
+		})
+	}
+
+	for _, entry := range newValues {
+		if oldFields[entry.field] {
+			continue
+		}
+		changes = append(changes, Change{
+			Kind:    ChangeAdded,
+			Field:   entry.field,
+			New:     displayValue(entry.value),
+			NewType: entry.value.Type(),
+		})
+	}
+
+	return changes
+}
+
+// changelogEntry pairs a value with its display field name
+type changelogEntry struct {
+	field string
+	value Value
+}
+
+// changelogValues returns the container's values keyed by display field
+// name, taking a consistent snapshot under the read lock.
+func changelogValues(c *ValueContainer) []changelogEntry {
+	if c == nil {
+		return nil
+	}
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return fieldEntries(c.units)
+}
+
+// fieldEntries names each value by its display field, suffixing repeated
+// names with [n] so that occurrences can be matched by position
+func fieldEntries(units []Value) []changelogEntry {
+	seen := make(map[string]int, len(units))
+	entries := make([]changelogEntry, 0, len(units))
+	for _, value := range units {
+		name := value.Name()
+		field := name
+		if n := seen[name]; n > 0 {
+			field = fmt.Sprintf("%s[%d]", name, n)
+		}
+		seen[name]++
+		entries = append(entries, changelogEntry{field: field, value: value})
+	}
+	return entries
+}
+
+// displayValue renders a value's content for a changelog entry
+func displayValue(v Value) string {
+	switch v.Type() {
+	case NullValue:
+		return "null"
+	case BoolValue:
+		if b, err := v.ToBool(); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case ShortValue, IntValue, LongValue, LLongValue:
+		if n, err := v.ToInt64(); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+	case UShortValue, UIntValue, ULongValue, ULLongValue:
+		if n, err := v.ToUInt64(); err == nil {
+			return strconv.FormatUint(n, 10)
+		}
+	case FloatValue:
+		if f, err := v.ToFloat32(); err == nil {
+			return strconv.FormatFloat(float64(f), 'g', -1, 32)
+		}
+	case DoubleValue:
+		if f, err := v.ToFloat64(); err == nil {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	case StringValue:
+		if s, err := v.ToString(); err == nil {
+			return strconv.Quote(s)
+		}
+	case BytesValue:
+		return "0x" + hex.EncodeToString(v.Data())
+	case ContainerValue, ArrayValue, MapValue:
+		if s, err := v.ToJSON(); err == nil {
+			return s
+		}
+	default:
+		if s, err := v.ToString(); err == nil {
+			return s
+		}
+	}
+	return fmt.Sprintf("<%d bytes>", v.Size())
+}
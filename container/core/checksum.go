@@ -0,0 +1,46 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// checksumSize is the length of the CRC32 footer
+const checksumSize = 4
+
+// SerializeArrayWithChecksum serializes the container in the binary
+// container format (see SerializeBinary) and appends a 4-byte little-endian
+// CRC32 (IEEE) of that payload, so corruption in transit can be detected.
+//
+// Use DeserializeArrayWithChecksum to verify and decode the result.
+func (c *ValueContainer) SerializeArrayWithChecksum() ([]byte, error) {
+	payload, err := c.SerializeBinary()
+	if err != nil {
+		return nil, err
+	}
+	return binary.LittleEndian.AppendUint32(payload, crc32.ChecksumIEEE(payload)), nil
+}
+
+// DeserializeArrayWithChecksum verifies the CRC32 footer written by
+// SerializeArrayWithChecksum and replaces the container's header and values
+// with the decoded payload. Data whose checksum does not match returns
+// ErrChecksumMismatch and leaves the container unchanged.
+func (c *ValueContainer) DeserializeArrayWithChecksum(data []byte) error {
+	if len(data) < checksumSize {
+		return fmt.Errorf("checksummed data too short: %d bytes: %w", len(data), ErrTruncatedData)
+	}
+	payload := data[:len(data)-checksumSize]
+	want := binary.LittleEndian.Uint32(data[len(payload):])
+	if got := crc32.ChecksumIEEE(payload); got != want {
+		return fmt.Errorf("CRC32 %08x, footer %08x: %w", got, want, ErrChecksumMismatch)
+	}
+	return c.DeserializeBinary(payload)
+}
@@ -0,0 +1,268 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ColumnarVersion is the version byte for the columnar batch format
+const ColumnarVersion uint8 = 1
+
+// absentCell marks a row that has no value for a column
+const absentCell uint32 = 0xFFFFFFFF
+
+// column collects one field across all rows of a batch
+type column struct {
+	name  string
+	vtype ValueType
+	cells [][]byte // nil entry = row has no value for this column
+}
+
+// SerializeColumnar serializes a batch of containers in a columnar layout.
+//
+// Instead of repeating every value's name and type per container, each field
+// name and type is stored once followed by that field's payload for every
+// row. Uniform batches (same fields in each container) become much smaller
+// and compress far better than row-wise output.
+//
+// Binary format (little-endian):
+//   - Version byte (1)
+//   - Row count (4 bytes)
+//   - Header columns: for each of source_id, source_sub_id, target_id,
+//     target_sub_id, message_type, version: row_count x [len:4][bytes]
+//   - Column count (4 bytes)
+//   - For each column:
+//   - Name length (4 bytes) + name
+//   - Value type (1 byte)
+//   - row_count x [len:4][payload] (len 0xFFFFFFFF = absent)
+//
+// Every value name may appear at most once per container, and a column must
+// have the same type in every row that contains it. Deserialized containers
+// list their values in column order.
+// Each container is read under its own lock if EnableThreadSafe was called.
+func SerializeColumnar(containers []*ValueContainer) ([]byte, error) {
+	rowCount := len(containers)
+	columns := make([]*column, 0)
+	index := make(map[string]*column)
+
+	headers := make([]Header, rowCount)
+	for row, c := range containers {
+		var units []Value
+		headers[row], units = c.columnarSnapshot()
+		seen := make(map[string]bool)
+		for _, value := range units {
+			name := value.Name()
+			if seen[name] {
+				return nil, fmt.Errorf("columnar: row %d has duplicate value name %q", row, name)
+			}
+			seen[name] = true
+
+			col, exists := index[name]
+			if !exists {
+				col = &column{name: name, vtype: value.Type(), cells: make([][]byte, rowCount)}
+				index[name] = col
+				columns = append(columns, col)
+			} else if col.vtype != value.Type() {
+				return nil, fmt.Errorf("columnar: row %d column %q has type %s, expected %s",
+					row, name, value.Type().TypeName(), col.vtype.TypeName())
+			}
+
+			payload, err := RawPayload(value)
+			if err != nil {
+				return nil, fmt.Errorf("columnar: row %d column %q: %w", row, name, err)
+			}
+			if payload == nil {
+				payload = []byte{}
+			}
+			col.cells[row] = payload
+		}
+	}
+
+	result := make([]byte, 0, 64*rowCount)
+	result = append(result, ColumnarVersion)
+	result = binary.LittleEndian.AppendUint32(result, uint32(rowCount))
+
+	// Header columns
+	for _, field := range headerFields {
+		for row := range headers {
+			value := field(&headers[row])
+			result = binary.LittleEndian.AppendUint32(result, uint32(len(*value)))
+			result = append(result, *value...)
+		}
+	}
+
+	// Value columns
+	result = binary.LittleEndian.AppendUint32(result, uint32(len(columns)))
+	for _, col := range columns {
+		result = binary.LittleEndian.AppendUint32(result, uint32(len(col.name)))
+		result = append(result, col.name...)
+		result = append(result, byte(col.vtype))
+		for _, cell := range col.cells {
+			if cell == nil {
+				result = binary.LittleEndian.AppendUint32(result, absentCell)
+				continue
+			}
+			result = binary.LittleEndian.AppendUint32(result, uint32(len(cell)))
+			result = append(result, cell...)
+		}
+	}
+
+	return result, nil
+}
+
+// columnarSnapshot returns the header and a copy of the values taken under a
+// single read lock, so a concurrent writer cannot tear a row
+func (c *ValueContainer) columnarSnapshot() (Header, []Value) {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	units := make([]Value, len(c.units))
+	copy(units, c.units)
+	return Header{
+		SourceID:    c.sourceID,
+		SourceSubID: c.sourceSubID,
+		TargetID:    c.targetID,
+		TargetSubID: c.targetSubID,
+		MessageType: c.messageType,
+		Version:     c.version,
+		CreatedAt:   c.createdAt,
+	}, units
+}
+
+// DeserializeColumnar rebuilds the containers written by SerializeColumnar.
+// Values are created through the shared value factory.
+func DeserializeColumnar(data []byte) ([]*ValueContainer, error) {
+	r := &columnarReader{data: data}
+
+	version, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	if version != ColumnarVersion {
+		return nil, fmt.Errorf("columnar: unsupported version %d", version)
+	}
+
+	rowCount, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	// Every row needs at least 6 header length fields
+	if uint64(rowCount)*24 > uint64(len(data)) {
+		return nil, errors.New("columnar: row count exceeds data size")
+	}
+
+	headers := make([]Header, rowCount)
+	for _, field := range headerFields {
+		for row := range headers {
+			value, err := r.lengthPrefixed()
+			if err != nil {
+				return nil, err
+			}
+			*field(&headers[row]) = string(value)
+		}
+	}
+
+	containers := make([]*ValueContainer, rowCount)
+	for row := range containers {
+		containers[row] = NewValueContainer()
+		containers[row].SetHeader(headers[row])
+	}
+
+	columnCount, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < columnCount; i++ {
+		name, err := r.lengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		vtype, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		for row := range containers {
+			cellLen, err := r.uint32()
+			if err != nil {
+				return nil, err
+			}
+			if cellLen == absentCell {
+				continue
+			}
+			payload, err := r.bytes(int(cellLen))
+			if err != nil {
+				return nil, err
+			}
+			value, err := NewValueFromData(string(name), ValueType(vtype), payload)
+			if err != nil {
+				return nil, fmt.Errorf("columnar: row %d column %q: %w", row, name, err)
+			}
+			containers[row].AddValue(value)
+		}
+	}
+
+	return containers, nil
+}
+
+// headerFields lists the header columns in their serialized order
+var headerFields = []func(*Header) *string{
+	func(h *Header) *string { return &h.SourceID },
+	func(h *Header) *string { return &h.SourceSubID },
+	func(h *Header) *string { return &h.TargetID },
+	func(h *Header) *string { return &h.TargetSubID },
+	func(h *Header) *string { return &h.MessageType },
+	func(h *Header) *string { return &h.Version },
+}
+
+// columnarReader is a bounds-checked cursor over columnar data
+type columnarReader struct {
+	data   []byte
+	offset int
+}
+
+var errColumnarTruncated = errors.New("columnar: truncated data")
+
+func (r *columnarReader) byte() (byte, error) {
+	if r.offset+1 > len(r.data) {
+		return 0, errColumnarTruncated
+	}
+	b := r.data[r.offset]
+	r.offset++
+	return b, nil
+}
+
+func (r *columnarReader) uint32() (uint32, error) {
+	if r.offset+4 > len(r.data) {
+		return 0, errColumnarTruncated
+	}
+	v := binary.LittleEndian.Uint32(r.data[r.offset:])
+	r.offset += 4
+	return v, nil
+}
+
+func (r *columnarReader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.offset+n > len(r.data) {
+		return nil, errColumnarTruncated
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b, nil
+}
+
+func (r *columnarReader) lengthPrefixed() ([]byte, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	return r.bytes(int(n))
+}
@@ -0,0 +1,122 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// compressedFileMagic starts every file written by SaveToFileCompressed
+var compressedFileMagic = [4]byte{'C', 'S', 'G', 'Z'}
+
+// compressedFileVersion is the layout version of the compressed file header
+const compressedFileVersion uint8 = 1
+
+// ErrNotCompressedContainer is returned when a file does not start with the
+// compressed container header
+var ErrNotCompressedContainer = errors.New("not a compressed container file")
+
+// CompressionStats reports the result of a compressed save
+type CompressionStats struct {
+	OriginalBytes   int64   // length of the uncompressed serialization
+	CompressedBytes int64   // length of the compressed stream, excluding the file header
+	Ratio           float64 // CompressedBytes / OriginalBytes, or 0 when OriginalBytes is 0
+	Codec           string  // compression codec, e.g. "gzip"
+}
+
+// SaveToFileCompressed serializes the container in the given format and
+// writes it gzip-compressed to filePath.
+//
+// File layout: magic "CSGZ" (4 bytes), header version (1 byte), format
+// (1 byte), then the gzip stream of the serialized container.
+func (c *ValueContainer) SaveToFileCompressed(filePath string, format SerializationFormat) error {
+	_, err := c.SaveToFileCompressedWithStats(filePath, format)
+	return err
+}
+
+// SaveToFileCompressedWithStats is like SaveToFileCompressed but also
+// returns the achieved compression, e.g. for monitoring poorly compressing
+// payloads
+func (c *ValueContainer) SaveToFileCompressedWithStats(filePath string, format SerializationFormat) (CompressionStats, error) {
+	data, err := c.Marshal(format)
+	if err != nil {
+		return CompressionStats{}, fmt.Errorf("%s serialization failed: %w", format, err)
+	}
+
+	stats := CompressionStats{OriginalBytes: int64(len(data)), Codec: "gzip"}
+	err = writeFile(filePath, func(w io.Writer) error {
+		header := append(compressedFileMagic[:], compressedFileVersion, byte(format))
+		if _, err := w.Write(header); err != nil {
+			return err
+		}
+
+		cw := &countingWriter{w: w}
+		zw := gzip.NewWriter(cw)
+		if _, err := zw.Write(data); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		stats.CompressedBytes = cw.n
+		return nil
+	})
+	if err != nil {
+		return CompressionStats{}, err
+	}
+
+	if stats.OriginalBytes > 0 {
+		stats.Ratio = float64(stats.CompressedBytes) / float64(stats.OriginalBytes)
+	}
+	return stats, nil
+}
+
+// LoadFromFileCompressed loads a file written by SaveToFileCompressed,
+// detecting the serialization format from the file header
+func (c *ValueContainer) LoadFromFileCompressed(filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("file read failed: %w", err)
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	var header [6]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return ErrNotCompressedContainer
+	}
+	if !bytes.Equal(header[:4], compressedFileMagic[:]) {
+		return ErrNotCompressedContainer
+	}
+	if header[4] != compressedFileVersion {
+		return fmt.Errorf("unsupported compressed file version: %d", header[4])
+	}
+	format := SerializationFormat(header[5])
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("decompression failed: %w", err)
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("decompression failed: %w", err)
+	}
+
+	if err := c.Unmarshal(data, format); err != nil {
+		return fmt.Errorf("%s deserialization failed: %w", format, err)
+	}
+	return nil
+}
@@ -8,12 +8,16 @@ All rights reserved.
 package core
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -28,18 +32,32 @@ type ValueContainer struct {
 	messageType string
 	version     string
 
+	// Creation timestamp (see SetCreatedAt); zero when unset
+	createdAt time.Time
+
 	// Values
 	units []Value
 
+	// Name index (see EnableIndex); nil when disabled
+	index map[string][]int
+
+	// Statistics (see GetReadCount); atomic, so they need no lock
+	readCount          atomic.Uint64
+	writeCount         atomic.Uint64
+	serializationCount atomic.Uint64
+
 	// Thread safety
 	mu         sync.RWMutex
 	threadSafe bool
 }
 
+// DefaultVersion is the header version stamped by the constructors
+const DefaultVersion = "1.0.0.0"
+
 // NewValueContainer creates a new empty container
 func NewValueContainer() *ValueContainer {
 	return &ValueContainer{
-		version: "1.0.0.0",
+		version: DefaultVersion,
 		units:   make([]Value, 0),
 	}
 }
@@ -48,7 +66,7 @@ func NewValueContainer() *ValueContainer {
 func NewValueContainerWithType(messageType string, units ...Value) *ValueContainer {
 	return &ValueContainer{
 		messageType: messageType,
-		version:     "1.0.0.0",
+		version:     DefaultVersion,
 		units:       units,
 	}
 }
@@ -59,7 +77,7 @@ func NewValueContainerWithTarget(targetID, targetSubID, messageType string, unit
 		targetID:    targetID,
 		targetSubID: targetSubID,
 		messageType: messageType,
-		version:     "1.0.0.0",
+		version:     DefaultVersion,
 		units:       units,
 	}
 }
@@ -72,7 +90,7 @@ func NewValueContainerFull(sourceID, sourceSubID, targetID, targetSubID, message
 		targetID:    targetID,
 		targetSubID: targetSubID,
 		messageType: messageType,
-		version:     "1.0.0.0",
+		version:     DefaultVersion,
 		units:       units,
 	}
 }
@@ -121,6 +139,16 @@ func (c *ValueContainer) SetMessageType(messageType string) {
 	c.messageType = messageType
 }
 
+// SetVersion sets the header version. NormalizeVersion restores
+// DefaultVersion if it is later found empty.
+func (c *ValueContainer) SetVersion(version string) {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.version = version
+}
+
 // SwapHeader swaps source and target
 func (c *ValueContainer) SwapHeader() {
 	c.sourceID, c.targetID = c.targetID, c.sourceID
@@ -136,6 +164,25 @@ func (c *ValueContainer) MessageType() string    { return c.messageType }
 func (c *ValueContainer) Version() string        { return c.version }
 func (c *ValueContainer) Values() []Value        { return c.units }
 
+// NormalizeVersion sets an empty version to DefaultVersion and reports
+// whether it did so. A set version is left unchanged.
+//
+// The constructors always stamp DefaultVersion, but the version can still be
+// empty when the container is a zero ValueContainer literal, or when it was
+// deserialized (text, binary, protobuf, MessagePack, SetHeader) from data
+// that carried an empty version field; Copy preserves whatever the source had.
+func (c *ValueContainer) NormalizeVersion() bool {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	if c.version != "" {
+		return false
+	}
+	c.version = DefaultVersion
+	return true
+}
+
 // AddValue adds a value to the container
 func (c *ValueContainer) AddValue(value Value) {
 	if c.threadSafe {
@@ -143,6 +190,49 @@ func (c *ValueContainer) AddValue(value Value) {
 		defer c.mu.Unlock()
 	}
 	c.units = append(c.units, value)
+	c.indexAppend(len(c.units) - 1)
+	c.writeCount.Add(1)
+}
+
+// InsertValue inserts a value at the given position, shifting later values
+// back. Index len(Values()) appends.
+func (c *ValueContainer) InsertValue(index int, value Value) error {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	if index < 0 || index > len(c.units) {
+		return fmt.Errorf("insert index %d out of range [0, %d]", index, len(c.units))
+	}
+	c.units = append(c.units, nil)
+	copy(c.units[index+1:], c.units[index:])
+	c.units[index] = value
+	c.reindex()
+	return nil
+}
+
+// ReplaceValue replaces the index-th value with the given name (counting
+// as GetValue does) and keeps its position. It returns false if there is no
+// such value.
+func (c *ValueContainer) ReplaceValue(name string, index int, value Value) bool {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	count := 0
+	for i, unit := range c.units {
+		if unit.Name() == name {
+			if count == index {
+				c.units[i] = value
+				if value.Name() != name {
+					c.reindex()
+				}
+				return true
+			}
+			count++
+		}
+	}
+	return false
 }
 
 // RemoveValue removes all values with the given name
@@ -158,28 +248,118 @@ func (c *ValueContainer) RemoveValue(name string) {
 		}
 	}
 	c.units = newUnits
+	c.reindex()
+}
+
+// RenameValue renames every top-level value named oldName to newName and
+// returns how many were renamed. Values are renamed in place with SetName,
+// so a value shared with another container is renamed there as well.
+func (c *ValueContainer) RenameValue(oldName, newName string) int {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	renamed := 0
+	for _, unit := range c.units {
+		if unit.Name() == oldName {
+			unit.SetName(newName)
+			renamed++
+		}
+	}
+	if renamed > 0 && oldName != newName {
+		c.reindex()
+	}
+	return renamed
+}
+
+// TransformNames renames every top-level value to fn(name), in place like
+// RenameValue. Children of nested containers keep their names.
+// fn must not call back into the container.
+func (c *ValueContainer) TransformNames(fn func(name string) string) {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	for _, unit := range c.units {
+		if newName := fn(unit.Name()); newName != unit.Name() {
+			unit.SetName(newName)
+		}
+	}
+	c.reindex()
 }
 
-// GetValue gets the first value with the given name
+// TransformValues replaces every value with the result of fn, in order.
+// When fn returns false the value is removed instead. The whole pass runs
+// under the write lock in thread-safe mode and the value slice is rebuilt
+// once, so readers never observe a partially transformed container.
+// fn must not call back into the container.
+func (c *ValueContainer) TransformValues(fn func(v Value) (Value, bool)) {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	newUnits := make([]Value, 0, len(c.units))
+	for _, unit := range c.units {
+		if replacement, keep := fn(unit); keep {
+			newUnits = append(newUnits, replacement)
+		}
+	}
+	c.units = newUnits
+	c.reindex()
+}
+
+// GetValue gets the index-th value with the given name, or an unnamed
+// NullValue placeholder if there is none. Use TryGetValue or Has to tell a
+// missing value apart.
 func (c *ValueContainer) GetValue(name string, index int) Value {
+	if value, ok := c.TryGetValue(name, index); ok {
+		return value
+	}
+	return NewBaseValue("", NullValue, nil)
+}
+
+// TryGetValue gets the index-th value with the given name (counting as
+// GetValue does) and reports whether it exists, without the null placeholder
+// that GetValue returns for a missing value.
+func (c *ValueContainer) TryGetValue(name string, index int) (Value, bool) {
 	if c.threadSafe {
 		c.mu.RLock()
 		defer c.mu.RUnlock()
 	}
+	if c.index != nil {
+		if positions := c.index[name]; index >= 0 && index < len(positions) {
+			c.readCount.Add(1)
+			return c.units[positions[index]], true
+		}
+		return nil, false
+	}
 	count := 0
 	for _, unit := range c.units {
 		if unit.Name() == name {
 			if count == index {
-				return unit
+				c.readCount.Add(1)
+				return unit, true
 			}
 			count++
 		}
 	}
-	return NewBaseValue("", NullValue, nil)
+	return nil, false
 }
 
 // GetValues gets all values with the given name
 func (c *ValueContainer) GetValues(name string) []Value {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	if c.index != nil {
+		positions := c.index[name]
+		result := make([]Value, len(positions))
+		for i, position := range positions {
+			result[i] = c.units[position]
+		}
+		return result
+	}
 	result := make([]Value, 0)
 	for _, unit := range c.units {
 		if unit.Name() == name {
@@ -189,13 +369,112 @@ func (c *ValueContainer) GetValues(name string) []Value {
 	return result
 }
 
+// Has reports whether the container holds a value with the given name.
+// Unlike checking GetValue for a null placeholder, it also finds values
+// whose type is NullValue.
+func (c *ValueContainer) Has(name string) bool {
+	return c.Count(name) > 0
+}
+
+// Count returns the number of values with the given name
+func (c *ValueContainer) Count(name string) int {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	if c.index != nil {
+		return len(c.index[name])
+	}
+	count := 0
+	for _, unit := range c.units {
+		if unit.Name() == name {
+			count++
+		}
+	}
+	return count
+}
+
+// GroupByName groups values by name.
+// Each name maps to its occurrences in insertion order.
+func (c *ValueContainer) GroupByName() map[string][]Value {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	groups := make(map[string][]Value)
+	for _, unit := range c.units {
+		groups[unit.Name()] = append(groups[unit.Name()], unit)
+	}
+	return groups
+}
+
+// ForEach calls fn for each value in order, without exposing the value
+// slice. If fn returns false, the iteration stops.
+// Thread-safe if EnableThreadSafe was called: the read lock is held for the
+// whole iteration, so fn must not modify the container.
+func (c *ValueContainer) ForEach(fn func(v Value) bool) {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	for _, unit := range c.units {
+		if !fn(unit) {
+			break
+		}
+	}
+}
+
+// Find returns the values for which pred returns true, in order. The
+// result is empty, not nil, when nothing matches.
+// Thread-safe if EnableThreadSafe was called: the read lock is held while
+// pred runs, so pred must not modify the container.
+func (c *ValueContainer) Find(pred func(v Value) bool) []Value {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	result := make([]Value, 0)
+	for _, unit := range c.units {
+		if pred(unit) {
+			result = append(result, unit)
+		}
+	}
+	return result
+}
+
+// FindFirst returns the first value for which pred returns true, and
+// whether there is one. The same locking rules as Find apply.
+func (c *ValueContainer) FindFirst(pred func(v Value) bool) (Value, bool) {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	for _, unit := range c.units {
+		if pred(unit) {
+			return unit, true
+		}
+	}
+	return nil, false
+}
+
 // ClearValues removes all values
 func (c *ValueContainer) ClearValues() {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
 	c.units = make([]Value, 0)
+	c.reindex()
 }
 
-// Copy creates a copy of this container
+// Copy creates a copy of this container.
+// With containingValues, every value is deep-copied with CloneValue, so the
+// copy shares no mutable values with the original.
 func (c *ValueContainer) Copy(containingValues bool) *ValueContainer {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
 	newContainer := &ValueContainer{
 		sourceID:    c.sourceID,
 		sourceSubID: c.sourceSubID,
@@ -203,12 +482,15 @@ func (c *ValueContainer) Copy(containingValues bool) *ValueContainer {
 		targetSubID: c.targetSubID,
 		messageType: c.messageType,
 		version:     c.version,
+		createdAt:   c.createdAt,
 		units:       make([]Value, 0),
 	}
 
 	if containingValues {
 		newContainer.units = make([]Value, len(c.units))
-		copy(newContainer.units, c.units)
+		for i, unit := range c.units {
+			newContainer.units[i] = CloneValue(unit)
+		}
 	}
 
 	return newContainer
@@ -230,23 +512,81 @@ func (c *ValueContainer) Serialize() (string, error) {
 	fmt.Fprintln(os.Stderr, "         Use wireprotocol.SerializeCppWire() for cross-language compatibility.")
 	fmt.Fprintln(os.Stderr, "         See: https://github.com/kcenon/container_system/blob/main/MIGRATION_GUIDE.md")
 
+	var sb strings.Builder
+	sb.Grow(c.Size())
+	if err := c.writeText(&sb); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// SerializeText writes the container in the same text format as Serialize
+// directly to w, without building the intermediate string.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) SerializeText(w io.Writer) error {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return c.writeText(w)
+}
+
+// WriteTo implements io.WriterTo. It streams the container to w in the same
+// text format as SerializeArray, writing each value as it is serialized
+// instead of buffering the whole payload. It returns the number of bytes
+// written; on error that is the count accepted by w before the failure.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) WriteTo(w io.Writer) (int64, error) {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	cw := &countingWriter{w: w}
+	err := c.writeText(cw)
+	return cw.n, err
+}
+
+// countingWriter counts the bytes accepted by the wrapped writer
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// writeText writes the header line followed by the pipe-joined values
+func (c *ValueContainer) writeText(w io.Writer) error {
+	c.serializationCount.Add(1)
+
 	// Header: sourceID|sourceSubID|targetID|targetSubID|messageType|version
-	header := fmt.Sprintf("%s|%s|%s|%s|%s|%s",
-		c.sourceID, c.sourceSubID, c.targetID, c.targetSubID,
-		c.messageType, c.version)
+	// Each field is escaped so that '|' and newlines cannot break the layout
+	if _, err := fmt.Fprintf(w, "%s|%s|%s|%s|%s|%s\n",
+		EscapeTextField(c.sourceID), EscapeTextField(c.sourceSubID),
+		EscapeTextField(c.targetID), EscapeTextField(c.targetSubID),
+		EscapeTextField(c.messageType), EscapeTextField(c.version)); err != nil {
+		return err
+	}
 
 	// Values
-	valueStrs := make([]string, len(c.units))
 	for i, unit := range c.units {
 		valStr, err := unit.Serialize()
 		if err != nil {
-			return "", err
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "|"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, valStr); err != nil {
+			return err
 		}
-		valueStrs[i] = valStr
 	}
-
-	data := strings.Join(valueStrs, "|")
-	return fmt.Sprintf("%s\n%s", header, data), nil
+	return nil
 }
 
 // SerializeArray serializes the container to byte array
@@ -279,13 +619,14 @@ func (c *ValueContainer) Deserialize(data string) error {
 	fmt.Fprintln(os.Stderr, "         Use wireprotocol.DeserializeCppWire() for cross-language compatibility.")
 	fmt.Fprintln(os.Stderr, "         See: https://github.com/kcenon/container_system/blob/main/MIGRATION_GUIDE.md")
 
-	lines := strings.Split(data, "\n")
+	// Newlines inside fields are escaped, so the first raw newline ends the header
+	lines := strings.SplitN(data, "\n", 2)
 	if len(lines) < 1 {
 		return fmt.Errorf("invalid data format")
 	}
 
 	// Parse header
-	headerParts := strings.Split(lines[0], "|")
+	headerParts := SplitTextFields(lines[0])
 	if len(headerParts) >= 6 {
 		c.sourceID = headerParts[0]
 		c.sourceSubID = headerParts[1]
@@ -323,6 +664,8 @@ func (c *ValueContainer) ToXML() (string, error) {
 	fmt.Fprintln(os.Stderr, "         Use wireprotocol.SerializeCppWire() for cross-language compatibility.")
 	fmt.Fprintln(os.Stderr, "         See: https://github.com/kcenon/container_system/blob/main/MIGRATION_GUIDE.md")
 
+	c.serializationCount.Add(1)
+
 	type XMLContainer struct {
 		XMLName     xml.Name `xml:"container"`
 		SourceID    string   `xml:"source_id"`
@@ -331,7 +674,9 @@ func (c *ValueContainer) ToXML() (string, error) {
 		TargetSubID string   `xml:"target_sub_id"`
 		MessageType string   `xml:"message_type"`
 		Version     string   `xml:"version"`
-		Values      []string `xml:"values>value"`
+		Values      struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"values"`
 	}
 
 	xmlCont := XMLContainer{
@@ -341,16 +686,18 @@ func (c *ValueContainer) ToXML() (string, error) {
 		TargetSubID: c.targetSubID,
 		MessageType: c.messageType,
 		Version:     c.version,
-		Values:      make([]string, 0),
 	}
 
+	// Embed each value's XML element as-is, so FromXML can parse it back
+	var values strings.Builder
 	for _, unit := range c.units {
 		unitXML, err := unit.ToXML()
 		if err != nil {
 			return "", err
 		}
-		xmlCont.Values = append(xmlCont.Values, unitXML)
+		values.WriteString(unitXML)
 	}
+	xmlCont.Values.Inner = values.String()
 
 	data, err := xml.MarshalIndent(xmlCont, "", "  ")
 	if err != nil {
@@ -359,7 +706,8 @@ func (c *ValueContainer) ToXML() (string, error) {
 	return string(data), nil
 }
 
-// ToJSON converts to JSON representation
+// ToJSON converts to JSON representation. A NaN or infinite float fails
+// with ErrNonFiniteFloat; use ToJSONWith to choose another NonFinitePolicy.
 //
 // DEPRECATED: Use wireprotocol.SerializeCppWire() instead for cross-language compatibility.
 // JSON format is not compatible with C++/Python/Rust systems and will be removed in version 2.0.0 (July 2025).
@@ -371,31 +719,87 @@ func (c *ValueContainer) ToJSON() (string, error) {
 	fmt.Fprintln(os.Stderr, "         Use wireprotocol.SerializeCppWire() for cross-language compatibility.")
 	fmt.Fprintln(os.Stderr, "         See: https://github.com/kcenon/container_system/blob/main/MIGRATION_GUIDE.md")
 
-	jsonCont := map[string]interface{}{
-		"source_id":     c.sourceID,
-		"source_sub_id": c.sourceSubID,
-		"target_id":     c.targetID,
-		"target_sub_id": c.targetSubID,
-		"message_type":  c.messageType,
-		"version":       c.version,
-		"values":        make([]map[string]interface{}, 0),
+	return c.ToJSONWithOptions("  ")
+}
+
+// ToJSONCompact converts to JSON without indentation or newlines, for wire
+// payloads. The document is otherwise identical to ToJSON and can be read
+// back with FromJSON.
+func (c *ValueContainer) ToJSONCompact() (string, error) {
+	return c.ToJSONWithOptions("")
+}
+
+// jsonContainerOutput is the document written by ToJSONWithOptions. Fields
+// are declared in alphabetical key order, the order the earlier map-based
+// encoding produced, so the output is byte-stable and unchanged.
+type jsonContainerOutput struct {
+	CreatedAt   *int64            `json:"created_at,omitempty"`
+	MessageType string            `json:"message_type"`
+	SourceID    string            `json:"source_id"`
+	SourceSubID string            `json:"source_sub_id"`
+	TargetID    string            `json:"target_id"`
+	TargetSubID string            `json:"target_sub_id"`
+	Values      []json.RawMessage `json:"values"`
+	Version     string            `json:"version"`
+}
+
+// ToJSONWithOptions converts to JSON, indenting nested elements with indent.
+// An empty indent produces the compact form of ToJSONCompact; ToJSON uses
+// two spaces.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) ToJSONWithOptions(indent string) (string, error) {
+	return c.ToJSONWith(JSONOptions{Indent: indent})
+}
+
+// ToJSONWith converts to JSON as configured by opts; see JSONOptions.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) ToJSONWith(opts JSONOptions) (string, error) {
+	if opts.NonFinite < NaNAsError || opts.NonFinite > NaNAsNull {
+		return "", fmt.Errorf("unknown non-finite policy: %d", int(opts.NonFinite))
+	}
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+
+	c.serializationCount.Add(1)
+
+	jsonCont := jsonContainerOutput{
+		MessageType: c.messageType,
+		SourceID:    c.sourceID,
+		SourceSubID: c.sourceSubID,
+		TargetID:    c.targetID,
+		TargetSubID: c.targetSubID,
+		Values:      make([]json.RawMessage, 0, len(c.units)),
+		Version:     c.version,
+	}
+	if !c.createdAt.IsZero() {
+		ms := c.createdAt.UnixMilli()
+		jsonCont.CreatedAt = &ms
 	}
 
-	values := make([]map[string]interface{}, 0)
 	for _, unit := range c.units {
 		unitJSON, err := unit.ToJSON()
 		if err != nil {
 			return "", err
 		}
-		var unitMap map[string]interface{}
-		if err := json.Unmarshal([]byte(unitJSON), &unitMap); err != nil {
-			return "", err
+		if opts.NonFinite != NaNAsString && firstNonFiniteFloat(unit) != nil {
+			if unitJSON, err = applyNonFinitePolicy(unit, unitJSON, opts.NonFinite); err != nil {
+				return "", err
+			}
 		}
-		values = append(values, unitMap)
+		// Embed the value JSON as-is so 64-bit integers keep their precision;
+		// the encoder re-indents or compacts it to match the document
+		jsonCont.Values = append(jsonCont.Values, json.RawMessage(unitJSON))
 	}
-	jsonCont["values"] = values
 
-	data, err := json.MarshalIndent(jsonCont, "", "  ")
+	var data []byte
+	var err error
+	if opts.Indent == "" {
+		data, err = json.Marshal(jsonCont)
+	} else {
+		data, err = json.MarshalIndent(jsonCont, "", opts.Indent)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -414,34 +818,85 @@ func (c *ValueContainer) ToMessagePack() ([]byte, error) {
 	fmt.Fprintln(os.Stderr, "         Use wireprotocol.SerializeCppWire() for cross-language compatibility.")
 	fmt.Fprintln(os.Stderr, "         See: https://github.com/kcenon/container_system/blob/main/MIGRATION_GUIDE.md")
 
-	// Create a map structure for MessagePack
-	mpData := map[string]interface{}{
-		"source_id":     c.sourceID,
-		"source_sub_id": c.sourceSubID,
-		"target_id":     c.targetID,
-		"target_sub_id": c.targetSubID,
-		"message_type":  c.messageType,
-		"version":       c.version,
-		"values":        make([]map[string]interface{}, 0),
+	var buf bytes.Buffer
+	buf.Grow(c.sizeHint(messagePackValueOverhead))
+	if err := c.WriteMessagePackTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteMessagePackTo streams the container to w in the same MessagePack
+// layout as ToMessagePack, encoding each value as it is written instead of
+// building the whole payload in memory.
+//
+// Values are {name, type, data} maps. The data is a native MessagePack
+// object for the value type (int, uint, float, bool, str, bin or timestamp),
+// or an array of value maps for containers and arrays.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) WriteMessagePackTo(w io.Writer) error {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+
+	c.serializationCount.Add(1)
+
+	enc := msgpack.NewEncoder(w)
+
+	// Header map: 6 header fields [+ created_at] + values
+	mapLen := 7
+	if !c.createdAt.IsZero() {
+		mapLen++
+	}
+	if err := enc.EncodeMapLen(mapLen); err != nil {
+		return err
+	}
+	header := [][2]string{
+		{"source_id", c.sourceID},
+		{"source_sub_id", c.sourceSubID},
+		{"target_id", c.targetID},
+		{"target_sub_id", c.targetSubID},
+		{"message_type", c.messageType},
+		{"version", c.version},
+	}
+	for _, field := range header {
+		if err := enc.EncodeString(field[0]); err != nil {
+			return err
+		}
+		if err := enc.EncodeString(field[1]); err != nil {
+			return err
+		}
+	}
+	if !c.createdAt.IsZero() {
+		if err := enc.EncodeString("created_at"); err != nil {
+			return err
+		}
+		if err := enc.EncodeInt(c.createdAt.UnixMilli()); err != nil {
+			return err
+		}
 	}
 
-	// Serialize each value
-	values := make([]map[string]interface{}, 0)
+	// Values: array of {name, type, data} maps (see writeMessagePackValue)
+	if err := enc.EncodeString("values"); err != nil {
+		return err
+	}
+	if err := enc.EncodeArrayLen(len(c.units)); err != nil {
+		return err
+	}
 	for _, unit := range c.units {
-		valueData := map[string]interface{}{
-			"name": unit.Name(),
-			"type": unit.Type().String(),
-			"data": unit.Data(),
+		if err := writeMessagePackValue(enc, unit); err != nil {
+			return err
 		}
-		values = append(values, valueData)
 	}
-	mpData["values"] = values
 
-	// Marshal to MessagePack
-	return msgpack.Marshal(mpData)
+	return nil
 }
 
-// FromMessagePack deserializes from MessagePack binary format
+// FromMessagePack deserializes from MessagePack binary format, rebuilding
+// typed values through the shared factory. Values written with native
+// MessagePack scalars (see WriteMessagePackTo) and values in the legacy
+// layout, whose data is the raw payload as bin, are both read.
 //
 // DEPRECATED: Use wireprotocol.DeserializeCppWire() instead for cross-language compatibility.
 // MessagePack format is not compatible with C++/Python/Rust systems and will be removed in version 2.0.0 (July 2025).
@@ -458,6 +913,19 @@ func (c *ValueContainer) FromMessagePack(data []byte) error {
 		return err
 	}
 
+	var units []Value
+	if items, ok := mpData["values"].([]interface{}); ok {
+		var err error
+		if units, err = valuesFromMessagePack(items); err != nil {
+			return err
+		}
+	}
+
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
 	// Extract header fields
 	if val, ok := mpData["source_id"].(string); ok {
 		c.sourceID = val
@@ -477,21 +945,45 @@ func (c *ValueContainer) FromMessagePack(data []byte) error {
 	if val, ok := mpData["version"].(string); ok {
 		c.version = val
 	}
+	c.createdAt = time.Time{}
+	if ms, ok := messagePackInt64(mpData["created_at"]); ok {
+		c.createdAt = time.UnixMilli(ms).UTC()
+	}
 
-	// TODO: Deserialize values
-	// This would require a value factory to create values based on type
-
+	c.units = units
+	if c.units == nil {
+		c.units = make([]Value, 0)
+	}
+	c.reindex()
 	return nil
 }
 
-// SaveToFile saves the container to a file
+// SaveToFile saves the container to a file, streaming it with WriteTo
 func (c *ValueContainer) SaveToFile(filePath string) error {
-	data, err := c.SerializeArray()
+	// Log deprecation warning to stderr; the file uses the legacy Serialize() format
+	fmt.Fprintln(os.Stderr, "WARNING: ValueContainer.Serialize() is deprecated and will be removed in v2.0.0 (July 2025).")
+	fmt.Fprintln(os.Stderr, "         Use wireprotocol.SerializeCppWire() for cross-language compatibility.")
+	fmt.Fprintln(os.Stderr, "         See: https://github.com/kcenon/container_system/blob/main/MIGRATION_GUIDE.md")
+
+	return writeFile(filePath, func(w io.Writer) error {
+		_, err := c.WriteTo(w)
+		return err
+	})
+}
+
+// writeFile creates filePath and streams its content with write
+func writeFile(filePath string, write func(w io.Writer) error) error {
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
-		return fmt.Errorf("serialization failed: %w", err)
+		return fmt.Errorf("file write failed: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("file write failed: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
 		return fmt.Errorf("file write failed: %w", err)
 	}
 
@@ -512,18 +1004,15 @@ func (c *ValueContainer) LoadFromFile(filePath string) error {
 	return nil
 }
 
-// SaveToFileMessagePack saves the container to a file in MessagePack format
+// SaveToFileMessagePack saves the container to a file in MessagePack format,
+// streaming it with WriteMessagePackTo
 func (c *ValueContainer) SaveToFileMessagePack(filePath string) error {
-	data, err := c.ToMessagePack()
-	if err != nil {
-		return fmt.Errorf("messagepack serialization failed: %w", err)
-	}
-
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("file write failed: %w", err)
-	}
+	// Log deprecation warning to stderr; the file uses the ToMessagePack() format
+	fmt.Fprintln(os.Stderr, "WARNING: ValueContainer.ToMessagePack() is deprecated and will be removed in v2.0.0 (July 2025).")
+	fmt.Fprintln(os.Stderr, "         Use wireprotocol.SerializeCppWire() for cross-language compatibility.")
+	fmt.Fprintln(os.Stderr, "         See: https://github.com/kcenon/container_system/blob/main/MIGRATION_GUIDE.md")
 
-	return nil
+	return writeFile(filePath, c.WriteMessagePackTo)
 }
 
 // LoadFromFileMessagePack loads the container from a MessagePack file
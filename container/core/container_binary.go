@@ -0,0 +1,369 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// containerHeaderFieldCount is the number of header strings in the binary format
+const containerHeaderFieldCount = 6
+
+// SerializeBinary serializes the container to the binary container format.
+//
+// Binary format (little-endian):
+//   - Version byte (BinaryVersion)
+//   - Header fields: sourceID, sourceSubID, targetID, targetSubID,
+//     messageType, version, each as length (4 bytes) + UTF-8 data
+//   - Number of values (4 bytes, uint32)
+//   - Each value as its ToBytes() frame:
+//     [type:1][name_len:4][name][value_size:4][payload]
+//
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) SerializeBinary() ([]byte, error) {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+
+	// Build the payload in a pooled scratch buffer and copy it out once, so
+	// repeated serialization does not regrow a fresh buffer every time
+	scratch := getScratch()
+	defer putScratch(scratch)
+
+	buf, err := c.appendBinary(*scratch)
+	*scratch = buf
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, len(buf))
+	copy(result, buf)
+	return result, nil
+}
+
+// Size returns the length of the container in the binary container format
+// (see SerializeBinary): the version byte, the length-prefixed header fields,
+// the value count and every value's SerializedSize. The other serializers
+// use it as a capacity hint to pre-grow their buffers.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) Size() int {
+	return c.sizeHint(0)
+}
+
+// messagePackValueOverhead approximates the bytes MessagePack adds to a
+// value frame: the map header, the "name"/"type"/"data" keys and the type
+// string
+const messagePackValueOverhead = 24
+
+// sizeHint returns Size plus perValue bytes for every value
+func (c *ValueContainer) sizeHint(perValue int) int {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	size := 1 + 4*containerHeaderFieldCount + 4 +
+		len(c.sourceID) + len(c.sourceSubID) + len(c.targetID) +
+		len(c.targetSubID) + len(c.messageType) + len(c.version)
+	for _, unit := range c.units {
+		size += unit.SerializedSize() + perValue
+	}
+	return size
+}
+
+// appendBinary appends the binary container format to dst.
+// The caller must hold the read lock when thread-safe.
+func (c *ValueContainer) appendBinary(dst []byte) ([]byte, error) {
+	c.serializationCount.Add(1)
+	dst = append(dst, BinaryVersion)
+
+	header := [containerHeaderFieldCount]string{
+		c.sourceID, c.sourceSubID, c.targetID, c.targetSubID, c.messageType, c.version,
+	}
+	for _, field := range header {
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(len(field)))
+		dst = append(dst, field...)
+	}
+
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(c.units)))
+
+	var err error
+	for _, unit := range c.units {
+		if dst, err = AppendValueFrame(dst, unit); err != nil {
+			return dst, fmt.Errorf("serialize value %q: %w", unit.Name(), err)
+		}
+	}
+	return dst, nil
+}
+
+// WriteBinaryTo streams the container to w in the binary container format
+// (see SerializeBinary), writing each value frame as it is serialized.
+// It returns the number of bytes written.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) WriteBinaryTo(w io.Writer) (int64, error) {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	c.serializationCount.Add(1)
+
+	cw := &countingWriter{w: w}
+
+	if _, err := cw.Write([]byte{BinaryVersion}); err != nil {
+		return cw.n, err
+	}
+
+	header := [containerHeaderFieldCount]string{
+		c.sourceID, c.sourceSubID, c.targetID, c.targetSubID, c.messageType, c.version,
+	}
+	for _, field := range header {
+		if err := writeUint32(cw, uint32(len(field))); err != nil {
+			return cw.n, err
+		}
+		if _, err := io.WriteString(cw, field); err != nil {
+			return cw.n, err
+		}
+	}
+
+	if err := writeUint32(cw, uint32(len(c.units))); err != nil {
+		return cw.n, err
+	}
+
+	scratch := getScratch()
+	defer putScratch(scratch)
+
+	for _, unit := range c.units {
+		frame, err := AppendValueFrame((*scratch)[:0], unit)
+		*scratch = frame
+		if err != nil {
+			return cw.n, fmt.Errorf("serialize value %q: %w", unit.Name(), err)
+		}
+		if _, err := cw.Write(frame); err != nil {
+			return cw.n, err
+		}
+	}
+
+	return cw.n, nil
+}
+
+// PartialReadError is returned by ReadContainerFrom when reading fails after
+// the header has been decoded. Container holds the header and the values
+// decoded before the failure, so callers can retry or use the partial result.
+type PartialReadError struct {
+	Container  *ValueContainer
+	ValuesRead int // number of values decoded before the failure
+	Err        error
+}
+
+// Error implements the error interface
+func (e *PartialReadError) Error() string {
+	return fmt.Sprintf("partial container read after %d values: %v", e.ValuesRead, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *PartialReadError) Unwrap() error {
+	return e.Err
+}
+
+// ReadContainerFrom decodes one container in the binary container format
+// (see SerializeBinary) incrementally from r.
+//
+// Short reads are retried until each field is complete, so r may be a
+// network connection. The reader is consumed up to the end of the container
+// and no further. A stream that ends before the container is complete yields
+// an error matching io.ErrUnexpectedEOF (use errors.Is); an empty stream
+// yields io.EOF, so consecutive containers can be read until io.EOF.
+// A failure after the header is reported as a *PartialReadError.
+// Values are rebuilt with the shared value factory (see NewValueFromData).
+func ReadContainerFrom(r io.Reader) (*ValueContainer, error) {
+	return readContainer(r)
+}
+
+// ReadContainerFromContext is like ReadContainerFrom but stops when ctx is
+// done, returning ctx.Err() (wrapped in a *PartialReadError once the header
+// has been read).
+//
+// The context is checked before every read. If r has a SetReadDeadline
+// method (such as net.Conn), the deadline is also moved into the past on
+// cancellation so that a read blocked on a stalled peer returns promptly.
+func ReadContainerFromContext(ctx context.Context, r io.Reader) (*ValueContainer, error) {
+	if dr, ok := r.(interface{ SetReadDeadline(time.Time) error }); ok {
+		stop := context.AfterFunc(ctx, func() {
+			dr.SetReadDeadline(time.Unix(1, 0))
+		})
+		defer stop()
+	}
+	return readContainer(&contextReader{ctx: ctx, r: r})
+}
+
+// contextReader fails reads once its context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := cr.r.Read(p)
+	if err != nil && cr.ctx.Err() != nil {
+		// Report cancellation rather than the deadline error it caused
+		return n, cr.ctx.Err()
+	}
+	return n, err
+}
+
+// readContainer implements ReadContainerFrom
+func readContainer(r io.Reader) (*ValueContainer, error) {
+	var version [1]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return nil, err
+	}
+	if version[0] != BinaryVersion {
+		return nil, fmt.Errorf("unsupported binary version: %d", version[0])
+	}
+
+	header, err := readHeaderFields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := readUint32(r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	container := NewValueContainer()
+	container.SetHeader(header)
+
+	for i := uint32(0); i < count; i++ {
+		value, err := readValueFrame(r)
+		if err != nil {
+			return nil, &PartialReadError{
+				Container:  container,
+				ValuesRead: int(i),
+				Err:        fmt.Errorf("value %d: %w", i, unexpectedEOF(err)),
+			}
+		}
+		container.AddValue(value)
+	}
+
+	return container, nil
+}
+
+// readHeaderFields reads the six length-prefixed header fields that follow
+// the version byte
+func readHeaderFields(r io.Reader) (Header, error) {
+	var fields [containerHeaderFieldCount]string
+	for i := range fields {
+		field, err := readLengthPrefixed(r)
+		if err != nil {
+			return Header{}, unexpectedEOF(err)
+		}
+		fields[i] = string(field)
+	}
+	return Header{
+		SourceID:    fields[0],
+		SourceSubID: fields[1],
+		TargetID:    fields[2],
+		TargetSubID: fields[3],
+		MessageType: fields[4],
+		Version:     fields[5],
+	}, nil
+}
+
+// DeserializeBinary replaces the container's header and values with those
+// decoded from data in the binary container format
+func (c *ValueContainer) DeserializeBinary(data []byte) error {
+	decoded, err := ReadContainerFrom(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.sourceID = decoded.sourceID
+	c.sourceSubID = decoded.sourceSubID
+	c.targetID = decoded.targetID
+	c.targetSubID = decoded.targetSubID
+	c.messageType = decoded.messageType
+	c.version = decoded.version
+	c.units = decoded.units
+	c.reindex()
+	return nil
+}
+
+// readValueFrame reads one [type:1][name_len:4][name][value_size:4][payload]
+// frame and rebuilds the value with the shared factory
+func readValueFrame(r io.Reader) (Value, error) {
+	var vtype [1]byte
+	if _, err := io.ReadFull(r, vtype[:]); err != nil {
+		return nil, err
+	}
+	name, err := readLengthPrefixed(r)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := readLengthPrefixed(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewValueFromData(string(name), ValueType(vtype[0]), payload)
+}
+
+// readLengthPrefixed reads a 4-byte little-endian length followed by that
+// many bytes. The buffer grows as data arrives rather than trusting the
+// declared length up front, so a corrupt length cannot force a huge allocation.
+func readLengthPrefixed(r io.Reader) ([]byte, error) {
+	n, err := readUint32(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, int64(n)))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < int(n) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
+func readUint32(r io.Reader) (uint32, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(buf[:]), nil
+}
+
+func writeUint32(w io.Writer, v uint32) error {
+	_, err := w.Write([]byte{
+		byte(v & 0xFF),
+		byte((v >> 8) & 0xFF),
+		byte((v >> 16) & 0xFF),
+		byte((v >> 24) & 0xFF),
+	})
+	return err
+}
+
+// unexpectedEOF converts io.EOF inside a container into io.ErrUnexpectedEOF
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ToMap converts the values to a map keyed by value name, for code that
+// works with map[string]interface{}. Containers and maps become nested maps
+// (maps keyed by entry key) and arrays become []interface{}. Scalars map to
+// Go types by value type:
+//
+//	null                          nil
+//	bool                          bool
+//	short, int, long, llong       int64
+//	ushort, uint, ulong, ullong   uint64
+//	float, double                 float64
+//	string, decimal, uuid         string (decimal and uuid in text form)
+//	bytes                         []byte (copied)
+//	datetime                      time.Time (UTC)
+//
+// The header is not included. When names repeat, the last value wins.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) ToMap() map[string]interface{} {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return valuesToMap(c.units)
+}
+
+// valuesToMap converts values to a map keyed by name
+func valuesToMap(units []Value) map[string]interface{} {
+	m := make(map[string]interface{}, len(units))
+	for _, unit := range units {
+		m[unit.Name()] = nativeValue(unit)
+	}
+	return m
+}
+
+// nativeValue converts a value to the Go type listed in ToMap
+func nativeValue(v Value) interface{} {
+	le := binary.LittleEndian
+	data := v.Data()
+	switch v.Type() {
+	case NullValue:
+		return nil
+	case BoolValue:
+		if len(data) == 1 {
+			return data[0] != 0
+		}
+	case ShortValue:
+		if len(data) == 2 {
+			return int64(int16(le.Uint16(data)))
+		}
+	case UShortValue:
+		if len(data) == 2 {
+			return uint64(le.Uint16(data))
+		}
+	case IntValue, LongValue:
+		if len(data) == 4 {
+			return int64(int32(le.Uint32(data)))
+		}
+	case UIntValue, ULongValue:
+		if len(data) == 4 {
+			return uint64(le.Uint32(data))
+		}
+	case LLongValue:
+		if len(data) == 8 {
+			return int64(le.Uint64(data))
+		}
+	case ULLongValue:
+		if len(data) == 8 {
+			return le.Uint64(data)
+		}
+	case FloatValue:
+		if len(data) == 4 {
+			return float64(math.Float32frombits(le.Uint32(data)))
+		}
+	case DoubleValue:
+		if len(data) == 8 {
+			return math.Float64frombits(le.Uint64(data))
+		}
+	case StringValue:
+		return string(data)
+	case DateTimeValue:
+		if len(data) == 8 {
+			return time.Unix(0, int64(le.Uint64(data))).UTC()
+		}
+	case DecimalValue, UUIDValue:
+		if s, err := v.ToString(); err == nil {
+			return s
+		}
+	case ContainerValue:
+		return valuesToMap(v.Children())
+	case ArrayValue:
+		var elements []Value
+		if holder, ok := v.(elementHolder); ok {
+			elements = holder.Elements()
+		}
+		list := make([]interface{}, len(elements))
+		for i, element := range elements {
+			list[i] = nativeValue(element)
+		}
+		return list
+	case MapValue:
+		entries, _ := mapEntries(v)
+		m := make(map[string]interface{}, len(entries))
+		for _, entry := range entries {
+			m[entry.key] = nativeValue(entry.value)
+		}
+		return m
+	}
+	return append([]byte(nil), data...)
+}
+
+// ContainerFromMap builds a container with the given message type from a
+// map, inferring each value type from the Go type of the map entry:
+//
+//	nil                            NullValue
+//	bool                           BoolValue
+//	int, int8 .. int64             LLongValue (Int64Value)
+//	uint, uint8 .. uint64          ULLongValue (UInt64Value)
+//	float32                        FloatValue
+//	float64                        DoubleValue (Float64Value)
+//	string                         StringValue
+//	[]byte                         BytesValue
+//	time.Time                      DateTimeValue
+//	[]interface{}                  ArrayValue
+//	map[string]interface{}         ContainerValue
+//
+// Map keys are added in sorted order, since Go maps are unordered, and
+// become value names; array elements are unnamed. Any other Go type yields
+// an error wrapping ErrTypeConversion. Building values requires the shared
+// value factory (import the values package).
+func ContainerFromMap(m map[string]interface{}, messageType string) (*ValueContainer, error) {
+	units, err := valuesFromMap(m)
+	if err != nil {
+		return nil, err
+	}
+	container := NewValueContainerWithType(messageType)
+	container.units = units
+	return container, nil
+}
+
+// valuesFromMap converts map entries to values in sorted key order
+func valuesFromMap(m map[string]interface{}) ([]Value, error) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	units := make([]Value, 0, len(keys))
+	for _, key := range keys {
+		value, err := valueFromNative(key, m[key])
+		if err != nil {
+			return nil, fmt.Errorf("value %q: %w", key, err)
+		}
+		units = append(units, value)
+	}
+	return units, nil
+}
+
+// valueFromNative builds a value from a Go value as listed in
+// ContainerFromMap
+func valueFromNative(name string, v interface{}) (Value, error) {
+	le := binary.LittleEndian
+	var vtype ValueType
+	var payload []byte
+	var err error
+	switch n := v.(type) {
+	case nil:
+		vtype = NullValue
+	case bool:
+		vtype, payload = BoolValue, []byte{0}
+		if n {
+			payload[0] = 1
+		}
+	case int:
+		vtype, payload = LLongValue, le.AppendUint64(nil, uint64(n))
+	case int8:
+		vtype, payload = LLongValue, le.AppendUint64(nil, uint64(n))
+	case int16:
+		vtype, payload = LLongValue, le.AppendUint64(nil, uint64(n))
+	case int32:
+		vtype, payload = LLongValue, le.AppendUint64(nil, uint64(n))
+	case int64:
+		vtype, payload = LLongValue, le.AppendUint64(nil, uint64(n))
+	case uint:
+		vtype, payload = ULLongValue, le.AppendUint64(nil, uint64(n))
+	case uint8:
+		vtype, payload = ULLongValue, le.AppendUint64(nil, uint64(n))
+	case uint16:
+		vtype, payload = ULLongValue, le.AppendUint64(nil, uint64(n))
+	case uint32:
+		vtype, payload = ULLongValue, le.AppendUint64(nil, uint64(n))
+	case uint64:
+		vtype, payload = ULLongValue, le.AppendUint64(nil, n)
+	case float32:
+		vtype, payload = FloatValue, le.AppendUint32(nil, math.Float32bits(n))
+	case float64:
+		vtype, payload = DoubleValue, le.AppendUint64(nil, math.Float64bits(n))
+	case string:
+		vtype, payload = StringValue, []byte(n)
+	case []byte:
+		vtype, payload = BytesValue, append([]byte(nil), n...)
+	case time.Time:
+		vtype, payload = DateTimeValue, le.AppendUint64(nil, uint64(n.UnixNano()))
+	case []interface{}:
+		elements := make([]Value, len(n))
+		for i, element := range n {
+			if elements[i], err = valueFromNative("", element); err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		vtype = ArrayValue
+		payload, err = nestedPayload(elements)
+	case map[string]interface{}:
+		var children []Value
+		if children, err = valuesFromMap(n); err != nil {
+			return nil, err
+		}
+		vtype = ContainerValue
+		payload, err = nestedPayload(children)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrTypeConversion, v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewValueFromData(name, vtype, payload)
+}
@@ -0,0 +1,33 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+// GetReadCount returns the number of values found by GetValue or TryGetValue.
+// The counters are atomic, so they are accurate without EnableThreadSafe.
+func (c *ValueContainer) GetReadCount() uint64 {
+	return c.readCount.Load()
+}
+
+// GetWriteCount returns the number of values added with AddValue.
+func (c *ValueContainer) GetWriteCount() uint64 {
+	return c.writeCount.Load()
+}
+
+// GetSerializationCount returns the number of times the container was
+// serialized: to text (Serialize, SerializeText, WriteTo), JSON, XML,
+// MessagePack, the binary format or protobuf.
+func (c *ValueContainer) GetSerializationCount() uint64 {
+	return c.serializationCount.Load()
+}
+
+// ResetStatistics resets all statistics to zero.
+func (c *ValueContainer) ResetStatistics() {
+	c.readCount.Store(0)
+	c.writeCount.Store(0)
+	c.serializationCount.Store(0)
+}
@@ -0,0 +1,117 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// MaxDecimalScale bounds the magnitude of a decimal scale. Rendering a
+// decimal pads with up to |scale| zeros, so an unbounded scale read from
+// untrusted input could demand gigabytes for a 9-byte payload.
+const MaxDecimalScale = 4096
+
+// ParseDecimal parses a decimal such as "123.45", "-0.001" or "+7" into its
+// unscaled integer and scale (the number of digits after the point), so
+// "1.50" yields 150 with scale 2.
+func ParseDecimal(s string) (*big.Int, int32, error) {
+	digits := s
+	negative := false
+	if digits != "" && (digits[0] == '-' || digits[0] == '+') {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" {
+		return nil, 0, fmt.Errorf("invalid decimal %q: no digits", s)
+	}
+	for _, part := range []string{intPart, fracPart} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return nil, 0, fmt.Errorf("invalid decimal %q: unexpected character %q", s, r)
+			}
+		}
+	}
+	if len(fracPart) > MaxDecimalScale {
+		return nil, 0, fmt.Errorf("invalid decimal %q: more than %d fraction digits", s, MaxDecimalScale)
+	}
+
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid decimal %q", s)
+	}
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+	return unscaled, int32(len(fracPart)), nil
+}
+
+// EncodeDecimal builds the DecimalValue payload [scale:4 LE][len:4 LE]
+// [unscaled], where unscaled is the minimal big-endian two's-complement
+// encoding of the unscaled integer (empty for zero)
+func EncodeDecimal(unscaled *big.Int, scale int32) []byte {
+	var raw []byte
+	switch unscaled.Sign() {
+	case 1:
+		raw = unscaled.Bytes()
+		if raw[0]&0x80 != 0 {
+			raw = append([]byte{0}, raw...)
+		}
+	case -1:
+		// Encode -x - 1 and invert, giving the two's complement of x
+		magnitude := new(big.Int).Neg(unscaled)
+		magnitude.Sub(magnitude, big.NewInt(1))
+		raw = magnitude.Bytes()
+		for i := range raw {
+			raw[i] = ^raw[i]
+		}
+		if len(raw) == 0 || raw[0]&0x80 == 0 {
+			raw = append([]byte{0xFF}, raw...)
+		}
+	}
+
+	data := make([]byte, 8, 8+len(raw))
+	binary.LittleEndian.PutUint32(data[0:4], uint32(scale))
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(raw)))
+	return append(data, raw...)
+}
+
+// DecodeDecimal parses a payload written by EncodeDecimal. A scale whose
+// magnitude exceeds MaxDecimalScale is rejected.
+func DecodeDecimal(data []byte) (*big.Int, int32, error) {
+	if len(data) < 8 {
+		return nil, 0, fmt.Errorf("invalid payload size for decimal: expected at least 8 bytes, got %d", len(data))
+	}
+	scale := int32(binary.LittleEndian.Uint32(data[0:4]))
+	if scale > MaxDecimalScale || scale < -MaxDecimalScale {
+		return nil, 0, fmt.Errorf("invalid decimal scale %d: magnitude exceeds %d", scale, MaxDecimalScale)
+	}
+	n := binary.LittleEndian.Uint32(data[4:8])
+	if uint64(n) != uint64(len(data)-8) {
+		return nil, 0, fmt.Errorf("invalid payload size for decimal: length field %d, got %d bytes", n, len(data)-8)
+	}
+
+	raw := data[8:]
+	unscaled := new(big.Int)
+	if len(raw) == 0 || raw[0]&0x80 == 0 {
+		return unscaled.SetBytes(raw), scale, nil
+	}
+
+	// Negative: the two's complement of x is ^(-x - 1)
+	inverted := make([]byte, len(raw))
+	for i, b := range raw {
+		inverted[i] = ^b
+	}
+	unscaled.SetBytes(inverted)
+	unscaled.Add(unscaled, big.NewInt(1))
+	return unscaled.Neg(unscaled), scale, nil
+}
@@ -0,0 +1,123 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "fmt"
+
+// ValueDiff is a single difference reported by Diff.
+// Old is nil for additions and New is nil for removals.
+type ValueDiff struct {
+	Path string     // e.g. "customer.tags[1]"; repeated names are suffixed with [n]
+	Kind ChangeKind // ChangeAdded, ChangeRemoved or ChangeModified
+	Old  Value
+	New  Value
+}
+
+// String renders the diff for display, e.g. `~ customer.name: "Kim" -> "Lee"`
+func (d ValueDiff) String() string {
+	switch d.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", d.Path, displayValue(d.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", d.Path, displayValue(d.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, displayValue(d.Old), displayValue(d.New))
+	}
+}
+
+// Diff lists the value differences from c to other, recursing into
+// ContainerValue children, ArrayValue elements and MapValue entries. Header
+// fields are not compared; use Header() for that.
+//
+// Named values are matched as in Changelog: by name, and by position among
+// values that share a name. Array elements are matched by index and map
+// entries by key. A value whose type changed is reported as modified
+// without recursing into it. Differences are ordered as in Changelog at each
+// level. A nil container is treated as empty.
+func (c *ValueContainer) Diff(other *ValueContainer) []ValueDiff {
+	diffs := make([]ValueDiff, 0)
+	return diffEntries(diffs, "", changelogValues(c), changelogValues(other))
+}
+
+// diffEntries appends the differences between two lists of named values
+func diffEntries(diffs []ValueDiff, prefix string, oldEntries, newEntries []changelogEntry) []ValueDiff {
+	newByField := make(map[string]Value, len(newEntries))
+	for _, entry := range newEntries {
+		newByField[entry.field] = entry.value
+	}
+	oldFields := make(map[string]bool, len(oldEntries))
+
+	for _, entry := range oldEntries {
+		oldFields[entry.field] = true
+		path := diffPath(prefix, entry.field)
+		newValue, ok := newByField[entry.field]
+		if !ok {
+			diffs = append(diffs, ValueDiff{Path: path, Kind: ChangeRemoved, Old: entry.value})
+			continue
+		}
+		diffs = diffValues(diffs, path, entry.value, newValue)
+	}
+
+	for _, entry := range newEntries {
+		if !oldFields[entry.field] {
+			diffs = append(diffs, ValueDiff{Path: diffPath(prefix, entry.field), Kind: ChangeAdded, New: entry.value})
+		}
+	}
+	return diffs
+}
+
+// diffValues appends the differences between two values at the same path
+func diffValues(diffs []ValueDiff, path string, oldValue, newValue Value) []ValueDiff {
+	if oldValue.Type() == newValue.Type() {
+		switch oldValue.Type() {
+		case ContainerValue:
+			return diffEntries(diffs, path, fieldEntries(oldValue.Children()), fieldEntries(newValue.Children()))
+		case ArrayValue:
+			oldHolder, oldOK := oldValue.(elementHolder)
+			newHolder, newOK := newValue.(elementHolder)
+			if oldOK && newOK {
+				return diffElements(diffs, path, oldHolder.Elements(), newHolder.Elements())
+			}
+		case MapValue:
+			_, oldOK := oldValue.(mapHolder)
+			_, newOK := newValue.(mapHolder)
+			if oldOK && newOK {
+				return diffEntries(diffs, path, mapChangelogEntries(oldValue), mapChangelogEntries(newValue))
+			}
+		}
+	}
+
+	if !ValuesEqual(oldValue, newValue) {
+		diffs = append(diffs, ValueDiff{Path: path, Kind: ChangeModified, Old: oldValue, New: newValue})
+	}
+	return diffs
+}
+
+// diffElements appends the differences between two arrays, matched by index
+func diffElements(diffs []ValueDiff, path string, oldElements, newElements []Value) []ValueDiff {
+	for i, oldElement := range oldElements {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		if i >= len(newElements) {
+			diffs = append(diffs, ValueDiff{Path: elementPath, Kind: ChangeRemoved, Old: oldElement})
+			continue
+		}
+		diffs = diffValues(diffs, elementPath, oldElement, newElements[i])
+	}
+	for i := len(oldElements); i < len(newElements); i++ {
+		diffs = append(diffs, ValueDiff{Path: fmt.Sprintf("%s[%d]", path, i), Kind: ChangeAdded, New: newElements[i]})
+	}
+	return diffs
+}
+
+// diffPath joins a parent path and a field name with a dot
+func diffPath(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	return prefix + "." + field
+}
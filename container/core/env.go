@@ -0,0 +1,91 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ToEnv flattens the container into environment-style variables, e.g. for
+// the Env of an exec.Cmd. Each key is PREFIX_FIELD in upper case, with every
+// character other than a letter or digit in the prefix and names replaced
+// by '_'. Nested container
+// children extend the prefix (PREFIX_PARENT_CHILD) and array elements are
+// keyed by index (PREFIX_LIST_0).
+//
+// Values are rendered as plain strings: strings unquoted, numbers and bools
+// in decimal form, bytes as 0x-prefixed hex and null as "". When names
+// repeat, the last value wins.
+func (c *ValueContainer) ToEnv(prefix string) map[string]string {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	env := make(map[string]string)
+	for _, unit := range c.units {
+		addEnvValue(env, envKey(prefix, unit.Name()), unit)
+	}
+	return env
+}
+
+// addEnvValue adds v under key, recursing into containers and arrays
+func addEnvValue(env map[string]string, key string, v Value) {
+	switch v.Type() {
+	case ContainerValue:
+		for _, child := range v.Children() {
+			addEnvValue(env, envKey(key, child.Name()), child)
+		}
+	case ArrayValue:
+		if array, ok := v.(interface{ Elements() []Value }); ok {
+			for i, element := range array.Elements() {
+				addEnvValue(env, envKey(key, strconv.Itoa(i)), element)
+			}
+		}
+	case MapValue:
+		if entries, err := mapEntries(v); err == nil {
+			for _, entry := range entries {
+				addEnvValue(env, envKey(key, entry.key), entry.value)
+			}
+		}
+	case NullValue:
+		env[key] = ""
+	case StringValue:
+		s, _ := v.ToString()
+		env[key] = s
+	default:
+		env[key] = displayValue(v)
+	}
+}
+
+// envKey joins prefix and name into an upper-case environment variable name
+func envKey(prefix, name string) string {
+	prefix, name = envName(prefix), envName(name)
+	if prefix == "" {
+		return name
+	}
+	if name == "" {
+		return prefix
+	}
+	return prefix + "_" + name
+}
+
+// envName upper-cases s and replaces every character other than a letter or
+// digit with '_'
+func envName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -0,0 +1,100 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "bytes"
+
+// Equal reports whether other has the same header fields and the same
+// values in the same order, compared with ValuesEqual.
+// Thread-safe if EnableThreadSafe was called on either container.
+func (c *ValueContainer) Equal(other *ValueContainer) bool {
+	if c == other {
+		return true
+	}
+	if c == nil || other == nil {
+		return false
+	}
+
+	// Each container is snapshotted under its own lock, so two goroutines
+	// comparing a with b and b with a cannot deadlock
+	if c.Header() != other.Header() {
+		return false
+	}
+	return valueListsEqual(c.unitsSnapshot(), other.unitsSnapshot())
+}
+
+// EqualsOn reports whether c and other hold equal values (see ValuesEqual)
+// for each of the named top-level fields, ignoring the header and every
+// other value. All occurrences of a name are compared in order, so a field
+// absent from both containers counts as equal. With no fields it returns
+// true.
+// Thread-safe if EnableThreadSafe was called on either container.
+func (c *ValueContainer) EqualsOn(other *ValueContainer, fields ...string) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	for _, field := range fields {
+		if !valueListsEqual(c.GetValues(field), other.GetValues(field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// unitsSnapshot returns a copy of the value slice taken under the read lock
+func (c *ValueContainer) unitsSnapshot() []Value {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	units := make([]Value, len(c.units))
+	copy(units, c.units)
+	return units
+}
+
+// ValuesEqual reports whether a and b have the same name, type and payload
+// bytes. Container children and array elements are compared recursively,
+// in order. Two nil values are equal.
+func ValuesEqual(a, b Value) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.Name() != b.Name() || a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Type() {
+	case ContainerValue:
+		return valueListsEqual(a.Children(), b.Children())
+	case ArrayValue:
+		aHolder, aOK := a.(elementHolder)
+		bHolder, bOK := b.(elementHolder)
+		if aOK && bOK {
+			return valueListsEqual(aHolder.Elements(), bHolder.Elements())
+		}
+		// Arrays that hide their elements are compared by binary frame
+		aBytes, aErr := a.ToBytes()
+		bBytes, bErr := b.ToBytes()
+		return aErr == nil && bErr == nil && bytes.Equal(aBytes, bBytes)
+	default:
+		return bytes.Equal(a.Data(), b.Data())
+	}
+}
+
+// valueListsEqual compares two value slices element by element
+func valueListsEqual(a, b []Value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !ValuesEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "errors"
+
+// Sentinel errors shared by the core and values packages. Call sites wrap
+// them with %w and add context, so match them with errors.Is.
+var (
+	// ErrTypeConversion is returned when a value cannot be converted to the
+	// requested type
+	ErrTypeConversion = errors.New("type conversion not supported")
+
+	// ErrIndexOutOfRange is returned for an element index outside an array
+	ErrIndexOutOfRange = errors.New("index out of range")
+
+	// ErrTruncatedData is returned when binary input ends before a complete
+	// header, frame or entry has been read
+	ErrTruncatedData = errors.New("truncated data")
+
+	// ErrTrailingData is returned when a nested payload holds bytes after
+	// its declared elements, children or entries
+	ErrTrailingData = errors.New("trailing data")
+
+	// ErrUnsupportedVersion is returned for binary data with an unknown
+	// version byte
+	ErrUnsupportedVersion = errors.New("unsupported binary version")
+
+	// ErrNotContainer is returned when adding or removing children on a
+	// value that is not a container
+	ErrNotContainer = errors.New("not a container value")
+
+	// ErrSelfTestFailed is returned by SelfTest when a value does not
+	// survive a round trip through a serialization format
+	ErrSelfTestFailed = errors.New("serialization self-test failed")
+
+	// ErrMissingField is reported by Schema.Validate for a required field
+	// that is absent
+	ErrMissingField = errors.New("missing required field")
+
+	// ErrFieldTypeMismatch is reported by Schema.Validate for a field whose
+	// value type differs from the declared one
+	ErrFieldTypeMismatch = errors.New("field type mismatch")
+
+	// ErrMessageTypeMismatch is reported by Schema.Validate when the message
+	// type differs from the one the schema requires
+	ErrMessageTypeMismatch = errors.New("message type mismatch")
+
+	// ErrChecksumMismatch is returned when the CRC32 footer of checksummed
+	// binary data does not match its payload
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrSignatureInvalid is returned when the HMAC of signed data does not
+	// match its payload under the given key
+	ErrSignatureInvalid = errors.New("signature invalid")
+
+	// ErrNonFiniteFloat is returned by ToJSONWith under NaNAsError for a
+	// float or double value that is NaN or infinite
+	ErrNonFiniteFloat = errors.New("non-finite float value")
+
+	// ErrFrameTooLarge is returned by the wire frame readers when a frame
+	// grows past the configured maximum size before it ends
+	ErrFrameTooLarge = errors.New("frame too large")
+
+	// ErrNotBatchFile is returned by LoadContainers when a file does not
+	// start with the batch container header
+	ErrNotBatchFile = errors.New("not a container batch file")
+)
+
+// ErrUnknownType is an alias of ErrUnknownValueType
+var ErrUnknownType = ErrUnknownValueType
@@ -0,0 +1,147 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonContainerDocument mirrors the object written by ValueContainer.ToJSON
+type jsonContainerDocument struct {
+	headerDocument
+	Values []json.RawMessage `json:"values"`
+}
+
+// jsonValueDocument mirrors the object written by a value's ToJSON
+type jsonValueDocument struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Data     json.RawMessage   `json:"data"`
+	Encoding string            `json:"encoding"`
+	Children []json.RawMessage `json:"children"`
+	Elements []json.RawMessage `json:"elements"`
+}
+
+// FromJSON replaces the container's header and values with those of a JSON
+// document produced by ToJSON. Each value's "type" field selects the value
+// type, and the value is rebuilt through the shared factory, so nested
+// containers ("children") and arrays ("elements") come back typed.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) FromJSON(s string) error {
+	var doc jsonContainerDocument
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		return fmt.Errorf("invalid container JSON: %w", err)
+	}
+
+	units, err := valuesFromJSON(doc.Values)
+	if err != nil {
+		return err
+	}
+
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	header := doc.header()
+	c.sourceID = header.SourceID
+	c.sourceSubID = header.SourceSubID
+	c.targetID = header.TargetID
+	c.targetSubID = header.TargetSubID
+	c.messageType = header.MessageType
+	c.version = header.Version
+	c.createdAt = header.CreatedAt
+	c.units = units
+	c.reindex()
+	return nil
+}
+
+// valuesFromJSON decodes a list of value objects
+func valuesFromJSON(raws []json.RawMessage) ([]Value, error) {
+	units := make([]Value, 0, len(raws))
+	for i, raw := range raws {
+		value, err := valueFromJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		units = append(units, value)
+	}
+	return units, nil
+}
+
+// valueFromJSON decodes one value object into a typed value
+func valueFromJSON(raw json.RawMessage) (Value, error) {
+	var doc jsonValueDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("invalid value JSON: %w", err)
+	}
+	vtype, ok := ValueTypeFromName(doc.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownValueType, doc.Type)
+	}
+
+	payload, err := jsonPayload(vtype, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", doc.Type, doc.Name, err)
+	}
+	return NewValueFromData(doc.Name, vtype, payload)
+}
+
+// jsonPayload converts the JSON data of a value to its binary payload.
+// Scalars are read from their text form (see textPayload): JSON strings are
+// unquoted and numbers and booleans are taken literally.
+func jsonPayload(vtype ValueType, doc *jsonValueDocument) ([]byte, error) {
+	switch vtype {
+	case NullValue:
+		return nil, nil
+	case ContainerValue:
+		return nestedJSONPayload(doc.Children)
+	case ArrayValue:
+		return nestedJSONPayload(doc.Elements)
+	case MapValue:
+		return mapJSONPayload(doc.Data)
+	}
+
+	text := string(bytes.TrimSpace(doc.Data))
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(doc.Data, &text); err != nil {
+			return nil, err
+		}
+	}
+	return textPayload(vtype, text, doc.Encoding)
+}
+
+// mapJSONPayload decodes the data of a map value, an object of value
+// objects keyed by entry key, into a map payload
+func mapJSONPayload(data json.RawMessage) ([]byte, error) {
+	var raws map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, fmt.Errorf("invalid map data: %w", err)
+	}
+	entries := make([]mapEntry, 0, len(raws))
+	for key, raw := range raws {
+		value, err := valueFromJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("map entry %q: %w", key, err)
+		}
+		entries = append(entries, mapEntry{key: key, value: value})
+	}
+	return mapPayload(entries)
+}
+
+// nestedJSONPayload decodes child value objects into a container or array
+// payload
+func nestedJSONPayload(raws []json.RawMessage) ([]byte, error) {
+	children, err := valuesFromJSON(raws)
+	if err != nil {
+		return nil, err
+	}
+	return nestedPayload(children)
+}
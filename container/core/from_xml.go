@@ -0,0 +1,124 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// xmlContainerDocument mirrors the document written by ValueContainer.ToXML
+type xmlContainerDocument struct {
+	XMLName xml.Name `xml:"container"`
+	headerDocument
+	Values struct {
+		Items []xmlValueNode `xml:",any"`
+	} `xml:"values"`
+}
+
+// xmlValueNode mirrors the element written by a value's ToXML. Containers
+// and arrays nest their children's elements directly; maps nest one
+// <entry key="..."> element per entry, wrapping the entry value's element.
+type xmlValueNode struct {
+	XMLName  xml.Name
+	Name     string         `xml:"name,attr"`
+	Type     string         `xml:"type,attr"`
+	Key      string         `xml:"key,attr"`
+	Encoding string         `xml:"encoding,attr"`
+	Text     string         `xml:",chardata"`
+	Nested   []xmlValueNode `xml:",any"`
+}
+
+// FromXML replaces the container's header and values with those of an XML
+// document produced by ToXML. Each value element's type attribute selects
+// the value type, and the value is rebuilt through the shared factory, so
+// nested containers and arrays come back typed.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) FromXML(s string) error {
+	var doc xmlContainerDocument
+	if err := xml.Unmarshal([]byte(s), &doc); err != nil {
+		return fmt.Errorf("invalid container XML: %w", err)
+	}
+
+	units, err := valuesFromXML(doc.Values.Items)
+	if err != nil {
+		return err
+	}
+
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	header := doc.header()
+	c.sourceID = header.SourceID
+	c.sourceSubID = header.SourceSubID
+	c.targetID = header.TargetID
+	c.targetSubID = header.TargetSubID
+	c.messageType = header.MessageType
+	c.version = header.Version
+	c.createdAt = header.CreatedAt
+	c.units = units
+	c.reindex()
+	return nil
+}
+
+// valuesFromXML decodes a list of value elements
+func valuesFromXML(nodes []xmlValueNode) ([]Value, error) {
+	units := make([]Value, 0, len(nodes))
+	for i := range nodes {
+		value, err := valueFromXML(&nodes[i])
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		units = append(units, value)
+	}
+	return units, nil
+}
+
+// valueFromXML decodes one value element into a typed value
+func valueFromXML(node *xmlValueNode) (Value, error) {
+	vtype, ok := ValueTypeFromName(node.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownValueType, node.Type)
+	}
+
+	var payload []byte
+	var err error
+	switch vtype {
+	case ContainerValue, ArrayValue:
+		var children []Value
+		if children, err = valuesFromXML(node.Nested); err == nil {
+			payload, err = nestedPayload(children)
+		}
+	case MapValue:
+		payload, err = mapXMLPayload(node.Nested)
+	default:
+		payload, err = textPayload(vtype, node.Text, node.Encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", node.Type, node.Name, err)
+	}
+	return NewValueFromData(node.Name, vtype, payload)
+}
+
+// mapXMLPayload decodes the entry elements of a map value into a map payload
+func mapXMLPayload(nodes []xmlValueNode) ([]byte, error) {
+	entries := make([]mapEntry, 0, len(nodes))
+	for i := range nodes {
+		entry := &nodes[i]
+		if len(entry.Nested) != 1 {
+			return nil, fmt.Errorf("map entry %q: expected one value element, got %d", entry.Key, len(entry.Nested))
+		}
+		value, err := valueFromXML(&entry.Nested[0])
+		if err != nil {
+			return nil, fmt.Errorf("map entry %q: %w", entry.Key, err)
+		}
+		entries = append(entries, mapEntry{key: entry.Key, value: value})
+	}
+	return mapPayload(entries)
+}
@@ -0,0 +1,89 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "time"
+
+// Header holds the routing and identification fields of a ValueContainer
+type Header struct {
+	SourceID    string
+	SourceSubID string
+	TargetID    string
+	TargetSubID string
+	MessageType string
+	Version     string
+	// CreatedAt is the optional creation timestamp, in UTC at millisecond
+	// precision; zero when unset (see SetCreatedAt)
+	CreatedAt time.Time
+	// WireEscaped reports a C++ wire frame whose text fields are
+	// percent-encoded. It is set by wireprotocol.SplitHeaderPayload and
+	// honoured by JoinHeaderPayload; containers never store it.
+	WireEscaped bool
+}
+
+// Header returns a snapshot of the container's header fields
+func (c *ValueContainer) Header() Header {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return Header{
+		SourceID:    c.sourceID,
+		SourceSubID: c.sourceSubID,
+		TargetID:    c.targetID,
+		TargetSubID: c.targetSubID,
+		MessageType: c.messageType,
+		Version:     c.version,
+		CreatedAt:   c.createdAt,
+	}
+}
+
+// SetHeader replaces all header fields, including the version
+func (c *ValueContainer) SetHeader(h Header) {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.sourceID = h.SourceID
+	c.sourceSubID = h.SourceSubID
+	c.targetID = h.TargetID
+	c.targetSubID = h.TargetSubID
+	c.messageType = h.MessageType
+	c.version = h.Version
+	c.createdAt = normalizeCreatedAt(h.CreatedAt)
+}
+
+// SetCreatedAt sets the creation timestamp. It is stored in UTC truncated
+// to milliseconds, the precision of the epoch-milliseconds form written by
+// the wire protocol, JSON and MessagePack, so it compares equal after a
+// round trip. A zero time clears it.
+func (c *ValueContainer) SetCreatedAt(t time.Time) {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.createdAt = normalizeCreatedAt(t)
+}
+
+// CreatedAt returns the creation timestamp, or the zero time if unset
+func (c *ValueContainer) CreatedAt() time.Time {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return c.createdAt
+}
+
+// normalizeCreatedAt truncates t to milliseconds in UTC, keeping the zero
+// time as unset
+func normalizeCreatedAt(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Time{}
+	}
+	return time.UnixMilli(t.UnixMilli()).UTC()
+}
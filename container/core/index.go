@@ -0,0 +1,67 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+// EnableIndex builds a name index so that GetValue and GetValues find
+// values by name in constant time instead of scanning every value. The
+// index is kept up to date by the container's own mutators; it costs one
+// map entry per distinct name plus one int per value.
+//
+// Values are indexed by the name they had when added. Renaming a value in
+// place, or modifying the slice returned by Values(), leaves the index stale;
+// call EnableIndex again to rebuild it. Copy does not carry the index over.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) EnableIndex() {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.index = make(map[string][]int)
+	c.reindex()
+}
+
+// DisableIndex drops the name index; lookups scan the values again
+func (c *ValueContainer) DisableIndex() {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.index = nil
+}
+
+// IsIndexed reports whether the name index is enabled
+func (c *ValueContainer) IsIndexed() bool {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return c.index != nil
+}
+
+// reindex rebuilds the name index from c.units if it is enabled.
+// The caller must hold the write lock when thread-safe.
+func (c *ValueContainer) reindex() {
+	if c.index == nil {
+		return
+	}
+	clear(c.index)
+	for i := range c.units {
+		c.indexAppend(i)
+	}
+}
+
+// indexAppend adds the value at position i to the name index if it is
+// enabled. Positions of a name stay in ascending order as long as values
+// are indexed in order. The caller must hold the write lock when thread-safe.
+func (c *ValueContainer) indexAppend(i int) {
+	if c.index == nil {
+		return
+	}
+	name := c.units[i].Name()
+	c.index[name] = append(c.index[name], i)
+}
@@ -0,0 +1,223 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// NonFinitePolicy selects how ToJSONWith writes float and double values
+// that are NaN or infinite, which JSON numbers cannot represent
+type NonFinitePolicy int
+
+const (
+	// NaNAsError fails the serialization with ErrNonFiniteFloat. It is the
+	// default, since JSON numbers cannot represent the value.
+	NaNAsError NonFinitePolicy = iota
+	// NaNAsString writes "NaN", "+Inf" or "-Inf" as a JSON string, which
+	// FromJSON reads back
+	NaNAsString
+	// NaNAsNull writes null as the data, for consumers that reject the
+	// string form; the value itself is lost
+	NaNAsNull
+)
+
+// String returns the display name of the policy
+func (p NonFinitePolicy) String() string {
+	switch p {
+	case NaNAsError:
+		return "NaNAsError"
+	case NaNAsString:
+		return "NaNAsString"
+	case NaNAsNull:
+		return "NaNAsNull"
+	default:
+		return "Unknown"
+	}
+}
+
+// JSONOptions configures ToJSONWith. The zero value produces compact JSON
+// and rejects NaN and infinite floats, like ToJSONCompact.
+type JSONOptions struct {
+	// Indent indents nested elements; empty produces compact JSON
+	Indent string
+	// NonFinite selects how NaN and infinite floats are written
+	NonFinite NonFinitePolicy
+}
+
+// firstNonFiniteFloat returns the first float or double value in v,
+// searching container children, array elements and map entries, that is
+// NaN or infinite, or nil if there is none
+func firstNonFiniteFloat(v Value) Value {
+	if v == nil {
+		return nil
+	}
+
+	var nested []Value
+	switch v.Type() {
+	case FloatValue, DoubleValue:
+		if f, err := v.ToFloat64(); err == nil && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			return v
+		}
+		return nil
+	case ContainerValue:
+		nested = v.Children()
+	case ArrayValue:
+		if holder, ok := v.(elementHolder); ok {
+			nested = holder.Elements()
+		}
+	case MapValue:
+		entries, err := mapEntries(v)
+		if err != nil {
+			return nil
+		}
+		for _, entry := range entries {
+			nested = append(nested, entry.value)
+		}
+	}
+
+	for _, child := range nested {
+		if found := firstNonFiniteFloat(child); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// applyNonFinitePolicy rewrites the JSON of a value holding a non-finite
+// float (see firstNonFiniteFloat) according to policy
+func applyNonFinitePolicy(v Value, valueJSON string, policy NonFinitePolicy) (string, error) {
+	switch policy {
+	case NaNAsString:
+		return valueJSON, nil
+	case NaNAsError:
+		return "", fmt.Errorf("%w in value %q", ErrNonFiniteFloat, v.Name())
+	}
+
+	// NaNAsNull: the document is decoded into an ordered tree, so keys keep
+	// their order and numbers their original text
+	dec := json.NewDecoder(bytes.NewReader([]byte(valueJSON)))
+	dec.UseNumber()
+	doc, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return "", err
+	}
+	nullNonFiniteData(doc)
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// orderedJSONObject is a decoded JSON object that re-encodes its members in
+// their original order
+type orderedJSONObject []orderedJSONMember
+
+type orderedJSONMember struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON encodes the members in order
+func (o orderedJSONObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, member := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(member.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(member.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrderedJSON reads one JSON value from dec, decoding objects as
+// orderedJSONObject and arrays as []interface{}
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := orderedJSONObject{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			value, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, orderedJSONMember{key: key, value: value})
+		}
+		_, err := dec.Token() // '}'
+		return obj, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := dec.Token() // ']'
+		return list, err
+	default:
+		return tok, nil
+	}
+}
+
+// nullNonFiniteData replaces the "NaN", "+Inf" and "-Inf" data of float and
+// double value objects in a decoded value document with null
+func nullNonFiniteData(doc interface{}) {
+	switch node := doc.(type) {
+	case orderedJSONObject:
+		isFloat := false
+		for _, member := range node {
+			if t, _ := member.value.(string); member.key == "type" &&
+				(t == FloatValue.TypeName() || t == DoubleValue.TypeName()) {
+				isFloat = true
+			}
+		}
+		for i, member := range node {
+			if isFloat && member.key == "data" {
+				switch member.value {
+				case "NaN", "+Inf", "-Inf":
+					node[i].value = nil
+				}
+				continue
+			}
+			nullNonFiniteData(member.value)
+		}
+	case []interface{}:
+		for _, child := range node {
+			nullNonFiniteData(child)
+		}
+	}
+}
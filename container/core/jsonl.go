@@ -0,0 +1,60 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WriteContainersJSONL writes each container as one line of compact JSON
+// (JSON Lines), so batches can be grepped or ingested line by line.
+// Every line, including the last, ends with '\n'.
+func WriteContainersJSONL(w io.Writer, cs []*ValueContainer) error {
+	bw := bufio.NewWriter(w)
+	for i, c := range cs {
+		line, err := c.ToJSONCompact()
+		if err != nil {
+			return fmt.Errorf("container %d: %w", i, err)
+		}
+		if _, err := bw.WriteString(line); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadContainersJSONL reads containers written by WriteContainersJSONL, one
+// FromJSON document per line. Blank lines are skipped and a missing final
+// newline is accepted. Errors name the 1-based line that failed to decode.
+func ReadContainersJSONL(r io.Reader) ([]*ValueContainer, error) {
+	br := bufio.NewReader(r)
+	var cs []*ValueContainer
+	for lineNo := 1; ; lineNo++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			c := NewValueContainer()
+			if derr := c.FromJSON(string(trimmed)); derr != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, derr)
+			}
+			cs = append(cs, c)
+		}
+		if err != nil {
+			return cs, nil
+		}
+	}
+}
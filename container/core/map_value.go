@@ -0,0 +1,81 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// mapHolder is implemented by map values (values.MapValue), whose entries
+// are keyed by unique strings independently of the entry values' names
+type mapHolder interface {
+	Keys() []string
+	Get(key string) (Value, bool)
+}
+
+// mapEntry is one key/value pair of a map value
+type mapEntry struct {
+	key   string
+	value Value
+}
+
+// mapEntries returns the entries of a map value in key order
+func mapEntries(v Value) ([]mapEntry, error) {
+	holder, ok := v.(mapHolder)
+	if !ok {
+		return nil, fmt.Errorf("map %q does not expose its entries", v.Name())
+	}
+	keys := holder.Keys()
+	entries := make([]mapEntry, 0, len(keys))
+	for _, key := range keys {
+		value, _ := holder.Get(key)
+		entries = append(entries, mapEntry{key: key, value: value})
+	}
+	return entries, nil
+}
+
+// mapPayload encodes entries as the payload of a map value:
+// [count:4] followed by [key_len:4][key][value frame] per entry, in key
+// order. A later entry replaces an earlier one with the same key.
+func mapPayload(entries []mapEntry) ([]byte, error) {
+	byKey := make(map[string]Value, len(entries))
+	for _, entry := range entries {
+		byKey[entry.key] = entry.value
+	}
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	payload := binary.LittleEndian.AppendUint32(nil, uint32(len(keys)))
+	var err error
+	for _, key := range keys {
+		payload = appendLengthPrefixed(payload, key)
+		if payload, err = AppendValueFrame(payload, byKey[key]); err != nil {
+			return nil, fmt.Errorf("map entry %q: %w", key, err)
+		}
+	}
+	return payload, nil
+}
+
+// mapChangelogEntries names each entry of a map value by its key, for
+// Diff. A map that does not expose its entries has none.
+func mapChangelogEntries(v Value) []changelogEntry {
+	entries, err := mapEntries(v)
+	if err != nil {
+		return nil
+	}
+	result := make([]changelogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = changelogEntry{field: entry.key, value: entry.value}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "fmt"
+
+// MergePolicy selects how Merge resolves values that exist in both containers
+type MergePolicy int
+
+const (
+	// OverwriteExisting replaces values that have the same name, keeping
+	// their position, and lets non-empty header fields of the other
+	// container overwrite this one's
+	OverwriteExisting MergePolicy = iota
+	// KeepExisting keeps values that have the same name and only adds the
+	// new ones
+	KeepExisting
+	// AppendAll appends every value of the other container, even if the
+	// name already exists
+	AppendAll
+)
+
+// String returns the display name of the merge policy
+func (p MergePolicy) String() string {
+	switch p {
+	case OverwriteExisting:
+		return "OverwriteExisting"
+	case KeepExisting:
+		return "KeepExisting"
+	case AppendAll:
+		return "AppendAll"
+	default:
+		return "Unknown"
+	}
+}
+
+// Merge adds the values of other to c according to policy, e.g. to layer
+// per-request overrides on top of a default message.
+//
+// Under OverwriteExisting and KeepExisting values are matched by name; when
+// a name repeats, occurrences are matched by position, as in Changelog.
+// Values without a match are appended in the order of other. Merged values
+// are cloned, so c does not share values with other. The header of c is
+// changed only under OverwriteExisting, and only for fields that are
+// non-empty in other.
+// Thread-safe if EnableThreadSafe was called on either container.
+func (c *ValueContainer) Merge(other *ValueContainer, policy MergePolicy) error {
+	if policy < OverwriteExisting || policy > AppendAll {
+		return fmt.Errorf("unknown merge policy: %d", int(policy))
+	}
+	if other == nil {
+		return nil
+	}
+
+	// Snapshot other before locking c, so merging a container into itself
+	// (or two containers into each other concurrently) cannot deadlock
+	header := other.Header()
+	incoming := changelogValues(other)
+
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	if policy == AppendAll {
+		for _, entry := range incoming {
+			c.units = append(c.units, CloneValue(entry.value))
+		}
+		c.reindex()
+		return nil
+	}
+
+	existing := make(map[string]int, len(c.units))
+	for i, entry := range fieldEntries(c.units) {
+		existing[entry.field] = i
+	}
+	for _, entry := range incoming {
+		index, ok := existing[entry.field]
+		switch {
+		case !ok:
+			c.units = append(c.units, CloneValue(entry.value))
+		case policy == OverwriteExisting:
+			c.units[index] = CloneValue(entry.value)
+		}
+	}
+	c.reindex()
+
+	if policy == OverwriteExisting {
+		mergeHeaderField(&c.sourceID, header.SourceID)
+		mergeHeaderField(&c.sourceSubID, header.SourceSubID)
+		mergeHeaderField(&c.targetID, header.TargetID)
+		mergeHeaderField(&c.targetSubID, header.TargetSubID)
+		mergeHeaderField(&c.messageType, header.MessageType)
+		mergeHeaderField(&c.version, header.Version)
+		if !header.CreatedAt.IsZero() {
+			c.createdAt = header.CreatedAt
+		}
+	}
+	return nil
+}
+
+// mergeHeaderField overwrites *field with value when value is non-empty
+func mergeHeaderField(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
@@ -0,0 +1,290 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// writeMessagePackValue writes a value as a {name, type, data} map. The
+// data is a native MessagePack object chosen by type, so readers in other
+// languages see natural types:
+//
+//	null                  nil
+//	bool                  bool
+//	short .. llong        int
+//	ushort .. ullong      uint
+//	float / double        float 32 / float 64
+//	string / decimal      str (decimal as its text form)
+//	bytes / uuid          bin
+//	datetime              timestamp extension
+//	container / array     array of value maps
+//	map                   map of value maps keyed by entry key
+//
+// A payload of the wrong size for its type is written as bin, as is every
+// payload in the legacy format that FromMessagePack still reads.
+func writeMessagePackValue(enc *msgpack.Encoder, v Value) error {
+	if err := enc.EncodeMapLen(3); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("name"); err != nil {
+		return err
+	}
+	if err := enc.EncodeString(v.Name()); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("type"); err != nil {
+		return err
+	}
+	if err := enc.EncodeString(v.Type().String()); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("data"); err != nil {
+		return err
+	}
+	return writeMessagePackData(enc, v)
+}
+
+// writeMessagePackData writes the data object of writeMessagePackValue
+func writeMessagePackData(enc *msgpack.Encoder, v Value) error {
+	le := binary.LittleEndian
+	data := v.Data()
+	switch v.Type() {
+	case NullValue:
+		return enc.EncodeNil()
+	case BoolValue:
+		if len(data) == 1 {
+			return enc.EncodeBool(data[0] != 0)
+		}
+	case ShortValue:
+		if len(data) == 2 {
+			return enc.EncodeInt(int64(int16(le.Uint16(data))))
+		}
+	case UShortValue:
+		if len(data) == 2 {
+			return enc.EncodeUint(uint64(le.Uint16(data)))
+		}
+	case IntValue, LongValue:
+		if len(data) == 4 {
+			return enc.EncodeInt(int64(int32(le.Uint32(data))))
+		}
+	case UIntValue, ULongValue:
+		if len(data) == 4 {
+			return enc.EncodeUint(uint64(le.Uint32(data)))
+		}
+	case LLongValue:
+		if len(data) == 8 {
+			return enc.EncodeInt(int64(le.Uint64(data)))
+		}
+	case ULLongValue:
+		if len(data) == 8 {
+			return enc.EncodeUint(le.Uint64(data))
+		}
+	case FloatValue:
+		if len(data) == 4 {
+			return enc.EncodeFloat32(math.Float32frombits(le.Uint32(data)))
+		}
+	case DoubleValue:
+		if len(data) == 8 {
+			return enc.EncodeFloat64(math.Float64frombits(le.Uint64(data)))
+		}
+	case StringValue:
+		return enc.EncodeString(string(data))
+	case DateTimeValue:
+		if len(data) == 8 {
+			return enc.EncodeTime(time.Unix(0, int64(le.Uint64(data))).UTC())
+		}
+	case DecimalValue:
+		if s, err := v.ToString(); err == nil {
+			return enc.EncodeString(s)
+		}
+	case ContainerValue, ArrayValue:
+		children := v.Children()
+		if holder, ok := v.(elementHolder); ok {
+			children = holder.Elements()
+		}
+		if err := enc.EncodeArrayLen(len(children)); err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err := writeMessagePackValue(enc, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case MapValue:
+		entries, err := mapEntries(v)
+		if err != nil {
+			return err
+		}
+		if err := enc.EncodeMapLen(len(entries)); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := enc.EncodeString(entry.key); err != nil {
+				return err
+			}
+			if err := writeMessagePackValue(enc, entry.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return enc.EncodeBytes(data)
+}
+
+// valuesFromMessagePack rebuilds the values of a decoded "values" array
+func valuesFromMessagePack(items []interface{}) ([]Value, error) {
+	units := make([]Value, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("value %d: expected a map, got %T", i, item)
+		}
+		value, err := valueFromMessagePack(fields)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		units = append(units, value)
+	}
+	return units, nil
+}
+
+// valueFromMessagePack rebuilds one value from its {name, type, data} map
+func valueFromMessagePack(fields map[string]interface{}) (Value, error) {
+	name, _ := fields["name"].(string)
+	typeCode, _ := fields["type"].(string)
+	code, err := strconv.Atoi(typeCode)
+	vtype := ValueType(code)
+	if err != nil || !vtype.IsDefined() {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownValueType, typeCode)
+	}
+
+	payload, err := messagePackPayload(vtype, fields["data"])
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", vtype.TypeName(), name, err)
+	}
+	return NewValueFromData(name, vtype, payload)
+}
+
+// messagePackPayload converts a decoded data object to the payload accepted
+// by NewValueFromData. bin data is the raw payload, as written for bytes and
+// UUIDs and for every type by the legacy format; native scalars are read
+// through their text form (see textPayload).
+func messagePackPayload(vtype ValueType, data interface{}) ([]byte, error) {
+	nested := vtype == ContainerValue || vtype == ArrayValue
+	switch d := data.(type) {
+	case nil:
+		if nested || vtype == MapValue {
+			// An empty container, array or map payload is [count:4]=0
+			return nestedPayload(nil)
+		}
+		return nil, nil
+	case []byte:
+		if nested {
+			// The legacy format did not carry children
+			return nestedPayload(nil)
+		}
+		return d, nil
+	case map[string]interface{}:
+		if vtype != MapValue {
+			return nil, fmt.Errorf("unexpected map data")
+		}
+		return mapMessagePackPayload(d)
+	case []interface{}:
+		if !nested {
+			return nil, fmt.Errorf("unexpected array data")
+		}
+		children, err := valuesFromMessagePack(d)
+		if err != nil {
+			return nil, err
+		}
+		return nestedPayload(children)
+	case string:
+		if vtype == StringValue {
+			return []byte(d), nil
+		}
+		return textPayload(vtype, d, "")
+	case bool:
+		return textPayload(vtype, strconv.FormatBool(d), "")
+	case time.Time:
+		return textPayload(vtype, d.Format(time.RFC3339Nano), "")
+	case int8:
+		return textPayload(vtype, strconv.FormatInt(int64(d), 10), "")
+	case int16:
+		return textPayload(vtype, strconv.FormatInt(int64(d), 10), "")
+	case int32:
+		return textPayload(vtype, strconv.FormatInt(int64(d), 10), "")
+	case int64:
+		return textPayload(vtype, strconv.FormatInt(d, 10), "")
+	case uint8:
+		return textPayload(vtype, strconv.FormatUint(uint64(d), 10), "")
+	case uint16:
+		return textPayload(vtype, strconv.FormatUint(uint64(d), 10), "")
+	case uint32:
+		return textPayload(vtype, strconv.FormatUint(uint64(d), 10), "")
+	case uint64:
+		return textPayload(vtype, strconv.FormatUint(d, 10), "")
+	case float32:
+		return textPayload(vtype, strconv.FormatFloat(float64(d), 'g', -1, 32), "")
+	case float64:
+		return textPayload(vtype, strconv.FormatFloat(d, 'g', -1, 64), "")
+	default:
+		return nil, fmt.Errorf("unsupported data %T", data)
+	}
+}
+
+// mapMessagePackPayload rebuilds the entries of a decoded map value, whose
+// data is a map of value maps keyed by entry key, into a map payload
+func mapMessagePackPayload(items map[string]interface{}) ([]byte, error) {
+	entries := make([]mapEntry, 0, len(items))
+	for key, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("map entry %q: expected a map, got %T", key, item)
+		}
+		value, err := valueFromMessagePack(fields)
+		if err != nil {
+			return nil, fmt.Errorf("map entry %q: %w", key, err)
+		}
+		entries = append(entries, mapEntry{key: key, value: value})
+	}
+	return mapPayload(entries)
+}
+
+// messagePackInt64 reads a decoded MessagePack integer of any width, as
+// msgpack picks the smallest encoding for a value
+func messagePackInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		if n <= math.MaxInt64 {
+			return int64(n), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,229 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// MutationOp identifies the kind of a MutationLog record
+type MutationOp byte
+
+const (
+	// MutationAdd appends a value (AddValue)
+	MutationAdd MutationOp = iota + 1
+	// MutationRemove removes all values with a name (RemoveValue)
+	MutationRemove
+	// MutationSet replaces the index-th value with a name (ReplaceValue)
+	MutationSet
+	// MutationHeader replaces the header fields (SetHeader)
+	MutationHeader
+)
+
+// String returns the display name of the mutation op
+func (op MutationOp) String() string {
+	switch op {
+	case MutationAdd:
+		return "Add"
+	case MutationRemove:
+		return "Remove"
+	case MutationSet:
+		return "Set"
+	case MutationHeader:
+		return "Header"
+	default:
+		return "Unknown"
+	}
+}
+
+// MutationLog is an append-only write-ahead log of container mutations, for
+// rebuilding a container with Replay after a crash.
+//
+// Mutations made through the log are written to w before they are applied
+// to the container, one record per mutation:
+//
+//	Add:    [op:1][value frame]
+//	Remove: [op:1][name_len:4][name]
+//	Set:    [op:1][name_len:4][name][index:4][value frame]
+//	Header: [op:1] + 6 × [len:4][field] in Header field order
+//
+// Value frames use the binary value format ([type:1][name_len:4][name]
+// [value_size:4][payload]). Each record is written with a single Write call.
+// Mutations made on the container directly are not logged.
+// MutationLog is safe for concurrent use; records are written in the order
+// the mutations are applied.
+type MutationLog struct {
+	mu        sync.Mutex
+	container *ValueContainer
+	w         io.Writer
+	buf       []byte
+}
+
+// NewMutationLog creates a log that records mutations of c to w.
+// The log starts from an empty container, so c should be empty (or the
+// initial state replayed from an earlier log written to the same file).
+func NewMutationLog(c *ValueContainer, w io.Writer) *MutationLog {
+	return &MutationLog{container: c, w: w}
+}
+
+// Container returns the logged container
+func (l *MutationLog) Container() *ValueContainer {
+	return l.container
+}
+
+// AddValue logs and applies AddValue
+func (l *MutationLog) AddValue(value Value) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buf, err := AppendValueFrame(append(l.buf[:0], byte(MutationAdd)), value)
+	if err != nil {
+		return fmt.Errorf("log add %q: %w", value.Name(), err)
+	}
+	if err := l.write(buf); err != nil {
+		return err
+	}
+	l.container.AddValue(value)
+	return nil
+}
+
+// RemoveValue logs and applies RemoveValue
+func (l *MutationLog) RemoveValue(name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buf := appendLengthPrefixed(append(l.buf[:0], byte(MutationRemove)), name)
+	if err := l.write(buf); err != nil {
+		return err
+	}
+	l.container.RemoveValue(name)
+	return nil
+}
+
+// ReplaceValue logs and applies ReplaceValue, reporting whether a value was
+// replaced. The record is written even if there is no such value, since
+// replaying it is equally a no-op.
+func (l *MutationLog) ReplaceValue(name string, index int, value Value) (bool, error) {
+	if index < 0 {
+		return false, fmt.Errorf("%w: replace index %d", ErrIndexOutOfRange, index)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buf := appendLengthPrefixed(append(l.buf[:0], byte(MutationSet)), name)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(index))
+	buf, err := AppendValueFrame(buf, value)
+	if err != nil {
+		return false, fmt.Errorf("log set %q: %w", value.Name(), err)
+	}
+	if err := l.write(buf); err != nil {
+		return false, err
+	}
+	return l.container.ReplaceValue(name, index, value), nil
+}
+
+// SetHeader logs and applies SetHeader
+func (l *MutationLog) SetHeader(h Header) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buf := append(l.buf[:0], byte(MutationHeader))
+	for _, field := range [containerHeaderFieldCount]string{
+		h.SourceID, h.SourceSubID, h.TargetID, h.TargetSubID, h.MessageType, h.Version,
+	} {
+		buf = appendLengthPrefixed(buf, field)
+	}
+	if err := l.write(buf); err != nil {
+		return err
+	}
+	l.container.SetHeader(h)
+	return nil
+}
+
+// write writes one record and keeps its buffer for reuse.
+// The caller must hold l.mu.
+func (l *MutationLog) write(record []byte) error {
+	l.buf = record
+	if _, err := l.w.Write(record); err != nil {
+		return fmt.Errorf("write mutation log: %w", err)
+	}
+	return nil
+}
+
+// Replay rebuilds a container by applying the records of a MutationLog in
+// order to a new empty container. It stops at the end of r.
+//
+// A record cut short by a crash yields an error matching
+// io.ErrUnexpectedEOF; the returned container then holds the state after
+// the last complete record, so it can still be used for recovery. Other
+// errors also return the state reached so far.
+func Replay(r io.Reader) (*ValueContainer, error) {
+	container := NewValueContainer()
+	for record := 0; ; record++ {
+		var op [1]byte
+		if _, err := io.ReadFull(r, op[:]); err != nil {
+			if err == io.EOF {
+				return container, nil
+			}
+			return container, fmt.Errorf("record %d: %w", record, err)
+		}
+		if err := replayRecord(container, MutationOp(op[0]), r); err != nil {
+			return container, fmt.Errorf("record %d (%s): %w", record, MutationOp(op[0]), unexpectedEOF(err))
+		}
+	}
+}
+
+// replayRecord reads the body of one record and applies it to c
+func replayRecord(c *ValueContainer, op MutationOp, r io.Reader) error {
+	switch op {
+	case MutationAdd:
+		value, err := readValueFrame(r)
+		if err != nil {
+			return err
+		}
+		c.AddValue(value)
+
+	case MutationRemove:
+		name, err := readLengthPrefixed(r)
+		if err != nil {
+			return err
+		}
+		c.RemoveValue(string(name))
+
+	case MutationSet:
+		name, err := readLengthPrefixed(r)
+		if err != nil {
+			return err
+		}
+		index, err := readUint32(r)
+		if err != nil {
+			return err
+		}
+		value, err := readValueFrame(r)
+		if err != nil {
+			return err
+		}
+		c.ReplaceValue(string(name), int(index), value)
+
+	case MutationHeader:
+		header, err := readHeaderFields(r)
+		if err != nil {
+			return err
+		}
+		c.SetHeader(header)
+
+	default:
+		return fmt.Errorf("unknown mutation op: %d", byte(op))
+	}
+	return nil
+}
+
+// appendLengthPrefixed appends a 4-byte little-endian length and s
+func appendLengthPrefixed(dst []byte, s string) []byte {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(s)))
+	return append(dst, s...)
+}
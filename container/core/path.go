@@ -0,0 +1,153 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path lookup errors. Use errors.Is to test the cause of a *PathError.
+var (
+	// ErrInvalidPath is returned when a path cannot be parsed
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrPathNotFound is returned when a path segment does not exist
+	ErrPathNotFound = errors.New("path segment not found")
+	// ErrPathIndexOutOfRange is returned when an array index is out of range
+	ErrPathIndexOutOfRange = errors.New("path index out of range")
+	// ErrPathNotIndexable is returned when an index is applied to a non-array value
+	ErrPathNotIndexable = errors.New("path segment is not an array")
+	// ErrPathNotContainer is returned when a path descends into a value
+	// that is not a container
+	ErrPathNotContainer = errors.New("path segment is not a container")
+)
+
+// PathError describes a failed path lookup
+type PathError struct {
+	Path    string // full path that was requested
+	Segment string // segment at which the lookup failed
+	Err     error  // one of the ErrPath* / ErrInvalidPath sentinels
+}
+
+// Error implements the error interface
+func (e *PathError) Error() string {
+	return fmt.Sprintf("path %q: segment %q: %v", e.Path, e.Segment, e.Err)
+}
+
+// Unwrap returns the underlying sentinel error
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// elementHolder is implemented by array values that expose their elements
+type elementHolder interface {
+	Elements() []Value
+}
+
+// pathSegment is a single name with optional trailing array indexes,
+// e.g. "items[2][0]" -> {name: "items", indexes: [2, 0]}
+type pathSegment struct {
+	raw     string
+	name    string
+	indexes []int
+}
+
+// parsePath splits a dot/bracket path into segments
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, &PathError{Path: path, Segment: path, Err: ErrInvalidPath}
+	}
+
+	parts := strings.Split(path, ".")
+	segments := make([]pathSegment, 0, len(parts))
+	for _, part := range parts {
+		seg := pathSegment{raw: part}
+
+		bracket := strings.IndexByte(part, '[')
+		if bracket == -1 {
+			seg.name = part
+		} else {
+			seg.name = part[:bracket]
+			rest := part[bracket:]
+			for len(rest) > 0 {
+				end := strings.IndexByte(rest, ']')
+				if rest[0] != '[' || end == -1 {
+					return nil, &PathError{Path: path, Segment: part, Err: ErrInvalidPath}
+				}
+				index, err := strconv.Atoi(rest[1:end])
+				if err != nil {
+					return nil, &PathError{Path: path, Segment: part, Err: ErrInvalidPath}
+				}
+				seg.indexes = append(seg.indexes, index)
+				rest = rest[end+1:]
+			}
+		}
+
+		if seg.name == "" {
+			return nil, &PathError{Path: path, Segment: part, Err: ErrInvalidPath}
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// GetValueByPath resolves a dot/bracket path such as "profile.address.city"
+// or "items[2].name". Names select the first child (or top-level value) with
+// that name; bracketed indexes select elements of an ArrayValue.
+//
+// On failure a *PathError wrapping ErrPathNotFound, ErrPathIndexOutOfRange,
+// ErrPathNotIndexable or ErrInvalidPath is returned.
+func (c *ValueContainer) GetValueByPath(path string) (Value, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+
+	var current Value
+	for i, seg := range segments {
+		var candidates []Value
+		if i == 0 {
+			candidates = c.units
+		} else {
+			candidates = current.Children()
+		}
+
+		var found Value
+		for _, candidate := range candidates {
+			if candidate.Name() == seg.name {
+				found = candidate
+				break
+			}
+		}
+		if found == nil {
+			return nil, &PathError{Path: path, Segment: seg.raw, Err: ErrPathNotFound}
+		}
+		current = found
+
+		for _, index := range seg.indexes {
+			holder, ok := current.(elementHolder)
+			if !ok {
+				return nil, &PathError{Path: path, Segment: seg.raw, Err: ErrPathNotIndexable}
+			}
+			elements := holder.Elements()
+			if index < 0 || index >= len(elements) {
+				return nil, &PathError{Path: path, Segment: seg.raw, Err: ErrPathIndexOutOfRange}
+			}
+			current = elements[index]
+		}
+	}
+
+	return current, nil
+}
@@ -0,0 +1,105 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// PeekHeader parses only the header of data in the binary container format
+// (see SerializeBinary). The value section is not read, so routing decisions
+// can be made without rebuilding any values; data may even be cut short
+// after the header.
+func PeekHeader(data []byte) (Header, error) {
+	if len(data) == 0 {
+		return Header{}, fmt.Errorf("empty binary container")
+	}
+	if data[0] != BinaryVersion {
+		return Header{}, fmt.Errorf("unsupported binary version: %d", data[0])
+	}
+	var fields [containerHeaderFieldCount]string
+	rest := data[1:]
+	for i := range fields {
+		if len(rest) < 4 {
+			return Header{}, fmt.Errorf("binary header field %d: %w", i, io.ErrUnexpectedEOF)
+		}
+		n := binary.LittleEndian.Uint32(rest)
+		rest = rest[4:]
+		if uint64(n) > uint64(len(rest)) {
+			return Header{}, fmt.Errorf("binary header field %d: %w", i, io.ErrUnexpectedEOF)
+		}
+		fields[i] = string(rest[:n])
+		rest = rest[n:]
+	}
+	return Header{
+		SourceID:    fields[0],
+		SourceSubID: fields[1],
+		TargetID:    fields[2],
+		TargetSubID: fields[3],
+		MessageType: fields[4],
+		Version:     fields[5],
+	}, nil
+}
+
+// PeekHeaderMessagePack parses only the header of data in the MessagePack
+// format (see ToMessagePack). The "values" entry is skipped without decoding
+// its elements, and parsing stops when it is reached, so the header fields
+// and the optional created_at timestamp must precede it, as ToMessagePack
+// writes them.
+// Missing header fields are left empty, as with FromMessagePack.
+func PeekHeaderMessagePack(data []byte) (Header, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	n, err := dec.DecodeMapLen()
+	if err != nil {
+		return Header{}, fmt.Errorf("messagepack header: %w", err)
+	}
+
+	var header Header
+	fields := map[string]*string{
+		"source_id":     &header.SourceID,
+		"source_sub_id": &header.SourceSubID,
+		"target_id":     &header.TargetID,
+		"target_sub_id": &header.TargetSubID,
+		"message_type":  &header.MessageType,
+		"version":       &header.Version,
+	}
+	for i := 0; i < n; i++ {
+		key, err := dec.DecodeString()
+		if err != nil {
+			return Header{}, fmt.Errorf("messagepack header key %d: %w", i, err)
+		}
+		if key == "values" {
+			break
+		}
+		if key == "created_at" {
+			ms, err := dec.DecodeInt64()
+			if err != nil {
+				return Header{}, fmt.Errorf("messagepack header %q: %w", key, err)
+			}
+			header.CreatedAt = time.UnixMilli(ms).UTC()
+			continue
+		}
+		field, ok := fields[key]
+		if !ok {
+			if err := dec.Skip(); err != nil {
+				return Header{}, fmt.Errorf("messagepack header %q: %w", key, err)
+			}
+			continue
+		}
+		if *field, err = dec.DecodeString(); err != nil {
+			return Header{}, fmt.Errorf("messagepack header %q: %w", key, err)
+		}
+	}
+	return header, nil
+}
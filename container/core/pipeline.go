@@ -0,0 +1,53 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "fmt"
+
+// Transform is one step of a Pipeline. It may modify and return its input
+// or return a new container.
+type Transform func(*ValueContainer) (*ValueContainer, error)
+
+// Pipeline applies a sequence of Transforms to a container in order
+type Pipeline struct {
+	transforms []Transform
+}
+
+// NewPipeline creates a pipeline of the given transforms
+func NewPipeline(transforms ...Transform) *Pipeline {
+	return &Pipeline{transforms: append([]Transform(nil), transforms...)}
+}
+
+// Then appends a transform and returns the pipeline for chaining
+func (p *Pipeline) Then(transform Transform) *Pipeline {
+	p.transforms = append(p.transforms, transform)
+	return p
+}
+
+// Len returns the number of transforms in the pipeline
+func (p *Pipeline) Len() int {
+	return len(p.transforms)
+}
+
+// Apply runs every transform in order, feeding each the result of the
+// previous one. It stops at the first transform that fails, returning its
+// error wrapped with the step index. Apply has the Transform signature, so a
+// pipeline can be used as a step of another pipeline.
+func (p *Pipeline) Apply(c *ValueContainer) (*ValueContainer, error) {
+	for i, transform := range p.transforms {
+		next, err := transform(c)
+		if err != nil {
+			return nil, fmt.Errorf("transform %d failed: %w", i, err)
+		}
+		if next == nil {
+			return nil, fmt.Errorf("transform %d returned no container", i)
+		}
+		c = next
+	}
+	return c, nil
+}
@@ -0,0 +1,380 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	containerpb "github.com/kcenon/go_container_system/container/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidProto is returned when protobuf data cannot be decoded
+var ErrInvalidProto = errors.New("invalid protobuf data")
+
+// ToProto encodes the container as a protobuf Container message
+// (see container/proto/container.proto) using the generated containerpb
+// types.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) ToProto() ([]byte, error) {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	c.serializationCount.Add(1)
+
+	msg := &containerpb.Container{
+		SourceId:    c.sourceID,
+		SourceSubId: c.sourceSubID,
+		TargetId:    c.targetID,
+		TargetSubId: c.targetSubID,
+		MessageType: c.messageType,
+		Version:     c.version,
+		Values:      make([]*containerpb.Value, 0, len(c.units)),
+	}
+	for _, unit := range c.units {
+		value, err := encodeProtoValue(unit)
+		if err != nil {
+			return nil, err
+		}
+		msg.Values = append(msg.Values, value)
+	}
+
+	return proto.Marshal(msg)
+}
+
+// FromProto replaces the container's header and values with those decoded
+// from a protobuf Container message. Unknown fields are skipped.
+// Values are rebuilt with the shared value factory (see NewValueFromData).
+func (c *ValueContainer) FromProto(data []byte) error {
+	var msg containerpb.Container
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProto, err)
+	}
+
+	units := make([]Value, 0, len(msg.Values))
+	for _, pv := range msg.Values {
+		value, err := decodeProtoValue(pv)
+		if err != nil {
+			return err
+		}
+		units = append(units, value)
+	}
+
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.sourceID = msg.SourceId
+	c.sourceSubID = msg.SourceSubId
+	c.targetID = msg.TargetId
+	c.targetSubID = msg.TargetSubId
+	c.messageType = msg.MessageType
+	c.version = msg.Version
+	c.units = units
+	c.reindex()
+	return nil
+}
+
+// encodeProtoValue converts a value to a Value message: name plus the
+// type-tagged oneof
+func encodeProtoValue(v Value) (*containerpb.Value, error) {
+	msg := &containerpb.Value{Name: v.Name()}
+	data := v.Data()
+
+	switch v.Type() {
+	case NullValue:
+		msg.Payload = &containerpb.Value_NullValue{NullValue: true}
+
+	case BoolValue:
+		if len(data) != 1 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_BoolValue{BoolValue: data[0]&1 != 0}
+
+	case ShortValue:
+		if len(data) != 2 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_ShortValue{ShortValue: int32(int16(binary.LittleEndian.Uint16(data)))}
+
+	case UShortValue:
+		if len(data) != 2 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_UshortValue{UshortValue: uint32(binary.LittleEndian.Uint16(data))}
+
+	case IntValue, LongValue:
+		if len(data) != 4 {
+			return nil, protoPayloadError(v)
+		}
+		n := int32(binary.LittleEndian.Uint32(data))
+		if v.Type() == IntValue {
+			msg.Payload = &containerpb.Value_IntValue{IntValue: n}
+		} else {
+			msg.Payload = &containerpb.Value_LongValue{LongValue: n}
+		}
+
+	case UIntValue, ULongValue:
+		if len(data) != 4 {
+			return nil, protoPayloadError(v)
+		}
+		n := binary.LittleEndian.Uint32(data)
+		if v.Type() == UIntValue {
+			msg.Payload = &containerpb.Value_UintValue{UintValue: n}
+		} else {
+			msg.Payload = &containerpb.Value_UlongValue{UlongValue: n}
+		}
+
+	case LLongValue, ULLongValue:
+		if len(data) != 8 {
+			return nil, protoPayloadError(v)
+		}
+		n := binary.LittleEndian.Uint64(data)
+		if v.Type() == LLongValue {
+			msg.Payload = &containerpb.Value_LlongValue{LlongValue: int64(n)}
+		} else {
+			msg.Payload = &containerpb.Value_UllongValue{UllongValue: n}
+		}
+
+	case FloatValue:
+		if len(data) != 4 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_FloatValue{FloatValue: math.Float32frombits(binary.LittleEndian.Uint32(data))}
+
+	case DoubleValue:
+		if len(data) != 8 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_DoubleValue{DoubleValue: math.Float64frombits(binary.LittleEndian.Uint64(data))}
+
+	case DateTimeValue:
+		if len(data) != 8 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_DatetimeValue{DatetimeValue: int64(binary.LittleEndian.Uint64(data))}
+
+	case StringValue:
+		msg.Payload = &containerpb.Value_StringValue{StringValue: string(data)}
+
+	case BytesValue:
+		msg.Payload = &containerpb.Value_BytesValue{BytesValue: data}
+
+	case UUIDValue:
+		if len(data) != 16 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_UuidValue{UuidValue: data}
+
+	case DecimalValue:
+		if len(data) < 8 {
+			return nil, protoPayloadError(v)
+		}
+		msg.Payload = &containerpb.Value_DecimalValue{DecimalValue: data}
+
+	case ContainerValue, ArrayValue:
+		var children []Value
+		if v.Type() == ContainerValue {
+			children = v.Children()
+		} else if holder, ok := v.(elementHolder); ok {
+			children = holder.Elements()
+		} else {
+			return nil, fmt.Errorf("array %q does not expose its elements", v.Name())
+		}
+
+		list := &containerpb.ValueList{Values: make([]*containerpb.Value, 0, len(children))}
+		for _, child := range children {
+			pv, err := encodeProtoValue(child)
+			if err != nil {
+				return nil, err
+			}
+			list.Values = append(list.Values, pv)
+		}
+		if v.Type() == ContainerValue {
+			msg.Payload = &containerpb.Value_ContainerValue{ContainerValue: list}
+		} else {
+			msg.Payload = &containerpb.Value_ArrayValue{ArrayValue: list}
+		}
+
+	case MapValue:
+		entries, err := mapEntries(v)
+		if err != nil {
+			return nil, err
+		}
+		list := &containerpb.MapEntryList{Entries: make([]*containerpb.MapEntry, 0, len(entries))}
+		for _, entry := range entries {
+			pv, err := encodeProtoValue(entry.value)
+			if err != nil {
+				return nil, err
+			}
+			list.Entries = append(list.Entries, &containerpb.MapEntry{Key: entry.key, Value: pv})
+		}
+		msg.Payload = &containerpb.Value_MapValue{MapValue: list}
+
+	default:
+		return nil, fmt.Errorf("unsupported value type for protobuf: %d", v.Type())
+	}
+
+	return msg, nil
+}
+
+// decodeProtoValue converts a Value message into a typed value
+func decodeProtoValue(msg *containerpb.Value) (Value, error) {
+	name := msg.GetName()
+	var vtype ValueType
+	var payload []byte
+
+	switch p := msg.GetPayload().(type) {
+	case *containerpb.Value_NullValue:
+		vtype = NullValue
+
+	case *containerpb.Value_BoolValue:
+		vtype = BoolValue
+		payload = []byte{0}
+		if p.BoolValue {
+			payload[0] = 1
+		}
+
+	case *containerpb.Value_ShortValue:
+		vtype = ShortValue
+		if p.ShortValue < math.MinInt16 || p.ShortValue > math.MaxInt16 {
+			return nil, protoRangeError(vtype, int64(p.ShortValue))
+		}
+		payload = binary.LittleEndian.AppendUint16(nil, uint16(p.ShortValue))
+
+	case *containerpb.Value_UshortValue:
+		vtype = UShortValue
+		if p.UshortValue > math.MaxUint16 {
+			return nil, protoRangeError(vtype, int64(p.UshortValue))
+		}
+		payload = binary.LittleEndian.AppendUint16(nil, uint16(p.UshortValue))
+
+	case *containerpb.Value_IntValue:
+		vtype = IntValue
+		payload = binary.LittleEndian.AppendUint32(nil, uint32(p.IntValue))
+
+	case *containerpb.Value_UintValue:
+		vtype = UIntValue
+		payload = binary.LittleEndian.AppendUint32(nil, p.UintValue)
+
+	case *containerpb.Value_LongValue:
+		vtype = LongValue
+		payload = binary.LittleEndian.AppendUint32(nil, uint32(p.LongValue))
+
+	case *containerpb.Value_UlongValue:
+		vtype = ULongValue
+		payload = binary.LittleEndian.AppendUint32(nil, p.UlongValue)
+
+	case *containerpb.Value_LlongValue:
+		vtype = LLongValue
+		payload = binary.LittleEndian.AppendUint64(nil, uint64(p.LlongValue))
+
+	case *containerpb.Value_UllongValue:
+		vtype = ULLongValue
+		payload = binary.LittleEndian.AppendUint64(nil, p.UllongValue)
+
+	case *containerpb.Value_FloatValue:
+		vtype = FloatValue
+		payload = binary.LittleEndian.AppendUint32(nil, math.Float32bits(p.FloatValue))
+
+	case *containerpb.Value_DoubleValue:
+		vtype = DoubleValue
+		payload = binary.LittleEndian.AppendUint64(nil, math.Float64bits(p.DoubleValue))
+
+	case *containerpb.Value_DatetimeValue:
+		vtype = DateTimeValue
+		payload = binary.LittleEndian.AppendUint64(nil, uint64(p.DatetimeValue))
+
+	case *containerpb.Value_StringValue:
+		vtype = StringValue
+		payload = []byte(p.StringValue)
+
+	case *containerpb.Value_BytesValue:
+		vtype = BytesValue
+		payload = p.BytesValue
+
+	case *containerpb.Value_UuidValue:
+		vtype = UUIDValue
+		payload = p.UuidValue
+
+	case *containerpb.Value_DecimalValue:
+		vtype = DecimalValue
+		payload = p.DecimalValue
+
+	case *containerpb.Value_ContainerValue:
+		vtype = ContainerValue
+		var err error
+		if payload, err = protoValueListPayload(p.ContainerValue); err != nil {
+			return nil, err
+		}
+
+	case *containerpb.Value_ArrayValue:
+		vtype = ArrayValue
+		var err error
+		if payload, err = protoValueListPayload(p.ArrayValue); err != nil {
+			return nil, err
+		}
+
+	case *containerpb.Value_MapValue:
+		vtype = MapValue
+		var err error
+		if payload, err = protoMapPayload(p.MapValue); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: value %q has no payload", ErrInvalidProto, name)
+	}
+
+	return NewValueFromData(name, vtype, payload)
+}
+
+// protoValueListPayload converts a ValueList message into the
+// [count:4][child frames] payload used by containers and arrays
+func protoValueListPayload(list *containerpb.ValueList) ([]byte, error) {
+	payload := binary.LittleEndian.AppendUint32(nil, uint32(len(list.GetValues())))
+	for _, pv := range list.GetValues() {
+		child, err := decodeProtoValue(pv)
+		if err != nil {
+			return nil, err
+		}
+		if payload, err = AppendValueFrame(payload, child); err != nil {
+			return nil, err
+		}
+	}
+	return payload, nil
+}
+
+// protoMapPayload converts a MapEntryList message into the payload of a
+// map value (see mapPayload)
+func protoMapPayload(list *containerpb.MapEntryList) ([]byte, error) {
+	entries := make([]mapEntry, 0, len(list.GetEntries()))
+	for _, pe := range list.GetEntries() {
+		if pe.GetValue() == nil {
+			return nil, fmt.Errorf("%w: map entry %q has no value", ErrInvalidProto, pe.GetKey())
+		}
+		value, err := decodeProtoValue(pe.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, mapEntry{key: pe.GetKey(), value: value})
+	}
+	return mapPayload(entries)
+}
+
+func protoRangeError(t ValueType, n int64) error {
+	return fmt.Errorf("%w: %d out of range for %s", ErrInvalidProto, n, t.TypeName())
+}
+
+func protoPayloadError(v Value) error {
+	return fmt.Errorf("invalid %s payload size %d for %q", v.Type().TypeName(), len(v.Data()), v.Name())
+}
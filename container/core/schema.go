@@ -0,0 +1,115 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FieldSchema declares one expected top-level field of a message
+type FieldSchema struct {
+	Name     string
+	Type     ValueType
+	Optional bool // a missing optional field is not an error
+}
+
+// Schema declares the expected shape of a message, for rejecting malformed
+// messages before they are processed
+type Schema struct {
+	// MessageType, when non-empty, must equal the container's message type
+	MessageType string
+	Fields      []FieldSchema
+}
+
+// Validate checks c against the schema and returns one error per problem:
+// a message type mismatch (ErrMessageTypeMismatch), a missing required field
+// (ErrMissingField) or a field of the wrong type (ErrFieldTypeMismatch).
+// When a name repeats, every occurrence must have the declared type. Fields
+// not declared in the schema are allowed. It returns nil if c is valid.
+func (s *Schema) Validate(c *ValueContainer) []error {
+	var errs []error
+	if s.MessageType != "" {
+		if messageType := c.Header().MessageType; messageType != s.MessageType {
+			errs = append(errs, fmt.Errorf("%w: got %q, want %q",
+				ErrMessageTypeMismatch, messageType, s.MessageType))
+		}
+	}
+
+	groups := c.GroupByName()
+	for _, field := range s.Fields {
+		occurrences := groups[field.Name]
+		if len(occurrences) == 0 {
+			if !field.Optional {
+				errs = append(errs, fmt.Errorf("%w: %q", ErrMissingField, field.Name))
+			}
+			continue
+		}
+		for i, value := range occurrences {
+			if value.Type() == field.Type {
+				continue
+			}
+			name := field.Name
+			if len(occurrences) > 1 {
+				name = fmt.Sprintf("%s[%d]", field.Name, i)
+			}
+			errs = append(errs, fmt.Errorf("%w: %q is %s, want %s",
+				ErrFieldTypeMismatch, name, value.Type().TypeName(), field.Type.TypeName()))
+		}
+	}
+	return errs
+}
+
+// schemaDocument is the JSON form of a Schema
+type schemaDocument struct {
+	MessageType string `json:"message_type"`
+	Fields      []struct {
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Optional bool   `json:"optional"`
+	} `json:"fields"`
+}
+
+// ParseSchemaJSON loads a schema from JSON, so it can be kept in config:
+//
+//	{
+//	  "message_type": "order",
+//	  "fields": [
+//	    {"name": "id", "type": "string"},
+//	    {"name": "qty", "type": "int"},
+//	    {"name": "note", "type": "string", "optional": true}
+//	  ]
+//	}
+//
+// Types use the names of ValueType.TypeName.
+func ParseSchemaJSON(data []byte) (*Schema, error) {
+	var doc schemaDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+
+	schema := &Schema{
+		MessageType: doc.MessageType,
+		Fields:      make([]FieldSchema, 0, len(doc.Fields)),
+	}
+	for i, field := range doc.Fields {
+		if field.Name == "" {
+			return nil, fmt.Errorf("schema field %d: missing name", i)
+		}
+		vtype, ok := ValueTypeFromName(field.Type)
+		if !ok {
+			return nil, fmt.Errorf("schema field %q: %w: %q", field.Name, ErrUnknownValueType, field.Type)
+		}
+		schema.Fields = append(schema.Fields, FieldSchema{
+			Name:     field.Name,
+			Type:     vtype,
+			Optional: field.Optional,
+		})
+	}
+	return schema, nil
+}
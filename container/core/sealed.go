@@ -0,0 +1,99 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// sealedVersion is the layout version of the plaintext header of a sealed
+// container
+const sealedVersion uint8 = 1
+
+// sealedKeySize is the AES-256 key length required by SealTo and OpenContainer
+const sealedKeySize = 32
+
+// ErrSealedAuthentication is returned by OpenContainer when the data cannot
+// be authenticated: the key is wrong or the ciphertext was modified
+var ErrSealedAuthentication = errors.New("sealed container authentication failed")
+
+// SealTo serializes the container in the given format and encrypts it with
+// AES-256-GCM under key, which must be 32 bytes long.
+//
+// Layout: a random 12-byte nonce followed by the GCM ciphertext (including
+// its 16-byte tag) of the plaintext, which is a header version (1 byte), the
+// format (1 byte) and the serialized container. Use OpenContainer to decrypt.
+func (c *ValueContainer) SealTo(key []byte, format SerializationFormat) ([]byte, error) {
+	aead, err := newSealedAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.Marshal(format)
+	if err != nil {
+		return nil, fmt.Errorf("%s serialization failed: %w", format, err)
+	}
+	plaintext := make([]byte, 0, 2+len(data))
+	plaintext = append(plaintext, sealedVersion, byte(format))
+	plaintext = append(plaintext, data...)
+
+	sealed := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(sealed); err != nil {
+		return nil, fmt.Errorf("nonce generation failed: %w", err)
+	}
+	return aead.Seal(sealed, sealed, plaintext, nil), nil
+}
+
+// OpenContainer decrypts data written by SealTo with key and deserializes
+// the container, returning it together with the format it was sealed in.
+// A wrong key or modified data returns ErrSealedAuthentication.
+func OpenContainer(key, data []byte) (*ValueContainer, SerializationFormat, error) {
+	aead, err := newSealedAEAD(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(data) < aead.NonceSize()+aead.Overhead() {
+		return nil, 0, fmt.Errorf("sealed data too short: %d bytes: %w", len(data), ErrTruncatedData)
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, 0, ErrSealedAuthentication
+	}
+
+	if len(plaintext) < 2 {
+		return nil, 0, fmt.Errorf("sealed plaintext too short: %d bytes: %w", len(plaintext), ErrTruncatedData)
+	}
+	if plaintext[0] != sealedVersion {
+		return nil, 0, fmt.Errorf("sealed container version %d: %w", plaintext[0], ErrUnsupportedVersion)
+	}
+	format := SerializationFormat(plaintext[1])
+
+	container := NewValueContainer()
+	if err := container.Unmarshal(plaintext[2:], format); err != nil {
+		return nil, 0, fmt.Errorf("%s deserialization failed: %w", format, err)
+	}
+	return container, format, nil
+}
+
+// newSealedAEAD returns the AES-256-GCM cipher for key
+func newSealedAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != sealedKeySize {
+		return nil, fmt.Errorf("sealed container key must be %d bytes, got %d", sealedKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
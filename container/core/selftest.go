@@ -0,0 +1,257 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+)
+
+// SelfTestCodec is a serialization format checked by SelfTest
+type SelfTestCodec struct {
+	Name   string
+	Encode func(c *ValueContainer) ([]byte, error)
+	Decode func(data []byte) (*ValueContainer, error)
+	// Supports reports whether the format can carry a value type; nil
+	// means every defined type. Unsupported types are left out of the
+	// canonical container for this codec.
+	Supports func(vtype ValueType) bool
+}
+
+var (
+	selfTestCodecsMu sync.RWMutex
+	selfTestCodecs   = []SelfTestCodec{{
+		Name: "binary",
+		Encode: func(c *ValueContainer) ([]byte, error) {
+			return c.SerializeBinary()
+		},
+		Decode: func(data []byte) (*ValueContainer, error) {
+			c := NewValueContainer()
+			return c, c.DeserializeBinary(data)
+		},
+	}}
+
+	// selfTestRequiredCodecs are registered by other packages but must be
+	// present for SelfTest to pass
+	selfTestRequiredCodecs = []string{"wire"}
+)
+
+// RegisterSelfTestCodec adds a codec to the set checked by SelfTest,
+// replacing any codec with the same name. Packages that implement a format
+// register it on import, as the wireprotocol package does for the C++ wire
+// format.
+func RegisterSelfTestCodec(codec SelfTestCodec) {
+	selfTestCodecsMu.Lock()
+	defer selfTestCodecsMu.Unlock()
+	for i := range selfTestCodecs {
+		if selfTestCodecs[i].Name == codec.Name {
+			selfTestCodecs[i] = codec
+			return
+		}
+	}
+	selfTestCodecs = append(selfTestCodecs, codec)
+}
+
+// SelfTest checks at runtime that a canonical container holding every value
+// type survives a round trip through the binary format and every registered
+// codec (see RegisterSelfTestCodec). Call it at service startup to catch a
+// dependency or build problem that would silently break interoperability.
+//
+// The returned error matches ErrSelfTestFailed and lists every codec and
+// value that failed. Values are named after their type, so a failure such
+// as `wire: ~ double: 0.1 -> 0.1000001` points at the broken type. The
+// values package must be imported so that the shared factory is registered.
+//
+// The C++ wire format is the cross-language check SelfTest exists for, so
+// SelfTest fails when no codec named "wire" is registered; import the
+// wireprotocol package to register it. Use SelfTestCodecs to check a
+// chosen set of codecs instead.
+func SelfTest() error {
+	selfTestCodecsMu.RLock()
+	codecs := append([]SelfTestCodec(nil), selfTestCodecs...)
+	selfTestCodecsMu.RUnlock()
+
+	var errs []error
+	for _, name := range selfTestRequiredCodecs {
+		if !hasSelfTestCodec(codecs, name) {
+			errs = append(errs, fmt.Errorf("%s: codec not registered (import the wireprotocol package)", name))
+		}
+	}
+	for _, codec := range codecs {
+		if err := selfTestCodec(codec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrSelfTestFailed, errors.Join(errs...))
+	}
+	return nil
+}
+
+// hasSelfTestCodec reports whether codecs holds a codec with the given name
+func hasSelfTestCodec(codecs []SelfTestCodec, name string) bool {
+	for _, codec := range codecs {
+		if codec.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SelfTestCodecs runs the SelfTest round trip for the given codecs only
+func SelfTestCodecs(codecs ...SelfTestCodec) error {
+	var errs []error
+	for _, codec := range codecs {
+		if err := selfTestCodec(codec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrSelfTestFailed, errors.Join(errs...))
+	}
+	return nil
+}
+
+// selfTestCodec round-trips the canonical container through one codec
+func selfTestCodec(codec SelfTestCodec) error {
+	original, err := selfTestContainer(codec.Supports)
+	if err != nil {
+		return fmt.Errorf("%s: build canonical container: %w", codec.Name, err)
+	}
+	data, err := codec.Encode(original)
+	if err != nil {
+		return fmt.Errorf("%s: encode: %w", codec.Name, err)
+	}
+	decoded, err := codec.Decode(data)
+	if err != nil {
+		return fmt.Errorf("%s: decode: %w", codec.Name, err)
+	}
+	if decoded == nil {
+		return fmt.Errorf("%s: decode returned no container", codec.Name)
+	}
+
+	var errs []error
+	if got, want := decoded.Header(), original.Header(); got != want {
+		errs = append(errs, fmt.Errorf("%s: header %+v, want %+v", codec.Name, got, want))
+	}
+	for _, diff := range original.Diff(decoded) {
+		errs = append(errs, fmt.Errorf("%s: %s", codec.Name, diff))
+	}
+	return errors.Join(errs...)
+}
+
+// selfTestContainer builds the canonical container with one value of every
+// supported type, each named after its type. Values are built from raw
+// payloads with the shared factory, so core needs no concrete value types.
+func selfTestContainer(supports func(ValueType) bool) (*ValueContainer, error) {
+	if supports == nil {
+		supports = func(ValueType) bool { return true }
+	}
+
+	le := binary.LittleEndian
+	var (
+		short = int16(-12345)
+		i32   = int32(-123456789)
+		long  = int32(-2000000000)
+		llong = int64(math.MinInt64 + 42)
+	)
+	payloads := []struct {
+		vtype   ValueType
+		payload []byte
+	}{
+		{NullValue, nil},
+		{BoolValue, []byte{1}},
+		{ShortValue, le.AppendUint16(nil, uint16(short))},
+		{UShortValue, le.AppendUint16(nil, 54321)},
+		{IntValue, le.AppendUint32(nil, uint32(i32))},
+		{UIntValue, le.AppendUint32(nil, 3000000000)},
+		{LongValue, le.AppendUint32(nil, uint32(long))},
+		{ULongValue, le.AppendUint32(nil, 4000000000)},
+		{LLongValue, le.AppendUint64(nil, uint64(llong))},
+		{ULLongValue, le.AppendUint64(nil, math.MaxUint64-42)},
+		{FloatValue, le.AppendUint32(nil, math.Float32bits(-1.5))},
+		{DoubleValue, le.AppendUint64(nil, math.Float64bits(1234.5678))},
+		{BytesValue, []byte{0x00, 0x7f, 0x80, 0xff}},
+		{StringValue, []byte("self test")},
+		{DateTimeValue, le.AppendUint64(nil, 1700000000123456789)},
+		{UUIDValue, []byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}},
+		{DecimalValue, EncodeDecimal(big.NewInt(-1234567), 3)},
+	}
+
+	container := NewValueContainerFull("selftest_source", "1", "selftest_target", "2", "selftest")
+	for _, p := range payloads {
+		if !supports(p.vtype) {
+			continue
+		}
+		value, err := NewValueFromData(p.vtype.TypeName(), p.vtype, p.payload)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.vtype.TypeName(), err)
+		}
+		container.AddValue(value)
+	}
+
+	// Nested values and map entries use only the primitive types every
+	// format carries
+	children := []struct {
+		name    string
+		vtype   ValueType
+		payload []byte
+	}{
+		{"id", IntValue, le.AppendUint32(nil, 7)},
+		{"label", StringValue, []byte("nested")},
+	}
+	for _, nested := range []ValueType{ContainerValue, ArrayValue} {
+		if !supports(nested) {
+			continue
+		}
+		payload := le.AppendUint32(nil, uint32(len(children)))
+		for _, child := range children {
+			name := child.name
+			if nested == ArrayValue {
+				name = ""
+			}
+			value, err := NewValueFromData(name, child.vtype, child.payload)
+			if err != nil {
+				return nil, fmt.Errorf("%s element: %w", nested.TypeName(), err)
+			}
+			if payload, err = AppendValueFrame(payload, value); err != nil {
+				return nil, fmt.Errorf("%s element: %w", nested.TypeName(), err)
+			}
+		}
+		value, err := NewValueFromData(nested.TypeName(), nested, payload)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", nested.TypeName(), err)
+		}
+		container.AddValue(value)
+	}
+
+	if supports(MapValue) {
+		entries := make([]mapEntry, 0, len(children))
+		for _, child := range children {
+			value, err := NewValueFromData("", child.vtype, child.payload)
+			if err != nil {
+				return nil, fmt.Errorf("map entry: %w", err)
+			}
+			entries = append(entries, mapEntry{key: child.name, value: value})
+		}
+		payload, err := mapPayload(entries)
+		if err != nil {
+			return nil, fmt.Errorf("map: %w", err)
+		}
+		value, err := NewValueFromData(MapValue.TypeName(), MapValue, payload)
+		if err != nil {
+			return nil, fmt.Errorf("map: %w", err)
+		}
+		container.AddValue(value)
+	}
+	return container, nil
+}
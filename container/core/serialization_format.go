@@ -0,0 +1,134 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// SerializationFormat identifies one of the container serialization formats
+type SerializationFormat uint8
+
+const (
+	// FormatString is the pipe-delimited text format (SerializeArray)
+	FormatString SerializationFormat = iota
+	// FormatJSON is the JSON format (ToJSON)
+	FormatJSON
+	// FormatXML is the XML format (ToXML)
+	FormatXML
+	// FormatMessagePack is the MessagePack format (ToMessagePack)
+	FormatMessagePack
+	// FormatBinary is the binary container format (SerializeBinary)
+	FormatBinary
+)
+
+// String returns the name of the format
+func (f SerializationFormat) String() string {
+	switch f {
+	case FormatString:
+		return "string"
+	case FormatJSON:
+		return "json"
+	case FormatXML:
+		return "xml"
+	case FormatMessagePack:
+		return "msgpack"
+	case FormatBinary:
+		return "binary"
+	default:
+		return fmt.Sprintf("SerializationFormat(%d)", uint8(f))
+	}
+}
+
+// Marshal serializes the container in the given format, dispatching to
+// SerializeArray, ToJSON, ToXML, ToMessagePack or SerializeBinary. The
+// String and MessagePack formats are streamed through WriteTo and
+// WriteMessagePackTo, which produce the same bytes.
+func (c *ValueContainer) Marshal(format SerializationFormat) ([]byte, error) {
+	switch format {
+	case FormatString:
+		var buf bytes.Buffer
+		buf.Grow(c.Size())
+		if _, err := c.WriteTo(&buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatJSON:
+		s, err := c.ToJSON()
+		return []byte(s), err
+	case FormatXML:
+		s, err := c.ToXML()
+		return []byte(s), err
+	case FormatMessagePack:
+		var buf bytes.Buffer
+		buf.Grow(c.sizeHint(messagePackValueOverhead))
+		if err := c.WriteMessagePackTo(&buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatBinary:
+		return c.SerializeBinary()
+	default:
+		return nil, fmt.Errorf("unsupported serialization format: %s", format)
+	}
+}
+
+// Unmarshal deserializes data in the given format into the container,
+// dispatching to DeserializeArray, FromJSON, FromXML, FromMessagePack or
+// DeserializeBinary. DeserializeArray and FromMessagePack restore only the
+// header.
+func (c *ValueContainer) Unmarshal(data []byte, format SerializationFormat) error {
+	switch format {
+	case FormatString:
+		return c.DeserializeArray(data)
+	case FormatJSON:
+		return c.FromJSON(string(data))
+	case FormatXML:
+		return c.FromXML(string(data))
+	case FormatMessagePack:
+		return c.FromMessagePack(data)
+	case FormatBinary:
+		return c.DeserializeBinary(data)
+	default:
+		return fmt.Errorf("unsupported serialization format: %s", format)
+	}
+}
+
+// headerDocument mirrors the header fields written by ToJSON and ToXML
+type headerDocument struct {
+	SourceID    string `json:"source_id" xml:"source_id"`
+	SourceSubID string `json:"source_sub_id" xml:"source_sub_id"`
+	TargetID    string `json:"target_id" xml:"target_id"`
+	TargetSubID string `json:"target_sub_id" xml:"target_sub_id"`
+	MessageType string `json:"message_type" xml:"message_type"`
+	Version     string `json:"version" xml:"version"`
+	// CreatedAt is in epoch milliseconds; JSON only
+	CreatedAt *int64 `json:"created_at,omitempty" xml:"-"`
+}
+
+func (d headerDocument) header() Header {
+	return Header{
+		SourceID:    d.SourceID,
+		SourceSubID: d.SourceSubID,
+		TargetID:    d.TargetID,
+		TargetSubID: d.TargetSubID,
+		MessageType: d.MessageType,
+		Version:     d.Version,
+		CreatedAt:   createdAtFromMillis(d.CreatedAt),
+	}
+}
+
+// createdAtFromMillis converts an optional epoch-milliseconds timestamp
+func createdAtFromMillis(ms *int64) time.Time {
+	if ms == nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(*ms).UTC()
+}
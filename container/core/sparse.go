@@ -0,0 +1,92 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+// SerializeSparse serializes the container in the binary container format
+// (see SerializeBinary), omitting the values of every name whose values are
+// equal to those of the same name in defaults. Names are compared as a
+// whole: when a name repeats and any occurrence differs, or the number of
+// occurrences differs, every occurrence of that name is written, so that
+// positions are not lost on load. The header is always written in full.
+//
+// Use DeserializeSparse with the same defaults to restore the full container.
+// Removing a default cannot be expressed: on load, every default that is not
+// overridden is filled back in.
+func (c *ValueContainer) SerializeSparse(defaults *ValueContainer) ([]byte, error) {
+	defaultsByName := valuesByName(defaults)
+	ownByName := valuesByName(c)
+	units := c.unitsSnapshot()
+
+	sparse := NewValueContainer()
+	sparse.SetHeader(c.Header())
+	for _, value := range units {
+		name := value.Name()
+		if def, ok := defaultsByName[name]; ok && valueListsEqual(ownByName[name], def) {
+			continue
+		}
+		sparse.units = append(sparse.units, value)
+	}
+
+	return sparse.SerializeBinary()
+}
+
+// DeserializeSparse decodes data written by SerializeSparse and fills in the
+// omitted values from defaults.
+//
+// The result follows the order of defaults. The stored values of a name
+// replace all of its defaults, in their serialized order, at the position
+// of its first default; stored values that have no default are appended in
+// their serialized order. Filled-in defaults are cloned, so the result does
+// not share values with defaults.
+func DeserializeSparse(data []byte, defaults *ValueContainer) (*ValueContainer, error) {
+	sparse := NewValueContainer()
+	if err := sparse.DeserializeBinary(data); err != nil {
+		return nil, err
+	}
+	overridesByName := valuesByName(sparse)
+
+	result := NewValueContainer()
+	result.SetHeader(sparse.Header())
+
+	var defaultUnits []Value
+	if defaults != nil {
+		defaultUnits = defaults.unitsSnapshot()
+	}
+	defaultNames := make(map[string]bool, len(defaultUnits))
+	for _, value := range defaultUnits {
+		name := value.Name()
+		if overrides, ok := overridesByName[name]; ok {
+			if !defaultNames[name] {
+				result.units = append(result.units, overrides...)
+			}
+		} else {
+			result.units = append(result.units, value.Clone())
+		}
+		defaultNames[name] = true
+	}
+	for _, value := range sparse.units {
+		if !defaultNames[value.Name()] {
+			result.units = append(result.units, value)
+		}
+	}
+
+	return result, nil
+}
+
+// valuesByName groups the values of c by name, keeping their order
+func valuesByName(c *ValueContainer) map[string][]Value {
+	if c == nil {
+		return nil
+	}
+	units := c.unitsSnapshot()
+	byName := make(map[string][]Value, len(units))
+	for _, value := range units {
+		byName[value.Name()] = append(byName[value.Name()], value)
+	}
+	return byName
+}
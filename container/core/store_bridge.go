@@ -0,0 +1,55 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "strconv"
+
+// ToStore returns a ValueStore holding the container's values keyed by
+// value name, in container order. The header is not carried over.
+//
+// Duplicate names are kept rather than overwritten: the first value with a
+// name is stored under the name itself, and each later one under the name
+// with the smallest free "#n" suffix ("reading", "reading#1", "reading#2").
+// Only the keys are suffixed; the stored values keep their names, so
+// StoreToContainer restores the original container values.
+// Values are shared with the container, not copied.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) ToStore() *ValueStore {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	store := NewValueStore()
+	for _, unit := range c.units {
+		key := unit.Name()
+		for n := 1; store.Contains(key); n++ {
+			key = unit.Name() + "#" + strconv.Itoa(n)
+		}
+		store.Add(key, unit)
+	}
+	return store
+}
+
+// StoreToContainer returns a container with the given message type holding
+// the store's values in insertion order. Each value keeps its own name, so
+// a store produced by ToStore converts back to the original values even for
+// suffixed duplicate keys; a value with an empty name is renamed to its key.
+// Values are shared with the store, not copied.
+func StoreToContainer(vs *ValueStore, messageType string) *ValueContainer {
+	container := NewValueContainerWithType(messageType)
+	vs.Range(func(key string, value Value) bool {
+		if value.Name() == "" && key != "" {
+			if renamed, err := renameValue(value, key); err == nil {
+				value = renamed
+			}
+		}
+		container.AddValue(value)
+		return true
+	})
+	return container
+}
@@ -0,0 +1,80 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "strings"
+
+// TextFieldDelimiter separates fields in the legacy pipe-delimited text format
+const TextFieldDelimiter = '|'
+
+// EscapeTextField escapes a field for the pipe-delimited text format.
+//
+// A backslash is used as the escape character:
+//   - `\` becomes `\\`
+//   - `|` becomes `\|`
+//   - newline becomes `\n`
+//   - carriage return becomes `\r`
+//
+// This keeps names and header fields containing the delimiter or line breaks
+// from corrupting the header/value line structure.
+func EscapeTextField(s string) string {
+	if !strings.ContainsAny(s, "\\|\n\r") {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s) + 4)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			sb.WriteString(`\\`)
+		case TextFieldDelimiter:
+			sb.WriteString(`\|`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+// SplitTextFields splits a line of the text format on unescaped delimiters
+// and unescapes each resulting field. It is the inverse of joining fields
+// produced by EscapeTextField with TextFieldDelimiter.
+func SplitTextFields(line string) []string {
+	fields := make([]string, 0, 8)
+	var sb strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case ch == '\\' && i+1 < len(line):
+			i++
+			switch line[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			default:
+				// `\\`, `\|` and any unknown escape yield the literal character
+				sb.WriteByte(line[i])
+			}
+		case ch == TextFieldDelimiter:
+			fields = append(fields, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	fields = append(fields, sb.String())
+
+	return fields
+}
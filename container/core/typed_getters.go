@@ -0,0 +1,92 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import "errors"
+
+// errTypeMismatch is returned internally when a value has the wrong type
+var errTypeMismatch = errors.New("type conversion not supported")
+
+// getTyped looks up the first value with the given name and converts it.
+// It returns the zero value and false when the name is missing (including
+// the null sentinel returned by GetValue) or when the conversion fails.
+func getTyped[T any](c *ValueContainer, name string, convert func(Value) (T, error)) (T, bool) {
+	var zero T
+	value := c.GetValue(name, 0)
+	if value == nil || value.IsNull() {
+		return zero, false
+	}
+	result, err := convert(value)
+	if err != nil {
+		return zero, false
+	}
+	return result, true
+}
+
+// GetBool returns the named value as a bool
+func GetBool(c *ValueContainer, name string) (bool, bool) {
+	return getTyped(c, name, Value.ToBool)
+}
+
+// GetInt16 returns the named value as an int16
+func GetInt16(c *ValueContainer, name string) (int16, bool) {
+	return getTyped(c, name, Value.ToInt16)
+}
+
+// GetUInt16 returns the named value as a uint16
+func GetUInt16(c *ValueContainer, name string) (uint16, bool) {
+	return getTyped(c, name, Value.ToUInt16)
+}
+
+// GetInt32 returns the named value as an int32
+func GetInt32(c *ValueContainer, name string) (int32, bool) {
+	return getTyped(c, name, Value.ToInt32)
+}
+
+// GetUInt32 returns the named value as a uint32
+func GetUInt32(c *ValueContainer, name string) (uint32, bool) {
+	return getTyped(c, name, Value.ToUInt32)
+}
+
+// GetInt64 returns the named value as an int64
+func GetInt64(c *ValueContainer, name string) (int64, bool) {
+	return getTyped(c, name, Value.ToInt64)
+}
+
+// GetUInt64 returns the named value as a uint64
+func GetUInt64(c *ValueContainer, name string) (uint64, bool) {
+	return getTyped(c, name, Value.ToUInt64)
+}
+
+// GetFloat32 returns the named value as a float32
+func GetFloat32(c *ValueContainer, name string) (float32, bool) {
+	return getTyped(c, name, Value.ToFloat32)
+}
+
+// GetFloat64 returns the named value as a float64
+func GetFloat64(c *ValueContainer, name string) (float64, bool) {
+	return getTyped(c, name, Value.ToFloat64)
+}
+
+// GetString returns the named value as a string
+func GetString(c *ValueContainer, name string) (string, bool) {
+	return getTyped(c, name, Value.ToString)
+}
+
+// GetBytes returns the raw payload of the named BytesValue.
+// Other value types are reported as a type mismatch.
+func GetBytes(c *ValueContainer, name string) ([]byte, bool) {
+	return getTyped(c, name, func(v Value) ([]byte, error) {
+		if v.Type() != BytesValue {
+			return nil, errTypeMismatch
+		}
+		data := make([]byte, len(v.Data()))
+		copy(data, v.Data())
+		return data, nil
+	})
+}
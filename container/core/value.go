@@ -35,7 +35,6 @@ package core
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
 	"fmt"
 )
 
@@ -43,9 +42,11 @@ import (
 type Value interface {
 	// Basic accessors
 	Name() string
+	SetName(name string)
 	Type() ValueType
 	Data() []byte
 	Size() int
+	SerializedSize() int
 
 	// Type checking
 	IsNull() bool
@@ -73,6 +74,9 @@ type Value interface {
 	ToXML() (string, error)
 	ToJSON() (string, error)
 
+	// Copying
+	Clone() Value
+
 	// For container values
 	Children() []Value
 	ChildCount() int
@@ -105,6 +109,11 @@ func (v *BaseValue) Name() string {
 	return v.name
 }
 
+// SetName renames the value in place
+func (v *BaseValue) SetName(name string) {
+	v.name = name
+}
+
 // Type returns the type of the value
 func (v *BaseValue) Type() ValueType {
 	return v.vtype
@@ -120,6 +129,13 @@ func (v *BaseValue) Size() int {
 	return len(v.data)
 }
 
+// SerializedSize returns the length of the value's binary frame (see
+// FrameBytes)
+// Format: [type:1][name_len:4][name][value_size:4][data]
+func (v *BaseValue) SerializedSize() int {
+	return 1 + 4 + len(v.name) + 4 + len(v.data)
+}
+
 // IsNull checks if the value is null
 func (v *BaseValue) IsNull() bool {
 	return v.vtype == NullValue
@@ -137,7 +153,7 @@ func (v *BaseValue) IsBoolean() bool {
 
 // IsNumeric checks if the value is numeric
 func (v *BaseValue) IsNumeric() bool {
-	return v.vtype >= ShortValue && v.vtype <= DoubleValue
+	return v.vtype.IsNumeric()
 }
 
 // IsString checks if the value is string
@@ -152,74 +168,47 @@ func (v *BaseValue) IsContainer() bool {
 
 // ToBool converts to boolean (default implementation)
 func (v *BaseValue) ToBool() (bool, error) {
-	if v.IsNull() {
-		return false, errors.New("cannot convert null_value to bool")
-	}
-	return false, errors.New("type conversion not supported")
+	return false, v.conversionError("bool")
 }
 
 // ToInt16 converts to int16 (default implementation)
 func (v *BaseValue) ToInt16() (int16, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to int16")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("int16")
 }
 
 // ToUInt16 converts to uint16 (default implementation)
 func (v *BaseValue) ToUInt16() (uint16, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to uint16")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("uint16")
 }
 
 // ToInt32 converts to int32 (default implementation)
 func (v *BaseValue) ToInt32() (int32, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to int32")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("int32")
 }
 
 // ToUInt32 converts to uint32 (default implementation)
 func (v *BaseValue) ToUInt32() (uint32, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to uint32")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("uint32")
 }
 
 // ToInt64 converts to int64 (default implementation)
 func (v *BaseValue) ToInt64() (int64, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to int64")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("int64")
 }
 
 // ToUInt64 converts to uint64 (default implementation)
 func (v *BaseValue) ToUInt64() (uint64, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to uint64")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("uint64")
 }
 
 // ToFloat32 converts to float32 (default implementation)
 func (v *BaseValue) ToFloat32() (float32, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to float32")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("float32")
 }
 
 // ToFloat64 converts to float64 (default implementation)
 func (v *BaseValue) ToFloat64() (float64, error) {
-	if v.IsNull() {
-		return 0, errors.New("cannot convert null_value to float64")
-	}
-	return 0, errors.New("type conversion not supported")
+	return 0, v.conversionError("float64")
 }
 
 // ToString converts to string (default implementation)
@@ -227,26 +216,40 @@ func (v *BaseValue) ToString() (string, error) {
 	if v.IsNull() {
 		return "", nil
 	}
-	return "", errors.New("type conversion not supported")
+	return "", v.conversionError("string")
+}
+
+// conversionError reports an unsupported conversion to the target type,
+// wrapping ErrTypeConversion
+func (v *BaseValue) conversionError(target string) error {
+	if v.IsNull() {
+		return fmt.Errorf("cannot convert null_value to %s: %w", target, ErrTypeConversion)
+	}
+	return fmt.Errorf("%w: %s to %s", ErrTypeConversion, v.vtype.TypeName(), target)
 }
 
-// ToBytes converts to bytes
+// ToBytes converts to bytes: the raw payload, the same as Data(). Most typed
+// values in package values override it with their binary frame (null, long and
+// ulong values do not); use FrameBytes or AppendValueFrame to frame any value.
 func (v *BaseValue) ToBytes() ([]byte, error) {
 	return v.data, nil
 }
 
 // Serialize serializes the value to string
 func (v *BaseValue) Serialize() (string, error) {
-	return fmt.Sprintf("%s|%s|%d", v.name, v.vtype.String(), len(v.data)), nil
+	return fmt.Sprintf("%s|%s|%d", EscapeTextField(v.name), v.vtype.String(), len(v.data)), nil
 }
 
-// ToXML converts to XML representation
+// ToXML converts to XML representation. Scalars are written as text:
+// numbers in decimal, bools as true/false and bytes as base64 with an
+// encoding="base64" attribute.
 func (v *BaseValue) ToXML() (string, error) {
 	type XMLValue struct {
-		XMLName xml.Name `xml:"value"`
-		Name    string   `xml:"name,attr"`
-		Type    string   `xml:"type,attr"`
-		Data    string   `xml:",chardata"`
+		XMLName  xml.Name `xml:"value"`
+		Name     string   `xml:"name,attr"`
+		Type     string   `xml:"type,attr"`
+		Encoding string   `xml:"encoding,attr,omitempty"`
+		Data     string   `xml:",chardata"`
 	}
 
 	xmlVal := XMLValue{
@@ -254,6 +257,10 @@ func (v *BaseValue) ToXML() (string, error) {
 		Type: v.vtype.TypeName(),
 		Data: string(v.data),
 	}
+	if text, encoding, ok := scalarText(v.vtype, v.data); ok {
+		xmlVal.Data = text
+		xmlVal.Encoding = encoding
+	}
 
 	data, err := xml.MarshalIndent(xmlVal, "", "  ")
 	if err != nil {
@@ -277,6 +284,53 @@ func (v *BaseValue) ToJSON() (string, error) {
 	return string(data), nil
 }
 
+// Clone returns a deep copy of the value.
+// The data buffer and any child values are copied, so the clone shares no
+// mutable state with the original. The parent link is not copied.
+func (v *BaseValue) Clone() Value {
+	return v.CloneBase()
+}
+
+// FramedSize returns the exact length of v's binary frame,
+// type(1) + name_len(4) + name + value_size(4) + payload, counting nested
+// array, container and map elements recursively. Callers can pre-allocate
+// buffers with it without serializing; it returns 0 for a nil value.
+func FramedSize(v Value) int {
+	if v == nil {
+		return 0
+	}
+	return v.SerializedSize()
+}
+
+// CloneValue returns an independent copy of v, recursing into container and
+// array children through their Clone methods. It returns nil for a nil value.
+func CloneValue(v Value) Value {
+	if v == nil {
+		return nil
+	}
+	return v.Clone()
+}
+
+// CloneBase returns a deep copy of the embedded BaseValue.
+// Concrete value types use it to build their own Clone implementations.
+func (v *BaseValue) CloneBase() *BaseValue {
+	var data []byte
+	if v.data != nil {
+		data = make([]byte, len(v.data))
+		copy(data, v.data)
+	}
+	units := make([]Value, len(v.units))
+	for i, unit := range v.units {
+		units[i] = unit.Clone()
+	}
+	return &BaseValue{
+		name:  v.name,
+		vtype: v.vtype,
+		data:  data,
+		units: units,
+	}
+}
+
 // Children returns child values (for container values)
 func (v *BaseValue) Children() []Value {
 	return v.units
@@ -303,10 +357,10 @@ func (v *BaseValue) GetChild(name string, index int) Value {
 
 // AddChild adds a child value (default implementation throws error)
 func (v *BaseValue) AddChild(child Value) error {
-	return errors.New("cannot add child to non-container value")
+	return fmt.Errorf("cannot add child to %s value: %w", v.vtype.TypeName(), ErrNotContainer)
 }
 
 // RemoveChild removes a child by name (default implementation throws error)
 func (v *BaseValue) RemoveChild(name string) error {
-	return errors.New("cannot remove child from non-container value")
+	return fmt.Errorf("cannot remove child from %s value: %w", v.vtype.TypeName(), ErrNotContainer)
 }
@@ -0,0 +1,143 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ValueFactory creates a typed Value from its name, type and raw payload.
+//
+// The payload is the part of the binary frame that follows value_size:
+// Data() for primitive types, and [count:4][children...] for containers
+// and arrays.
+type ValueFactory func(name string, vtype ValueType, data []byte) (Value, error)
+
+// ErrNoValueFactory is returned when a value must be rebuilt but no factory
+// has been registered. Importing the values package registers one.
+var ErrNoValueFactory = errors.New("no value factory registered (import container/values)")
+
+// ErrUnknownValueType is returned in strict mode for a type code outside the
+// defined range (see ValueType.IsDefined)
+var ErrUnknownValueType = errors.New("unknown value type")
+
+// FactoryMode selects how deserializers treat type codes they do not define
+type FactoryMode int
+
+const (
+	// FactoryStrict rejects unknown type codes with ErrUnknownValueType.
+	// This is the default.
+	FactoryStrict FactoryMode = iota
+	// FactoryLenient keeps values of unknown type as opaque BaseValues that
+	// carry the original type code and payload, so data written by a newer
+	// peer survives a round trip.
+	FactoryLenient
+)
+
+var (
+	valueFactoryMu sync.RWMutex
+	valueFactory   ValueFactory
+	factoryMode    = FactoryStrict
+)
+
+// RegisterValueFactory installs the shared value factory used by core
+// deserializers. The values package registers its factory on import, so
+// most callers never need to call this directly.
+func RegisterValueFactory(factory ValueFactory) {
+	valueFactoryMu.Lock()
+	defer valueFactoryMu.Unlock()
+	valueFactory = factory
+}
+
+// SetFactoryMode sets how NewValueFromData and the values package
+// deserializers treat unknown type codes
+func SetFactoryMode(mode FactoryMode) {
+	valueFactoryMu.Lock()
+	defer valueFactoryMu.Unlock()
+	factoryMode = mode
+}
+
+// CurrentFactoryMode returns the mode set by SetFactoryMode
+func CurrentFactoryMode() FactoryMode {
+	valueFactoryMu.RLock()
+	defer valueFactoryMu.RUnlock()
+	return factoryMode
+}
+
+// NewValueFromData builds a typed Value using the registered factory.
+// Unknown type codes are handled according to CurrentFactoryMode.
+func NewValueFromData(name string, vtype ValueType, data []byte) (Value, error) {
+	if !vtype.IsDefined() {
+		return NewUnknownValue(name, vtype, data)
+	}
+
+	valueFactoryMu.RLock()
+	factory := valueFactory
+	valueFactoryMu.RUnlock()
+
+	if factory == nil {
+		return nil, ErrNoValueFactory
+	}
+	return factory(name, vtype, data)
+}
+
+// NewUnknownValue applies the factory mode to a value whose type code is
+// not defined. In strict mode it returns ErrUnknownValueType; in lenient
+// mode it returns a BaseValue holding a copy of the raw payload.
+func NewUnknownValue(name string, vtype ValueType, data []byte) (Value, error) {
+	if CurrentFactoryMode() != FactoryLenient {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownValueType, int(vtype))
+	}
+	return NewBaseValue(name, vtype, append([]byte(nil), data...)), nil
+}
+
+// RawPayload returns the payload that NewValueFromData accepts for v.
+//
+// For primitives this is Data(). Containers and arrays do not keep their
+// payload in Data(), so it is taken from the body of their ToBytes() frame.
+func RawPayload(v Value) ([]byte, error) {
+	if v.Type() != ContainerValue && v.Type() != ArrayValue {
+		return v.Data(), nil
+	}
+
+	frame, err := v.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	// [type:1][name_len:4][name][value_size:4][payload]
+	if len(frame) < 9 {
+		return nil, errors.New("invalid frame: too short")
+	}
+	nameLen := int(binary.LittleEndian.Uint32(frame[1:5]))
+	offset := 5 + nameLen
+	if offset+4 > len(frame) {
+		return nil, errors.New("invalid frame: name exceeds bounds")
+	}
+	valueSize := int(binary.LittleEndian.Uint32(frame[offset : offset+4]))
+	offset += 4
+	if offset+valueSize > len(frame) {
+		return nil, errors.New("invalid frame: payload exceeds bounds")
+	}
+	return frame[offset : offset+valueSize], nil
+}
+
+// nestedPayload encodes values as the payload of a container or array:
+// [count:4][child frames...]
+func nestedPayload(children []Value) ([]byte, error) {
+	payload := binary.LittleEndian.AppendUint32(nil, uint32(len(children)))
+	var err error
+	for _, child := range children {
+		if payload, err = AppendValueFrame(payload, child); err != nil {
+			return nil, err
+		}
+	}
+	return payload, nil
+}
@@ -34,9 +34,10 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package core
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 )
@@ -62,6 +63,9 @@ type ValueStore struct {
 	// Key-value storage
 	values map[string]Value
 
+	// Keys in insertion order, maintained alongside values
+	order []string
+
 	// Thread safety
 	mutex            sync.RWMutex
 	threadSafeEnabled atomic.Bool
@@ -76,6 +80,7 @@ type ValueStore struct {
 func NewValueStore() *ValueStore {
 	return &ValueStore{
 		values: make(map[string]Value),
+		order:  make([]string, 0),
 	}
 }
 
@@ -92,10 +97,20 @@ func (vs *ValueStore) Add(key string, value Value) {
 		defer vs.mutex.Unlock()
 	}
 
-	vs.values[key] = value
+	vs.set(key, value)
 	vs.writeCount.Add(1)
 }
 
+// set stores a value and records the key's insertion position.
+// Overwriting an existing key keeps its original position.
+// Caller must hold the write lock when thread safety is enabled.
+func (vs *ValueStore) set(key string, value Value) {
+	if _, exists := vs.values[key]; !exists {
+		vs.order = append(vs.order, key)
+	}
+	vs.values[key] = value
+}
+
 // Get retrieves a value by key.
 // Returns nil if the key doesn't exist.
 // Thread-safe if EnableThreadSafety was called.
@@ -135,6 +150,12 @@ func (vs *ValueStore) Remove(key string) bool {
 	_, exists := vs.values[key]
 	if exists {
 		delete(vs.values, key)
+		for i, k := range vs.order {
+			if k == key {
+				vs.order = append(vs.order[:i], vs.order[i+1:]...)
+				break
+			}
+		}
 		return true
 	}
 	return false
@@ -148,6 +169,7 @@ func (vs *ValueStore) Clear() {
 	}
 
 	vs.values = make(map[string]Value)
+	vs.order = make([]string, 0)
 }
 
 // Size returns the number of stored values.
@@ -165,30 +187,28 @@ func (vs *ValueStore) Empty() bool {
 	return vs.Size() == 0
 }
 
-// Keys returns all keys.
+// Keys returns all keys in insertion order.
 func (vs *ValueStore) Keys() []string {
 	if vs.threadSafeEnabled.Load() {
 		vs.mutex.RLock()
 		defer vs.mutex.RUnlock()
 	}
 
-	keys := make([]string, 0, len(vs.values))
-	for key := range vs.values {
-		keys = append(keys, key)
-	}
+	keys := make([]string, len(vs.order))
+	copy(keys, vs.order)
 	return keys
 }
 
-// Values returns all values.
+// Values returns all values in insertion order.
 func (vs *ValueStore) Values() []Value {
 	if vs.threadSafeEnabled.Load() {
 		vs.mutex.RLock()
 		defer vs.mutex.RUnlock()
 	}
 
-	values := make([]Value, 0, len(vs.values))
-	for _, value := range vs.values {
-		values = append(values, value)
+	values := make([]Value, 0, len(vs.order))
+	for _, key := range vs.order {
+		values = append(values, vs.values[key])
 	}
 	return values
 }
@@ -205,6 +225,7 @@ type valueJSON struct {
 }
 
 // Serialize serializes to JSON string.
+// Entries are emitted in insertion order.
 func (vs *ValueStore) Serialize() (string, error) {
 	if vs.threadSafeEnabled.Load() {
 		vs.mutex.RLock()
@@ -213,30 +234,50 @@ func (vs *ValueStore) Serialize() (string, error) {
 
 	vs.serializationCount.Add(1)
 
-	result := make(map[string]valueJSON)
+	// encoding/json sorts map keys, so the object is assembled by hand
+	// to preserve insertion order and then indented.
+	var compact bytes.Buffer
+	compact.WriteByte('{')
 
-	for key, value := range vs.values {
+	for i, key := range vs.order {
+		value := vs.values[key]
 		dataStr, err := value.ToString()
 		if err != nil {
 			dataStr = ""
 		}
 
-		result[key] = valueJSON{
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+		entryBytes, err := json.Marshal(valueJSON{
 			Name: value.Name(),
 			Type: value.Type().TypeName(),
 			Data: dataStr,
+		})
+		if err != nil {
+			return "", err
 		}
+
+		if i > 0 {
+			compact.WriteByte(',')
+		}
+		compact.Write(keyBytes)
+		compact.WriteByte(':')
+		compact.Write(entryBytes)
 	}
+	compact.WriteByte('}')
 
-	jsonBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
 		return "", err
 	}
 
-	return string(jsonBytes), nil
+	return indented.String(), nil
 }
 
 // SerializeBinary serializes to binary format.
+// Entries are emitted in insertion order.
 //
 // Binary format:
 //   - Version byte (1)
@@ -246,7 +287,7 @@ func (vs *ValueStore) Serialize() (string, error) {
 //   - Key data (UTF-8)
 //   - Value type (1 byte)
 //   - Value length (4 bytes, uint32, little-endian)
-//   - Value data
+//   - Value data (raw payload, see RawPayload)
 func (vs *ValueStore) SerializeBinary() ([]byte, error) {
 	if vs.threadSafeEnabled.Load() {
 		vs.mutex.RLock()
@@ -257,8 +298,14 @@ func (vs *ValueStore) SerializeBinary() ([]byte, error) {
 
 	// Pre-calculate size for efficiency
 	size := 1 + 4 // version + count
-	for key, value := range vs.values {
-		size += 4 + len(key) + 1 + 4 + len(value.Data())
+	payloads := make([][]byte, len(vs.order))
+	for i, key := range vs.order {
+		payload, err := RawPayload(vs.values[key])
+		if err != nil {
+			return nil, err
+		}
+		payloads[i] = payload
+		size += 4 + len(key) + 1 + 4 + len(payload)
 	}
 
 	result := make([]byte, 0, size)
@@ -272,7 +319,8 @@ func (vs *ValueStore) SerializeBinary() ([]byte, error) {
 	result = append(result, countBytes...)
 
 	// Serialize each key-value pair
-	for key, value := range vs.values {
+	for i, key := range vs.order {
+		value := vs.values[key]
 		// Key length and key
 		keyBytes := []byte(key)
 		keyLenBytes := make([]byte, 4)
@@ -284,7 +332,7 @@ func (vs *ValueStore) SerializeBinary() ([]byte, error) {
 		result = append(result, byte(value.Type()))
 
 		// Value data
-		valueData := value.Data()
+		valueData := payloads[i]
 		valueLenBytes := make([]byte, 4)
 		binary.LittleEndian.PutUint32(valueLenBytes, uint32(len(valueData)))
 		result = append(result, valueLenBytes...)
@@ -294,24 +342,70 @@ func (vs *ValueStore) SerializeBinary() ([]byte, error) {
 	return result, nil
 }
 
+// BinaryOption configures DeserializeBinary
+type BinaryOption func(*binaryOptions)
+
+type binaryOptions struct {
+	legacy bool
+}
+
+// WithLegacyCompat makes DeserializeBinary accept data written before the
+// version byte existed, or with an unknown version byte, and parse it on a
+// best-effort basis as version 0. Data that starts with BinaryVersion is
+// still read as the current format first. Otherwise the data is read as if
+// it had no version byte, and failing that, with its first byte skipped as
+// an unknown version.
+func WithLegacyCompat() BinaryOption {
+	return func(o *binaryOptions) {
+		o.legacy = true
+	}
+}
+
 // DeserializeBinary deserializes from binary format.
-// Note: This requires a ValueFactory function to create values from type and data.
-// For now, this returns an error as it requires integration with value factories.
-func DeserializeBinary(data []byte, factory func(name string, vtype ValueType, data []byte) (Value, error)) (*ValueStore, error) {
-	if len(data) < 5 {
-		return nil, errors.New("invalid data: too small")
+// The factory creates each value from its key, type and raw payload; entries
+// are skipped when factory is nil. Use LoadValueStore to deserialize with the
+// shared value factory. Pass WithLegacyCompat to read data from older writers.
+func DeserializeBinary(data []byte, factory ValueFactory, opts ...BinaryOption) (*ValueStore, error) {
+	var options binaryOptions
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	offset := 0
+	if len(data) >= 1 && data[0] == BinaryVersion {
+		store, err := deserializeBinaryEntries(data[1:], factory)
+		if err == nil || !options.legacy {
+			return store, err
+		}
+		// Version 0 data whose count happens to start with BinaryVersion
+		return deserializeBinaryEntries(data, factory)
+	}
+
+	if !options.legacy {
+		if len(data) < 5 {
+			return nil, fmt.Errorf("invalid data: too small: %w", ErrTruncatedData)
+		}
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[0])
+	}
 
-	// Read version
-	version := data[offset]
-	offset++
+	// Version 0: no version byte, or an unknown one
+	store, err := deserializeBinaryEntries(data, factory)
+	if err != nil && len(data) >= 1 {
+		if skipped, skipErr := deserializeBinaryEntries(data[1:], factory); skipErr == nil {
+			return skipped, nil
+		}
+	}
+	return store, err
+}
 
-	if version != BinaryVersion {
-		return nil, errors.New("unsupported binary version")
+// deserializeBinaryEntries decodes the entry count and entries that follow
+// the version byte
+func deserializeBinaryEntries(data []byte, factory ValueFactory) (*ValueStore, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("invalid data: too small: %w", ErrTruncatedData)
 	}
 
+	offset := 0
+
 	// Read count
 	count := binary.LittleEndian.Uint32(data[offset:])
 	offset += 4
@@ -321,15 +415,15 @@ func DeserializeBinary(data []byte, factory func(name string, vtype ValueType, d
 	// Read each key-value pair
 	for i := uint32(0); i < count; i++ {
 		if offset+4 > len(data) {
-			return nil, errors.New("truncated data at entry")
+			return nil, fmt.Errorf("%w at entry %d", ErrTruncatedData, i)
 		}
 
 		// Read key length
 		keyLen := binary.LittleEndian.Uint32(data[offset:])
 		offset += 4
 
-		if offset+int(keyLen)+5 > len(data) {
-			return nil, errors.New("truncated key data")
+		if uint64(offset)+uint64(keyLen)+5 > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: key of entry %d", ErrTruncatedData, i)
 		}
 
 		// Read key
@@ -344,8 +438,8 @@ func DeserializeBinary(data []byte, factory func(name string, vtype ValueType, d
 		valueLen := binary.LittleEndian.Uint32(data[offset:])
 		offset += 4
 
-		if offset+int(valueLen) > len(data) {
-			return nil, errors.New("truncated value data")
+		if uint64(offset)+uint64(valueLen) > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: value of entry %d", ErrTruncatedData, i)
 		}
 
 		// Read value data
@@ -358,13 +452,38 @@ func DeserializeBinary(data []byte, factory func(name string, vtype ValueType, d
 			if err != nil {
 				return nil, err
 			}
-			store.values[key] = value
+			store.set(key, value)
 		}
 	}
 
 	return store, nil
 }
 
+// LoadValueStore deserializes a ValueStore produced by SerializeBinary,
+// rebuilding typed values through the shared value factory.
+func LoadValueStore(data []byte, opts ...BinaryOption) (*ValueStore, error) {
+	return DeserializeBinary(data, NewValueFromData, opts...)
+}
+
+// DeserializeBinaryData replaces the contents of the store with the entries
+// decoded from data, using the shared value factory.
+// On error the store is left unchanged. Statistics are not reset.
+func (vs *ValueStore) DeserializeBinaryData(data []byte, opts ...BinaryOption) error {
+	decoded, err := LoadValueStore(data, opts...)
+	if err != nil {
+		return err
+	}
+
+	if vs.threadSafeEnabled.Load() {
+		vs.mutex.Lock()
+		defer vs.mutex.Unlock()
+	}
+
+	vs.values = decoded.values
+	vs.order = decoded.order
+	return nil
+}
+
 // ToJSON converts to JSON format (alias for Serialize)
 func (vs *ValueStore) ToJSON() (string, error) {
 	return vs.Serialize()
@@ -420,7 +539,7 @@ func (vs *ValueStore) ResetStatistics() {
 // Iteration Support
 // =========================================================================
 
-// Range calls fn for each key-value pair.
+// Range calls fn for each key-value pair in insertion order.
 // If fn returns false, the iteration stops.
 // Thread-safe if EnableThreadSafety was called.
 func (vs *ValueStore) Range(fn func(key string, value Value) bool) {
@@ -429,8 +548,8 @@ func (vs *ValueStore) Range(fn func(key string, value Value) bool) {
 		defer vs.mutex.RUnlock()
 	}
 
-	for key, value := range vs.values {
-		if !fn(key, value) {
+	for _, key := range vs.order {
+		if !fn(key, vs.values[key]) {
 			break
 		}
 	}
@@ -0,0 +1,160 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// AddPath adds a value under a dotted path such as "db.primary.host". The
+// first segment is the store key; each further segment names a child of a
+// ContainerValue, and missing containers along the way are created. The
+// value is stored under the last segment, renamed if its own name differs,
+// and replaces any existing child (or key) with that name.
+//
+// If an existing value along the path is not a container, nothing is changed
+// and a *PathError wrapping ErrPathNotContainer is returned. Creating
+// containers requires the shared value factory (import the values package).
+// Thread-safe if EnableThreadSafety was called.
+func (vs *ValueStore) AddPath(path string, value Value) error {
+	segments, err := splitStorePath(path)
+	if err != nil {
+		return err
+	}
+	leaf, err := renameValue(value, segments[len(segments)-1])
+	if err != nil {
+		return fmt.Errorf("path %q: %w", path, err)
+	}
+
+	if vs.threadSafeEnabled.Load() {
+		vs.mutex.Lock()
+		defer vs.mutex.Unlock()
+	}
+
+	if len(segments) == 1 {
+		vs.set(segments[0], leaf)
+		vs.writeCount.Add(1)
+		return nil
+	}
+
+	// Walk the existing containers first, so a conflict leaves the store
+	// unchanged
+	parent, exists := vs.values[segments[0]]
+	depth := 1
+	if exists {
+		if parent.Type() != ContainerValue {
+			return &PathError{Path: path, Segment: segments[0], Err: ErrPathNotContainer}
+		}
+		for ; depth < len(segments)-1; depth++ {
+			child := findChild(parent, segments[depth])
+			if child == nil {
+				break
+			}
+			if child.Type() != ContainerValue {
+				return &PathError{Path: path, Segment: segments[depth], Err: ErrPathNotContainer}
+			}
+			parent = child
+		}
+	} else {
+		depth = 0
+	}
+
+	// Build the missing part of the path bottom-up and attach it
+	node := leaf
+	for i := len(segments) - 2; i >= depth; i-- {
+		container, err := NewValueFromData(segments[i], ContainerValue, binary.LittleEndian.AppendUint32(nil, 0))
+		if err != nil {
+			return fmt.Errorf("path %q: create %q: %w", path, segments[i], err)
+		}
+		if err := container.AddChild(node); err != nil {
+			return fmt.Errorf("path %q: %w", path, err)
+		}
+		node = container
+	}
+
+	if depth == 0 {
+		vs.set(segments[0], node)
+	} else {
+		if findChild(parent, node.Name()) != nil {
+			if err := parent.RemoveChild(node.Name()); err != nil {
+				return fmt.Errorf("path %q: %w", path, err)
+			}
+		}
+		if err := parent.AddChild(node); err != nil {
+			return fmt.Errorf("path %q: %w", path, err)
+		}
+	}
+	vs.writeCount.Add(1)
+	return nil
+}
+
+// GetPath retrieves a value by dotted path, as written by AddPath.
+// Returns nil if any segment does not exist or is not a container.
+// Thread-safe if EnableThreadSafety was called.
+func (vs *ValueStore) GetPath(path string) Value {
+	segments, err := splitStorePath(path)
+	if err != nil {
+		return nil
+	}
+
+	if vs.threadSafeEnabled.Load() {
+		vs.mutex.RLock()
+		defer vs.mutex.RUnlock()
+	}
+
+	current, exists := vs.values[segments[0]]
+	if !exists {
+		return nil
+	}
+	for _, segment := range segments[1:] {
+		if current.Type() != ContainerValue {
+			return nil
+		}
+		if current = findChild(current, segment); current == nil {
+			return nil
+		}
+	}
+	vs.readCount.Add(1)
+	return current
+}
+
+// splitStorePath splits a dotted store path, rejecting empty segments
+func splitStorePath(path string) ([]string, error) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, &PathError{Path: path, Segment: segment, Err: ErrInvalidPath}
+		}
+	}
+	return segments, nil
+}
+
+// findChild returns the first child of v with the given name, or nil
+func findChild(v Value, name string) Value {
+	for _, child := range v.Children() {
+		if child.Name() == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// renameValue returns v if it already has the given name, and otherwise a
+// copy of v with that name, rebuilt through the shared factory
+func renameValue(v Value, name string) (Value, error) {
+	if v.Name() == name {
+		return v, nil
+	}
+	payload, err := RawPayload(v)
+	if err != nil {
+		return nil, err
+	}
+	return NewValueFromData(name, v.Type(), payload)
+}
@@ -0,0 +1,165 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scalarText renders the payload of a scalar value as text, as written by
+// BaseValue.ToXML: integers in decimal, floats in shortest form ("NaN",
+// "+Inf" and "-Inf" for non-finite values), bools as "true"/"false" and
+// bytes as standard base64, in which case encoding is "base64". ok is false
+// for types without a text form here, or for a payload of the wrong size.
+func scalarText(vtype ValueType, data []byte) (text, encoding string, ok bool) {
+	le := binary.LittleEndian
+	switch vtype {
+	case BoolValue:
+		if len(data) == 1 {
+			return strconv.FormatBool(data[0] != 0), "", true
+		}
+	case ShortValue:
+		if len(data) == 2 {
+			return strconv.FormatInt(int64(int16(le.Uint16(data))), 10), "", true
+		}
+	case UShortValue:
+		if len(data) == 2 {
+			return strconv.FormatUint(uint64(le.Uint16(data)), 10), "", true
+		}
+	case IntValue, LongValue:
+		if len(data) == 4 {
+			return strconv.FormatInt(int64(int32(le.Uint32(data))), 10), "", true
+		}
+	case UIntValue, ULongValue:
+		if len(data) == 4 {
+			return strconv.FormatUint(uint64(le.Uint32(data)), 10), "", true
+		}
+	case LLongValue:
+		if len(data) == 8 {
+			return strconv.FormatInt(int64(le.Uint64(data)), 10), "", true
+		}
+	case ULLongValue:
+		if len(data) == 8 {
+			return strconv.FormatUint(le.Uint64(data), 10), "", true
+		}
+	case FloatValue:
+		if len(data) == 4 {
+			return strconv.FormatFloat(float64(math.Float32frombits(le.Uint32(data))), 'g', -1, 32), "", true
+		}
+	case DoubleValue:
+		if len(data) == 8 {
+			return strconv.FormatFloat(math.Float64frombits(le.Uint64(data)), 'g', -1, 64), "", true
+		}
+	case StringValue:
+		return string(data), "", true
+	case BytesValue:
+		return base64.StdEncoding.EncodeToString(data), "base64", true
+	}
+	return "", "", false
+}
+
+// textPayload parses the text form of a non-nested value into the payload
+// accepted by NewValueFromData. It accepts what scalarText writes, plus the
+// text forms of the remaining types: RFC 3339 timestamps, dashed UUIDs and
+// plain decimals. Bytes are taken verbatim unless encoding is "base64".
+func textPayload(vtype ValueType, text, encoding string) ([]byte, error) {
+	le := binary.LittleEndian
+	switch vtype {
+	case NullValue:
+		return nil, nil
+
+	case BoolValue:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+
+	case ShortValue, IntValue, LongValue, LLongValue:
+		bits := integerBits(vtype)
+		n, err := strconv.ParseInt(strings.TrimSpace(text), 10, bits)
+		if err != nil {
+			return nil, err
+		}
+		return le.AppendUint64(nil, uint64(n))[:bits/8], nil
+
+	case UShortValue, UIntValue, ULongValue, ULLongValue:
+		bits := integerBits(vtype)
+		n, err := strconv.ParseUint(strings.TrimSpace(text), 10, bits)
+		if err != nil {
+			return nil, err
+		}
+		return le.AppendUint64(nil, n)[:bits/8], nil
+
+	case FloatValue:
+		// ParseFloat also accepts "NaN", "+Inf" and "-Inf"
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 32)
+		if err != nil {
+			return nil, err
+		}
+		return le.AppendUint32(nil, math.Float32bits(float32(f))), nil
+
+	case DoubleValue:
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, err
+		}
+		return le.AppendUint64(nil, math.Float64bits(f)), nil
+
+	case StringValue:
+		return []byte(text), nil
+
+	case BytesValue:
+		if encoding != "base64" {
+			return []byte(text), nil
+		}
+		return base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+
+	case DateTimeValue:
+		t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(text))
+		if err != nil {
+			return nil, err
+		}
+		return le.AppendUint64(nil, uint64(t.UnixNano())), nil
+
+	case UUIDValue:
+		return hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(text), "-", ""))
+
+	case DecimalValue:
+		unscaled, scale, err := ParseDecimal(strings.TrimSpace(text))
+		if err != nil {
+			return nil, err
+		}
+		return EncodeDecimal(unscaled, scale), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s has no text form", ErrUnknownValueType, vtype.TypeName())
+	}
+}
+
+// integerBits returns the payload width in bits of an integer type
+func integerBits(vtype ValueType) int {
+	switch vtype {
+	case ShortValue, UShortValue:
+		return 16
+	case IntValue, UIntValue, LongValue, ULongValue:
+		return 32
+	default:
+		return 64
+	}
+}
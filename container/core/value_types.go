@@ -55,6 +55,10 @@ const (
 	BytesValue     ValueType = 13 // bytes_value - matches C++ std::vector<uint8_t> position
 	ContainerValue ValueType = 14 // container_value (nested container)
 	ArrayValue     ValueType = 15 // array_value (heterogeneous array)
+	DateTimeValue  ValueType = 16 // datetime_value (nanoseconds since Unix epoch)
+	UUIDValue      ValueType = 17 // uuid_value (16 bytes, RFC 4122 order)
+	DecimalValue   ValueType = 18 // decimal_value (scale + big-endian unscaled integer)
+	MapValue       ValueType = 19 // map_value (values keyed by unique strings)
 )
 
 // String returns the string representation of the value type (numeric ID).
@@ -93,6 +97,14 @@ func (vt ValueType) String() string {
 		return "14"
 	case ArrayValue:
 		return "15"
+	case DateTimeValue:
+		return "16"
+	case UUIDValue:
+		return "17"
+	case DecimalValue:
+		return "18"
+	case MapValue:
+		return "19"
 	default:
 		return "0"
 	}
@@ -134,6 +146,14 @@ func ParseValueType(s string) ValueType {
 		return ContainerValue
 	case "15":
 		return ArrayValue
+	case "16":
+		return DateTimeValue
+	case "17":
+		return UUIDValue
+	case "18":
+		return DecimalValue
+	case "19":
+		return MapValue
 	default:
 		return NullValue
 	}
@@ -174,7 +194,73 @@ func (vt ValueType) TypeName() string {
 		return "container"
 	case ArrayValue:
 		return "array"
+	case DateTimeValue:
+		return "datetime"
+	case UUIDValue:
+		return "uuid"
+	case DecimalValue:
+		return "decimal"
+	case MapValue:
+		return "map"
 	default:
 		return "unknown"
 	}
 }
+
+// ValueTypeFromName returns the value type whose TypeName is name, and false
+// if no defined type has that name
+func ValueTypeFromName(name string) (ValueType, bool) {
+	for vt := NullValue; vt.IsDefined(); vt++ {
+		if vt.TypeName() == name {
+			return vt, true
+		}
+	}
+	return NullValue, false
+}
+
+// IsDefined reports whether the type code is one of the defined value types
+func (vt ValueType) IsDefined() bool {
+	return vt >= NullValue && vt <= MapValue
+}
+
+// IsNumeric reports whether the type is an integer or floating-point type
+func (vt ValueType) IsNumeric() bool {
+	return vt.IsInteger() || vt.IsFloat()
+}
+
+// IsInteger reports whether the type is a signed or unsigned integer type
+func (vt ValueType) IsInteger() bool {
+	return vt >= ShortValue && vt <= ULLongValue
+}
+
+// IsFloat reports whether the type is a floating-point type
+func (vt ValueType) IsFloat() bool {
+	return vt == FloatValue || vt == DoubleValue
+}
+
+// IsSigned reports whether the type can represent negative numbers.
+// Floating-point types are signed; non-numeric types are not.
+func (vt ValueType) IsSigned() bool {
+	switch vt {
+	case ShortValue, IntValue, LongValue, LLongValue, FloatValue, DoubleValue:
+		return true
+	default:
+		return false
+	}
+}
+
+// BitWidth returns the size in bits of a numeric type's binary payload,
+// or 0 for non-numeric types.
+// LongValue and ULongValue are 32-bit, matching their 4-byte wire encoding.
+func (vt ValueType) BitWidth() int {
+	switch vt {
+	case ShortValue, UShortValue:
+		return 16
+	case IntValue, UIntValue, LongValue, ULongValue, FloatValue:
+		return 32
+	case LLongValue, ULLongValue, DoubleValue:
+		return 64
+	default:
+		return 0
+	}
+}
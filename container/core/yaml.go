@@ -0,0 +1,131 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToYAML converts to a YAML document with the same shape as ToJSON: the
+// header fields followed by a "values" list of {name, type, data} entries.
+// Bytes are base64 with "encoding: base64", as in JSON, and integers keep
+// their full 64-bit precision. NaN and infinite floats are rejected with
+// ErrNonFiniteFloat, as by ToJSONCompact.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) ToYAML() ([]byte, error) {
+	doc, err := c.ToJSONCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so the document parses into a node tree that keeps
+	// key order and number literals; it is then re-emitted in block style
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(doc), &node); err != nil {
+		return nil, fmt.Errorf("yaml conversion failed: %w", err)
+	}
+	clearYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FromYAML replaces the container's header and values with those of a YAML
+// document produced by ToYAML, rebuilding typed values as FromJSON does.
+// Anchors and aliases are rejected: ToYAML never emits them, and expanding
+// them would let a small document inflate into millions of nodes.
+// Thread-safe if EnableThreadSafe was called.
+func (c *ValueContainer) FromYAML(data []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("invalid container YAML: %w", err)
+	}
+	if len(node.Content) == 0 {
+		return fmt.Errorf("invalid container YAML: empty document")
+	}
+
+	doc, err := yamlToJSONValue(node.Content[0])
+	if err != nil {
+		return fmt.Errorf("invalid container YAML: %w", err)
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("invalid container YAML: %w", err)
+	}
+	return c.FromJSON(string(out))
+}
+
+// clearYAMLStyle resets the flow and quoting styles taken from the JSON
+// source so the encoder picks block style and quotes only where needed
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// yamlToJSONValue converts a YAML node to the equivalent encoding/json
+// value. Integers are decoded as int or uint64, so they survive json.Marshal
+// without a float64 round trip.
+func yamlToJSONValue(n *yaml.Node) (interface{}, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return yamlToJSONValue(n.Content[0])
+	case yaml.AliasNode:
+		return nil, fmt.Errorf("unsupported YAML alias at line %d", n.Line)
+	case yaml.MappingNode:
+		obj := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			value, err := yamlToJSONValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			obj[n.Content[i].Value] = value
+		}
+		return obj, nil
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(n.Content))
+		for _, child := range n.Content {
+			value, err := yamlToJSONValue(child)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!null", "!!bool", "!!int", "!!float":
+			var value interface{}
+			if err := n.Decode(&value); err != nil {
+				return nil, err
+			}
+			return value, nil
+		default:
+			// Strings, and timestamps or binary tags, keep their text
+			return n.Value, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported YAML node at line %d", n.Line)
+	}
+}
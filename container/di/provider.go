@@ -35,10 +35,19 @@ All rights reserved.
 // Example usage with Uber Dig:
 //
 //	container := dig.New()
-//	container.Provide(di.NewContainerFactory)
+//	if err := di.RegisterDig(container); err != nil {
+//	    return err
+//	}
+//	err := container.Invoke(func(factory di.ContainerFactory) {
+//	    // ...
+//	})
 package di
 
 import (
+	"fmt"
+
+	"go.uber.org/dig"
+
 	"github.com/kcenon/go_container_system/container/core"
 	"github.com/kcenon/go_container_system/container/messaging"
 )
@@ -61,6 +70,34 @@ type ContainerFactory interface {
 
 	// NewBuilder creates a new ContainerBuilder for fluent container construction.
 	NewBuilder() *messaging.ContainerBuilder
+
+	// NewFromPrototype creates a ValueContainer from a default-configured
+	// prototype, copying its values if withValues is true.
+	NewFromPrototype(proto *core.ValueContainer, withValues bool) *core.ValueContainer
+}
+
+// Providers returns the constructors of this package for registration with a
+// reflection-based DI container: NewContainerFactory, which provides
+// ContainerFactory, and messaging.NewContainerBuilder, which provides
+// *messaging.ContainerBuilder. Each provider takes no arguments and returns a
+// single value.
+func Providers() []interface{} {
+	return []interface{}{
+		NewContainerFactory,
+		messaging.NewContainerBuilder,
+	}
+}
+
+// RegisterDig provides every constructor of Providers to an Uber Dig
+// container, so functions invoked on it can depend on ContainerFactory and
+// *messaging.ContainerBuilder.
+func RegisterDig(c *dig.Container) error {
+	for _, provider := range Providers() {
+		if err := c.Provide(provider); err != nil {
+			return fmt.Errorf("dig provide %T: %w", provider, err)
+		}
+	}
+	return nil
 }
 
 // DefaultContainerFactory is the default implementation of ContainerFactory.
@@ -97,3 +134,10 @@ func (f *DefaultContainerFactory) NewContainerFull(sourceID, sourceSubID, target
 func (f *DefaultContainerFactory) NewBuilder() *messaging.ContainerBuilder {
 	return messaging.NewContainerBuilder()
 }
+
+// NewFromPrototype creates a ValueContainer from a default-configured prototype.
+// The header is always copied; values are deep-copied only if withValues is true,
+// so per-request instances never share mutable values with the prototype.
+func (f *DefaultContainerFactory) NewFromPrototype(proto *core.ValueContainer, withValues bool) *core.ValueContainer {
+	return proto.Copy(withValues)
+}
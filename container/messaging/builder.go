@@ -9,7 +9,11 @@ All rights reserved.
 package messaging
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
 )
 
 // ContainerBuilder provides a fluent API for constructing ValueContainer instances.
@@ -29,8 +33,12 @@ type ContainerBuilder struct {
 	targetID    string
 	targetSubID string
 	messageType string
+	version     string
 	values      []core.Value
 	threadSafe  bool
+
+	requireType   bool
+	requireSource bool
 }
 
 // NewContainerBuilder creates a new ContainerBuilder instance.
@@ -63,6 +71,14 @@ func (b *ContainerBuilder) WithType(msgType string) *ContainerBuilder {
 	return b
 }
 
+// WithVersion sets the header version of the container. Without it the
+// container gets core.DefaultVersion.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) WithVersion(version string) *ContainerBuilder {
+	b.version = version
+	return b
+}
+
 // WithValues adds values to the container.
 // Returns the builder for method chaining.
 func (b *ContainerBuilder) WithValues(values ...core.Value) *ContainerBuilder {
@@ -70,6 +86,42 @@ func (b *ContainerBuilder) WithValues(values ...core.Value) *ContainerBuilder {
 	return b
 }
 
+// WithString adds a string value to the container.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) WithString(name, val string) *ContainerBuilder {
+	return b.WithValues(values.NewStringValue(name, val))
+}
+
+// WithBool adds a bool value to the container.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) WithBool(name string, val bool) *ContainerBuilder {
+	return b.WithValues(values.NewBoolValue(name, val))
+}
+
+// WithInt32 adds an int32 value to the container.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) WithInt32(name string, val int32) *ContainerBuilder {
+	return b.WithValues(values.NewInt32Value(name, val))
+}
+
+// WithInt64 adds an int64 value to the container.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) WithInt64(name string, val int64) *ContainerBuilder {
+	return b.WithValues(values.NewInt64Value(name, val))
+}
+
+// WithFloat64 adds a float64 value to the container.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) WithFloat64(name string, val float64) *ContainerBuilder {
+	return b.WithValues(values.NewFloat64Value(name, val))
+}
+
+// WithBytes adds a bytes value to the container.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) WithBytes(name string, val []byte) *ContainerBuilder {
+	return b.WithValues(values.NewBytesValue(name, val))
+}
+
 // WithThreadSafe enables thread-safe mode for the container.
 // Returns the builder for method chaining.
 func (b *ContainerBuilder) WithThreadSafe(enabled bool) *ContainerBuilder {
@@ -77,9 +129,39 @@ func (b *ContainerBuilder) WithThreadSafe(enabled bool) *ContainerBuilder {
 	return b
 }
 
+// RequireType makes Build fail unless a non-empty message type was set
+// with WithType.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) RequireType() *ContainerBuilder {
+	b.requireType = true
+	return b
+}
+
+// RequireSource makes Build fail unless a non-empty source ID was set with
+// WithSource. The source sub ID may be empty.
+// Returns the builder for method chaining.
+func (b *ContainerBuilder) RequireSource() *ContainerBuilder {
+	b.requireSource = true
+	return b
+}
+
 // Build creates a new ValueContainer with the configured properties.
 // Returns the constructed container and any error encountered.
+// If a field marked with RequireType or RequireSource was not set, it
+// returns a nil container and an error matching core.ErrMissingField that
+// names every missing field.
 func (b *ContainerBuilder) Build() (*core.ValueContainer, error) {
+	var errs []error
+	if b.requireType && b.messageType == "" {
+		errs = append(errs, fmt.Errorf("%w: message type (set it with WithType)", core.ErrMissingField))
+	}
+	if b.requireSource && b.sourceID == "" {
+		errs = append(errs, fmt.Errorf("%w: source ID (set it with WithSource)", core.ErrMissingField))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("build container: %w", errors.Join(errs...))
+	}
+
 	container := core.NewValueContainerFull(
 		b.sourceID,
 		b.sourceSubID,
@@ -88,6 +170,9 @@ func (b *ContainerBuilder) Build() (*core.ValueContainer, error) {
 		b.messageType,
 		b.values...,
 	)
+	if b.version != "" {
+		container.SetVersion(b.version)
+	}
 
 	if b.threadSafe {
 		container.EnableThreadSafe()
@@ -95,3 +180,13 @@ func (b *ContainerBuilder) Build() (*core.ValueContainer, error) {
 
 	return container, nil
 }
+
+// MustBuild is like Build but panics if Build returns an error.
+// It is intended for tests and static initialization.
+func (b *ContainerBuilder) MustBuild() *core.ValueContainer {
+	container, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return container
+}
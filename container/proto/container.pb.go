@@ -0,0 +1,824 @@
+// BSD 3-Clause License
+//
+// Copyright (c) 2021, 🍀☀🌕🌥 🌊
+// All rights reserved.
+
+// Protobuf schema for ValueContainer.ToProto / FromProto.
+//
+// container.pb.go holds the Go types generated from this file by
+// protoc-gen-go (see generate.go), and container/core builds its codec on
+// them. Other languages can generate bindings from this file with protoc.
+// tests/testdata/*.binpb are protoc --encode outputs of the .txtpb sources
+// next to them and pin the Go codec to this schema.
+//
+// Oneof field numbers are the value type ID + 2 (see core.ValueType).
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.0
+// 	protoc        (unknown)
+// source: container.proto
+
+package containerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Container struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceId      string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	SourceSubId   string                 `protobuf:"bytes,2,opt,name=source_sub_id,json=sourceSubId,proto3" json:"source_sub_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	TargetSubId   string                 `protobuf:"bytes,4,opt,name=target_sub_id,json=targetSubId,proto3" json:"target_sub_id,omitempty"`
+	MessageType   string                 `protobuf:"bytes,5,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	Version       string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	Values        []*Value               `protobuf:"bytes,7,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Container) Reset() {
+	*x = Container{}
+	mi := &file_container_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Container) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container) ProtoMessage() {}
+
+func (x *Container) ProtoReflect() protoreflect.Message {
+	mi := &file_container_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container.ProtoReflect.Descriptor instead.
+func (*Container) Descriptor() ([]byte, []int) {
+	return file_container_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Container) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *Container) GetSourceSubId() string {
+	if x != nil {
+		return x.SourceSubId
+	}
+	return ""
+}
+
+func (x *Container) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Container) GetTargetSubId() string {
+	if x != nil {
+		return x.TargetSubId
+	}
+	return ""
+}
+
+func (x *Container) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *Container) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Container) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Value struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Value_NullValue
+	//	*Value_BoolValue
+	//	*Value_ShortValue
+	//	*Value_UshortValue
+	//	*Value_IntValue
+	//	*Value_UintValue
+	//	*Value_LongValue
+	//	*Value_UlongValue
+	//	*Value_LlongValue
+	//	*Value_UllongValue
+	//	*Value_FloatValue
+	//	*Value_DoubleValue
+	//	*Value_StringValue
+	//	*Value_BytesValue
+	//	*Value_ContainerValue
+	//	*Value_ArrayValue
+	//	*Value_DatetimeValue
+	//	*Value_UuidValue
+	//	*Value_DecimalValue
+	//	*Value_MapValue
+	Payload       isValue_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_container_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_container_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_container_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Value) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Value) GetPayload() isValue_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Value) GetNullValue() bool {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetShortValue() int32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_ShortValue); ok {
+			return x.ShortValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUshortValue() uint32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_UshortValue); ok {
+			return x.UshortValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetIntValue() int32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUintValue() uint32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_UintValue); ok {
+			return x.UintValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetLongValue() int32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_LongValue); ok {
+			return x.LongValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUlongValue() uint32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_UlongValue); ok {
+			return x.UlongValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetLlongValue() int64 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_LlongValue); ok {
+			return x.LlongValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUllongValue() uint64 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_UllongValue); ok {
+			return x.UllongValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetFloatValue() float32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetContainerValue() *ValueList {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_ContainerValue); ok {
+			return x.ContainerValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetArrayValue() *ValueList {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_ArrayValue); ok {
+			return x.ArrayValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetDatetimeValue() int64 {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_DatetimeValue); ok {
+			return x.DatetimeValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUuidValue() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_UuidValue); ok {
+			return x.UuidValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetDecimalValue() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_DecimalValue); ok {
+			return x.DecimalValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetMapValue() *MapEntryList {
+	if x != nil {
+		if x, ok := x.Payload.(*Value_MapValue); ok {
+			return x.MapValue
+		}
+	}
+	return nil
+}
+
+type isValue_Payload interface {
+	isValue_Payload()
+}
+
+type Value_NullValue struct {
+	NullValue bool `protobuf:"varint,2,opt,name=null_value,json=nullValue,proto3,oneof"` // always true
+}
+
+type Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,3,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Value_ShortValue struct {
+	ShortValue int32 `protobuf:"varint,4,opt,name=short_value,json=shortValue,proto3,oneof"` // int16 range
+}
+
+type Value_UshortValue struct {
+	UshortValue uint32 `protobuf:"varint,5,opt,name=ushort_value,json=ushortValue,proto3,oneof"` // uint16 range
+}
+
+type Value_IntValue struct {
+	IntValue int32 `protobuf:"varint,6,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type Value_UintValue struct {
+	UintValue uint32 `protobuf:"varint,7,opt,name=uint_value,json=uintValue,proto3,oneof"`
+}
+
+type Value_LongValue struct {
+	LongValue int32 `protobuf:"varint,8,opt,name=long_value,json=longValue,proto3,oneof"` // 32-bit, matching LongValue
+}
+
+type Value_UlongValue struct {
+	UlongValue uint32 `protobuf:"varint,9,opt,name=ulong_value,json=ulongValue,proto3,oneof"` // 32-bit, matching ULongValue
+}
+
+type Value_LlongValue struct {
+	LlongValue int64 `protobuf:"varint,10,opt,name=llong_value,json=llongValue,proto3,oneof"`
+}
+
+type Value_UllongValue struct {
+	UllongValue uint64 `protobuf:"varint,11,opt,name=ullong_value,json=ullongValue,proto3,oneof"`
+}
+
+type Value_FloatValue struct {
+	FloatValue float32 `protobuf:"fixed32,12,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type Value_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,13,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,14,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Value_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,15,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type Value_ContainerValue struct {
+	ContainerValue *ValueList `protobuf:"bytes,16,opt,name=container_value,json=containerValue,proto3,oneof"`
+}
+
+type Value_ArrayValue struct {
+	ArrayValue *ValueList `protobuf:"bytes,17,opt,name=array_value,json=arrayValue,proto3,oneof"`
+}
+
+type Value_DatetimeValue struct {
+	DatetimeValue int64 `protobuf:"fixed64,18,opt,name=datetime_value,json=datetimeValue,proto3,oneof"` // nanoseconds since the Unix epoch
+}
+
+type Value_UuidValue struct {
+	UuidValue []byte `protobuf:"bytes,19,opt,name=uuid_value,json=uuidValue,proto3,oneof"` // 16 bytes, RFC 4122 order
+}
+
+type Value_DecimalValue struct {
+	DecimalValue []byte `protobuf:"bytes,20,opt,name=decimal_value,json=decimalValue,proto3,oneof"` // [scale:4 LE][len:4 LE][big-endian two's-complement unscaled]
+}
+
+type Value_MapValue struct {
+	MapValue *MapEntryList `protobuf:"bytes,21,opt,name=map_value,json=mapValue,proto3,oneof"` // entries in key order, keys unique
+}
+
+func (*Value_NullValue) isValue_Payload() {}
+
+func (*Value_BoolValue) isValue_Payload() {}
+
+func (*Value_ShortValue) isValue_Payload() {}
+
+func (*Value_UshortValue) isValue_Payload() {}
+
+func (*Value_IntValue) isValue_Payload() {}
+
+func (*Value_UintValue) isValue_Payload() {}
+
+func (*Value_LongValue) isValue_Payload() {}
+
+func (*Value_UlongValue) isValue_Payload() {}
+
+func (*Value_LlongValue) isValue_Payload() {}
+
+func (*Value_UllongValue) isValue_Payload() {}
+
+func (*Value_FloatValue) isValue_Payload() {}
+
+func (*Value_DoubleValue) isValue_Payload() {}
+
+func (*Value_StringValue) isValue_Payload() {}
+
+func (*Value_BytesValue) isValue_Payload() {}
+
+func (*Value_ContainerValue) isValue_Payload() {}
+
+func (*Value_ArrayValue) isValue_Payload() {}
+
+func (*Value_DatetimeValue) isValue_Payload() {}
+
+func (*Value_UuidValue) isValue_Payload() {}
+
+func (*Value_DecimalValue) isValue_Payload() {}
+
+func (*Value_MapValue) isValue_Payload() {}
+
+type ValueList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*Value               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValueList) Reset() {
+	*x = ValueList{}
+	mi := &file_container_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValueList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValueList) ProtoMessage() {}
+
+func (x *ValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_container_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValueList.ProtoReflect.Descriptor instead.
+func (*ValueList) Descriptor() ([]byte, []int) {
+	return file_container_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValueList) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// A map<string, Value> field cannot be a oneof member, so map values use an
+// explicit entry list
+type MapEntryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*MapEntry            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapEntryList) Reset() {
+	*x = MapEntryList{}
+	mi := &file_container_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapEntryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapEntryList) ProtoMessage() {}
+
+func (x *MapEntryList) ProtoReflect() protoreflect.Message {
+	mi := &file_container_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapEntryList.ProtoReflect.Descriptor instead.
+func (*MapEntryList) Descriptor() ([]byte, []int) {
+	return file_container_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MapEntryList) GetEntries() []*MapEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type MapEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         *Value                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapEntry) Reset() {
+	*x = MapEntry{}
+	mi := &file_container_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapEntry) ProtoMessage() {}
+
+func (x *MapEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_container_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapEntry.ProtoReflect.Descriptor instead.
+func (*MapEntry) Descriptor() ([]byte, []int) {
+	return file_container_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MapEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MapEntry) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var File_container_proto protoreflect.FileDescriptor
+
+var file_container_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x22, 0xfb, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x22,
+	0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x75, 0x62,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12,
+	0x22, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x75,
+	0x62, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x22, 0xc4, 0x06, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1f, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1f, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x21, 0x0a, 0x0b, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x75, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x73,
+	0x68, 0x6f, 0x72, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d, 0x0a, 0x09, 0x69, 0x6e, 0x74,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x75, 0x69, 0x6e, 0x74,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x09,
+	0x75, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x6c, 0x6f, 0x6e,
+	0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52,
+	0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x75, 0x6c,
+	0x6f, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x00, 0x52, 0x0a, 0x75, 0x6c, 0x6f, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a,
+	0x0b, 0x6c, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x00, 0x52, 0x0a, 0x6c, 0x6c, 0x6f, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x23, 0x0a, 0x0c, 0x75, 0x6c, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x6c, 0x6c, 0x6f, 0x6e, 0x67,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x0a, 0x66, 0x6c,
+	0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x64, 0x6f, 0x75, 0x62,
+	0x6c, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00,
+	0x52, 0x0b, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a,
+	0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0a, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x46, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3e, 0x0a,
+	0x0b, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0a, 0x61, 0x72, 0x72, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x27, 0x0a,
+	0x0e, 0x64, 0x61, 0x74, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x10, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x61, 0x74, 0x65, 0x74, 0x69, 0x6d,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x75, 0x75, 0x69, 0x64, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x75, 0x75,
+	0x69, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0d, 0x64, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x0c, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3d,
+	0x0a, 0x09, 0x6d, 0x61, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4c, 0x69, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x61, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3c, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x0c, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4d, 0x61, 0x70, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x08,
+	0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x63, 0x65, 0x6e, 0x6f, 0x6e, 0x2f, 0x67,
+	0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x3b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_container_proto_rawDescOnce sync.Once
+	file_container_proto_rawDescData = file_container_proto_rawDesc
+)
+
+func file_container_proto_rawDescGZIP() []byte {
+	file_container_proto_rawDescOnce.Do(func() {
+		file_container_proto_rawDescData = protoimpl.X.CompressGZIP(file_container_proto_rawDescData)
+	})
+	return file_container_proto_rawDescData
+}
+
+var file_container_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_container_proto_goTypes = []any{
+	(*Container)(nil),    // 0: container_system.Container
+	(*Value)(nil),        // 1: container_system.Value
+	(*ValueList)(nil),    // 2: container_system.ValueList
+	(*MapEntryList)(nil), // 3: container_system.MapEntryList
+	(*MapEntry)(nil),     // 4: container_system.MapEntry
+}
+var file_container_proto_depIdxs = []int32{
+	1, // 0: container_system.Container.values:type_name -> container_system.Value
+	2, // 1: container_system.Value.container_value:type_name -> container_system.ValueList
+	2, // 2: container_system.Value.array_value:type_name -> container_system.ValueList
+	3, // 3: container_system.Value.map_value:type_name -> container_system.MapEntryList
+	1, // 4: container_system.ValueList.values:type_name -> container_system.Value
+	4, // 5: container_system.MapEntryList.entries:type_name -> container_system.MapEntry
+	1, // 6: container_system.MapEntry.value:type_name -> container_system.Value
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_container_proto_init() }
+func file_container_proto_init() {
+	if File_container_proto != nil {
+		return
+	}
+	file_container_proto_msgTypes[1].OneofWrappers = []any{
+		(*Value_NullValue)(nil),
+		(*Value_BoolValue)(nil),
+		(*Value_ShortValue)(nil),
+		(*Value_UshortValue)(nil),
+		(*Value_IntValue)(nil),
+		(*Value_UintValue)(nil),
+		(*Value_LongValue)(nil),
+		(*Value_UlongValue)(nil),
+		(*Value_LlongValue)(nil),
+		(*Value_UllongValue)(nil),
+		(*Value_FloatValue)(nil),
+		(*Value_DoubleValue)(nil),
+		(*Value_StringValue)(nil),
+		(*Value_BytesValue)(nil),
+		(*Value_ContainerValue)(nil),
+		(*Value_ArrayValue)(nil),
+		(*Value_DatetimeValue)(nil),
+		(*Value_UuidValue)(nil),
+		(*Value_DecimalValue)(nil),
+		(*Value_MapValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_container_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_container_proto_goTypes,
+		DependencyIndexes: file_container_proto_depIdxs,
+		MessageInfos:      file_container_proto_msgTypes,
+	}.Build()
+	File_container_proto = out.File
+	file_container_proto_rawDesc = nil
+	file_container_proto_goTypes = nil
+	file_container_proto_depIdxs = nil
+}
@@ -0,0 +1,14 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+// Package containerpb holds the protoc-generated Go types for
+// container.proto, the schema of ValueContainer.ToProto and FromProto.
+package containerpb
+
+// Regenerate container.pb.go after editing container.proto; requires protoc
+// and protoc-gen-go v1.36.0 on PATH.
+//go:generate protoc --go_out=. --go_opt=paths=source_relative container.proto
@@ -8,10 +8,14 @@ All rights reserved.
 package values
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"math"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kcenon/go_container_system/container/core"
 )
@@ -25,9 +29,15 @@ import (
 //
 // Text format:
 // [name,15,count];[element1][element2]...
+//
+// ArrayValue is not safe for concurrent use unless EnableThreadSafe is called.
 type ArrayValue struct {
 	*core.BaseValue
 	elements []core.Value
+
+	// Thread safety
+	mu         sync.RWMutex
+	threadSafe bool
 }
 
 // NewArrayValue creates a new array value
@@ -42,31 +52,70 @@ func NewArrayValue(name string, elements ...core.Value) *ArrayValue {
 	return av
 }
 
-// Elements returns all elements
+// EnableThreadSafe enables thread-safe mode
+func (v *ArrayValue) EnableThreadSafe() {
+	v.threadSafe = true
+}
+
+// DisableThreadSafe disables thread-safe mode
+func (v *ArrayValue) DisableThreadSafe() {
+	v.threadSafe = false
+}
+
+// IsThreadSafe returns whether thread-safe mode is enabled
+func (v *ArrayValue) IsThreadSafe() bool {
+	return v.threadSafe
+}
+
+// snapshot returns the elements for reading. In thread-safe mode it is a
+// copy taken under the read lock, so later appends do not race with the caller.
+func (v *ArrayValue) snapshot() []core.Value {
+	if !v.threadSafe {
+		return v.elements
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return append([]core.Value(nil), v.elements...)
+}
+
+// Elements returns all elements.
+// In thread-safe mode the returned slice is a copy.
 func (v *ArrayValue) Elements() []core.Value {
-	return v.elements
+	return v.snapshot()
 }
 
 // Count returns the number of elements
 func (v *ArrayValue) Count() int {
+	if v.threadSafe {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+	}
 	return len(v.elements)
 }
 
 // IsEmpty checks if the array is empty
 func (v *ArrayValue) IsEmpty() bool {
-	return len(v.elements) == 0
+	return v.Count() == 0
 }
 
 // At gets element at index
 func (v *ArrayValue) At(index int) (core.Value, error) {
+	if v.threadSafe {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+	}
 	if index < 0 || index >= len(v.elements) {
-		return nil, fmt.Errorf("ArrayValue index %d out of range (size: %d)", index, len(v.elements))
+		return nil, v.indexError(index)
 	}
 	return v.elements[index], nil
 }
 
 // Append adds an element to the end of the array
 func (v *ArrayValue) Append(element core.Value) error {
+	if v.threadSafe {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
 	v.elements = append(v.elements, element)
 	return nil
 }
@@ -83,13 +132,81 @@ func (v *ArrayValue) PushBack(element core.Value) error {
 
 // Clear removes all elements
 func (v *ArrayValue) Clear() {
+	if v.threadSafe {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
 	v.elements = make([]core.Value, 0)
 }
 
+// Set replaces the element at index
+func (v *ArrayValue) Set(index int, element core.Value) error {
+	if v.threadSafe {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
+	if index < 0 || index >= len(v.elements) {
+		return v.indexError(index)
+	}
+	v.elements[index] = element
+	return nil
+}
+
+// InsertAt inserts an element before index, shifting later elements up.
+// An index equal to Count appends.
+func (v *ArrayValue) InsertAt(index int, element core.Value) error {
+	if v.threadSafe {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
+	if index < 0 || index > len(v.elements) {
+		return v.indexError(index)
+	}
+	v.elements = append(v.elements, nil)
+	copy(v.elements[index+1:], v.elements[index:])
+	v.elements[index] = element
+	return nil
+}
+
+// RemoveAt removes the element at index, shifting later elements down
+func (v *ArrayValue) RemoveAt(index int) error {
+	if v.threadSafe {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+	}
+	if index < 0 || index >= len(v.elements) {
+		return v.indexError(index)
+	}
+	copy(v.elements[index:], v.elements[index+1:])
+	v.elements[len(v.elements)-1] = nil
+	v.elements = v.elements[:len(v.elements)-1]
+	return nil
+}
+
+// indexError reports an out-of-range index. The caller must hold the lock
+// in thread-safe mode.
+func (v *ArrayValue) indexError(index int) error {
+	return fmt.Errorf("ArrayValue index %d out of range (size: %d): %w", index, len(v.elements), core.ErrIndexOutOfRange)
+}
+
+// Clone returns a deep copy of the array.
+// Every element is cloned recursively, so nested arrays and containers in
+// the clone share no state with the original.
+func (v *ArrayValue) Clone() core.Value {
+	elements := v.snapshot()
+	clone := NewArrayValue(v.Name())
+	clone.elements = make([]core.Value, len(elements))
+	for i, element := range elements {
+		clone.elements[i] = element.Clone()
+	}
+	return clone
+}
+
 // Serialize serializes the array and all its elements
 func (v *ArrayValue) Serialize() (string, error) {
-	result := fmt.Sprintf("[%s,%s,%d];", v.Name(), v.Type().String(), len(v.elements))
-	for _, element := range v.elements {
+	elements := v.snapshot()
+	result := fmt.Sprintf("[%s,%s,%d];", v.Name(), v.Type().String(), len(elements))
+	for _, element := range elements {
 		elemSer, err := element.Serialize()
 		if err != nil {
 			return "", err
@@ -99,30 +216,33 @@ func (v *ArrayValue) Serialize() (string, error) {
 	return result, nil
 }
 
-// ToXML converts to XML representation
+// ToXML converts to XML representation, nesting each element's XML element
+// directly inside the array element
 func (v *ArrayValue) ToXML() (string, error) {
 	type XMLArray struct {
 		XMLName  xml.Name `xml:"array"`
 		Name     string   `xml:"name,attr"`
 		Type     string   `xml:"type,attr"`
 		Count    int      `xml:"count,attr"`
-		Elements []string `xml:"element"`
+		Elements string   `xml:",innerxml"`
 	}
 
+	elements := v.snapshot()
 	xmlArr := XMLArray{
-		Name:     v.Name(),
-		Type:     v.Type().TypeName(),
-		Count:    len(v.elements),
-		Elements: make([]string, 0),
+		Name:  v.Name(),
+		Type:  v.Type().TypeName(),
+		Count: len(elements),
 	}
 
-	for _, element := range v.elements {
+	var inner strings.Builder
+	for _, element := range elements {
 		elemXML, err := element.ToXML()
 		if err != nil {
 			return "", err
 		}
-		xmlArr.Elements = append(xmlArr.Elements, elemXML)
+		inner.WriteString(elemXML)
 	}
+	xmlArr.Elements = inner.String()
 
 	data, err := xml.Marshal(xmlArr)
 	if err != nil {
@@ -135,27 +255,24 @@ func (v *ArrayValue) ToXML() (string, error) {
 // ToJSON converts to JSON representation
 func (v *ArrayValue) ToJSON() (string, error) {
 	type JSONArray struct {
-		Name     string        `json:"name"`
-		Type     string        `json:"type"`
-		Elements []interface{} `json:"elements"`
+		Name     string            `json:"name"`
+		Type     string            `json:"type"`
+		Elements []json.RawMessage `json:"elements"`
 	}
 
 	jsonArr := JSONArray{
 		Name:     v.Name(),
 		Type:     "array",
-		Elements: make([]interface{}, 0),
+		Elements: make([]json.RawMessage, 0),
 	}
 
-	for _, element := range v.elements {
+	for _, element := range v.snapshot() {
 		elemJSON, err := element.ToJSON()
 		if err != nil {
 			return "", err
 		}
-		var elemData interface{}
-		if err := json.Unmarshal([]byte(elemJSON), &elemData); err != nil {
-			return "", err
-		}
-		jsonArr.Elements = append(jsonArr.Elements, elemData)
+		// Embed the element JSON as-is so 64-bit integers keep their precision
+		jsonArr.Elements = append(jsonArr.Elements, json.RawMessage(elemJSON))
 	}
 
 	data, err := json.MarshalIndent(jsonArr, "", "  ")
@@ -168,13 +285,13 @@ func (v *ArrayValue) ToJSON() (string, error) {
 
 // Data returns a human-readable description as bytes
 func (v *ArrayValue) Data() []byte {
-	return []byte(fmt.Sprintf("Array(%d elements)", len(v.elements)))
+	return []byte(fmt.Sprintf("Array(%d elements)", v.Count()))
 }
 
 // Size returns the size in bytes (for serialization)
 func (v *ArrayValue) Size() int {
 	size := 4 // count (4 bytes)
-	for _, element := range v.elements {
+	for _, element := range v.snapshot() {
 		size += element.Size()
 	}
 	return size
@@ -185,70 +302,44 @@ func (v *ArrayValue) Size() int {
 // Binary format (little-endian):
 // [type:1=15][name_len:4 LE][name:UTF-8][value_size:4 LE][count:4 LE][element1_bytes][element2_bytes]...
 func (v *ArrayValue) ToBinaryBytes() ([]byte, error) {
-	// Serialize all elements first to calculate total size
-	serializedElements := make([][]byte, 0, len(v.elements))
-	totalElementsSize := 0
+	return v.AppendFrame(make([]byte, 0, v.SerializedSize()))
+}
 
-	for _, element := range v.elements {
-		elemBytes, err := element.ToBytes()
-		if err != nil {
-			return nil, fmt.Errorf("Failed to serialize element: %v", err)
-		}
-		serializedElements = append(serializedElements, elemBytes)
-		totalElementsSize += len(elemBytes)
-	}
+// AppendFrame appends the ToBinaryBytes frame to dst, serializing the
+// elements in place instead of building an intermediate slice per element
+func (v *ArrayValue) AppendFrame(dst []byte) ([]byte, error) {
+	elements := v.snapshot()
 
 	// value_size = count(4) + all element bytes
-	valueSize := 4 + totalElementsSize
+	valueSize := 4
+	for _, element := range elements {
+		valueSize += element.SerializedSize()
+	}
 
-	name := v.Name()
-	nameBytes := []byte(name)
-	nameLen := uint32(len(nameBytes))
+	dst = append(dst, byte(core.ArrayValue))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(v.Name())))
+	dst = append(dst, v.Name()...)
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(valueSize))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(elements)))
 
-	// Calculate total size
-	// type(1) + name_len(4) + name + value_size(4) + count(4) + elements
-	totalSize := 1 + 4 + len(nameBytes) + 4 + 4 + totalElementsSize
-
-	result := make([]byte, 0, totalSize)
-
-	// Type (1 byte) - ArrayValue = 15
-	result = append(result, byte(core.ArrayValue))
-
-	// Name length (4 bytes, little-endian)
-	result = append(result,
-		byte(nameLen&0xFF),
-		byte((nameLen>>8)&0xFF),
-		byte((nameLen>>16)&0xFF),
-		byte((nameLen>>24)&0xFF),
-	)
-
-	// Name (UTF-8 bytes)
-	result = append(result, nameBytes...)
-
-	// Value size (4 bytes, little-endian)
-	valueSizeU32 := uint32(valueSize)
-	result = append(result,
-		byte(valueSizeU32&0xFF),
-		byte((valueSizeU32>>8)&0xFF),
-		byte((valueSizeU32>>16)&0xFF),
-		byte((valueSizeU32>>24)&0xFF),
-	)
-
-	// Element count (4 bytes, little-endian)
-	count := uint32(len(v.elements))
-	result = append(result,
-		byte(count&0xFF),
-		byte((count>>8)&0xFF),
-		byte((count>>16)&0xFF),
-		byte((count>>24)&0xFF),
-	)
-
-	// Append all serialized elements
-	for _, elemBytes := range serializedElements {
-		result = append(result, elemBytes...)
+	var err error
+	for _, element := range elements {
+		if dst, err = core.AppendValueFrame(dst, element); err != nil {
+			return dst, fmt.Errorf("Failed to serialize element: %w", err)
+		}
 	}
+	return dst, nil
+}
 
-	return result, nil
+// SerializedSize returns the number of bytes ToBytes() produces,
+// computed from the elements without serializing them
+func (v *ArrayValue) SerializedSize() int {
+	// type(1) + name_len(4) + name + value_size(4) + count(4) + elements
+	size := 1 + 4 + len(v.Name()) + 4 + 4
+	for _, element := range v.snapshot() {
+		size += element.SerializedSize()
+	}
+	return size
 }
 
 // ToBytes implements the Value interface by delegating to ToBinaryBytes
@@ -265,7 +356,7 @@ func (v *ArrayValue) ToBytes() ([]byte, error) {
 // [type:1=15][name_len:4 LE][name:UTF-8][value_size:4 LE][count:4 LE][element1][element2]...
 func DeserializeArrayValue(data []byte) (*ArrayValue, error) {
 	if len(data) < 13 { // type(1) + name_len(4) + value_size(4) + count(4)
-		return nil, fmt.Errorf("ArrayValue binary data too short: %d bytes", len(data))
+		return nil, fmt.Errorf("ArrayValue binary data too short: %d bytes: %w", len(data), core.ErrTruncatedData)
 	}
 
 	offset := 0
@@ -286,15 +377,15 @@ func DeserializeArrayValue(data []byte) (*ArrayValue, error) {
 	offset += 4
 
 	// Read name
-	if offset+int(nameLen) > len(data) {
-		return nil, fmt.Errorf("Name length %d exceeds data bounds", nameLen)
+	if uint64(offset)+uint64(nameLen) > uint64(len(data)) {
+		return nil, fmt.Errorf("Name length %d exceeds data bounds: %w", nameLen, core.ErrTruncatedData)
 	}
 	name := string(data[offset : offset+int(nameLen)])
 	offset += int(nameLen)
 
 	// Read value size (4 bytes, little-endian)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("Insufficient data for value_size")
+		return nil, fmt.Errorf("Insufficient data for value_size: %w", core.ErrTruncatedData)
 	}
 	valueSize := uint32(data[offset]) |
 		(uint32(data[offset+1]) << 8) |
@@ -302,43 +393,13 @@ func DeserializeArrayValue(data []byte) (*ArrayValue, error) {
 		(uint32(data[offset+3]) << 24)
 	offset += 4
 
-	_ = valueSize // Not strictly needed for deserialization logic, but available for validation
-
-	// Read element count (4 bytes, little-endian)
-	if offset+4 > len(data) {
-		return nil, fmt.Errorf("Insufficient data for element count")
-	}
-	count := uint32(data[offset]) |
-		(uint32(data[offset+1]) << 8) |
-		(uint32(data[offset+2]) << 16) |
-		(uint32(data[offset+3]) << 24)
-	offset += 4
-
-	// Create ArrayValue
-	result := NewArrayValue(name)
-
-	// Deserialize all elements
-	for i := uint32(0); i < count; i++ {
-		if offset >= len(data) {
-			return nil, fmt.Errorf("Unexpected end of data while reading element %d/%d", i+1, count)
-		}
-
-		// Extract remaining data for element deserialization
-		elementData := data[offset:]
-
-		// Deserialize element using factory (requires value factory implementation)
-		// TODO: Implement value factory for all types
-		// For now, we'll need to implement type detection and routing
-		element, bytesRead, err := deserializeValue(elementData)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to deserialize element %d: %v", i, err)
-		}
-
-		result.Append(element)
-		offset += bytesRead
+	if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+		return nil, fmt.Errorf("Value size %d exceeds data bounds: %w", valueSize, core.ErrTruncatedData)
 	}
 
-	return result, nil
+	// Elements are read from the value_size region only, so a corrupt count
+	// cannot run into whatever follows the array
+	return deserializeArrayData(name, data[offset:offset+int(valueSize)])
 }
 
 // deserializeValue is a helper that deserializes a single value from binary data
@@ -347,7 +408,7 @@ func DeserializeArrayValue(data []byte) (*ArrayValue, error) {
 // This is a placeholder for the full Value factory implementation.
 func deserializeValue(data []byte) (core.Value, int, error) {
 	if len(data) < 1 {
-		return nil, 0, fmt.Errorf("Empty data for value deserialization")
+		return nil, 0, fmt.Errorf("Empty data for value deserialization: %w", core.ErrTruncatedData)
 	}
 
 	// Read type ID
@@ -355,363 +416,374 @@ func deserializeValue(data []byte) (core.Value, int, error) {
 
 	// Full factory pattern supporting all primitive value types
 	switch typeID {
-	case core.BoolValue:
-		// Deserialize BoolValue (type 1)
-		// Format: [type:1][name_len:4][name][value_size:4][value:1]
-		if len(data) < 10 { // Minimum: type(1) + name_len(4) + value_size(4) + value(1)
-			return nil, 0, fmt.Errorf("Insufficient data for BoolValue")
+	case core.NullValue:
+		// Deserialize NullValue (type 0)
+		// Format: [type:1][name_len:4][name][value_size:4=0]
+		name, _, n, err := fixedWidthFrame(data, typeID, 0)
+		if err != nil {
+			return nil, 0, err
 		}
+		return NewNullValue(name), n, nil
 
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		if offset+int(nameLen)+4+1 > len(data) {
-			return nil, 0, fmt.Errorf("Data too short for BoolValue")
+	case core.BoolValue:
+		// Deserialize BoolValue (type 1)
+		// Format: [type:1][name_len:4][name][value_size:4=1][value:1]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 1)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		// Skip value_size (4 bytes)
-		offset += 4
-
-		// Read bool value (1 byte)
-		value := data[offset] != 0
-		offset += 1
-
-		return NewBoolValue(name, value), offset, nil
+		return NewBoolValue(name, payload[0] != 0), n, nil
 
 	case core.ShortValue:
 		// Deserialize Int16Value (type 2)
-		// Format: [type:1][name_len:4][name][value_size:4][value:2]
-		if len(data) < 11 {
-			return nil, 0, fmt.Errorf("Insufficient data for Int16Value")
-		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		if offset+int(nameLen)+4+2 > len(data) {
-			return nil, 0, fmt.Errorf("Data too short for Int16Value")
+		// Format: [type:1][name_len:4][name][value_size:4=2][value:2]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 2)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		// Skip value_size (4 bytes)
-		offset += 4
-
-		// Read int16 value (2 bytes, little-endian)
-		value := int16(data[offset]) | (int16(data[offset+1]) << 8)
-		offset += 2
-
-		return NewInt16Value(name, value), offset, nil
+		return NewInt16Value(name, int16(binary.LittleEndian.Uint16(payload))), n, nil
 
 	case core.UShortValue:
 		// Deserialize UInt16Value (type 3)
-		// Format: [type:1][name_len:4][name][value_size:4][value:2]
-		if len(data) < 11 {
-			return nil, 0, fmt.Errorf("Insufficient data for UInt16Value")
-		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		if offset+int(nameLen)+4+2 > len(data) {
-			return nil, 0, fmt.Errorf("Data too short for UInt16Value")
+		// Format: [type:1][name_len:4][name][value_size:4=2][value:2]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 2)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		// Skip value_size (4 bytes)
-		offset += 4
-
-		// Read uint16 value (2 bytes, little-endian)
-		value := uint16(data[offset]) | (uint16(data[offset+1]) << 8)
-		offset += 2
-
-		return NewUInt16Value(name, value), offset, nil
+		return NewUInt16Value(name, binary.LittleEndian.Uint16(payload)), n, nil
 
 	case core.IntValue:
-		// Deserialize IntValue (type 3)
-		// Format: [type:1][name_len:4][name][value_size:4][value:4]
-		if len(data) < 13 { // Minimum: type(1) + name_len(4) + name(0) + value_size(4) + value(4)
-			return nil, 0, fmt.Errorf("Insufficient data for IntValue")
+		// Deserialize IntValue (type 4)
+		// Format: [type:1][name_len:4][name][value_size:4=4][value:4]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 4)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		// Skip value_size (4 bytes)
-		offset += 4
-
-		// Read int value (4 bytes, little-endian)
-		value := int32(data[offset]) | (int32(data[offset+1]) << 8) | (int32(data[offset+2]) << 16) | (int32(data[offset+3]) << 24)
-		offset += 4
-
-		return NewInt32Value(name, value), offset, nil
+		return NewInt32Value(name, int32(binary.LittleEndian.Uint32(payload))), n, nil
 
 	case core.UIntValue:
 		// Deserialize UInt32Value (type 5)
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for UInt32Value")
+		// Format: [type:1][name_len:4][name][value_size:4=4][value:4]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 4)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		offset += 4 // Skip value_size
-
-		value := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		return NewUInt32Value(name, value), offset, nil
+		return NewUInt32Value(name, binary.LittleEndian.Uint32(payload)), n, nil
 
 	case core.LLongValue:
 		// Deserialize Int64Value (type 8)
-		if len(data) < 17 {
-			return nil, 0, fmt.Errorf("Insufficient data for Int64Value")
+		// Format: [type:1][name_len:4][name][value_size:4=8][value:8]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 8)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		offset += 4 // Skip value_size
-
-		value := int64(data[offset]) | (int64(data[offset+1]) << 8) | (int64(data[offset+2]) << 16) | (int64(data[offset+3]) << 24) |
-			(int64(data[offset+4]) << 32) | (int64(data[offset+5]) << 40) | (int64(data[offset+6]) << 48) | (int64(data[offset+7]) << 56)
-		offset += 8
-
-		return NewInt64Value(name, value), offset, nil
+		return NewInt64Value(name, int64(binary.LittleEndian.Uint64(payload))), n, nil
 
 	case core.ULLongValue:
 		// Deserialize UInt64Value (type 9)
-		if len(data) < 17 {
-			return nil, 0, fmt.Errorf("Insufficient data for UInt64Value")
+		// Format: [type:1][name_len:4][name][value_size:4=8][value:8]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 8)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		offset += 4 // Skip value_size
-
-		value := uint64(data[offset]) | (uint64(data[offset+1]) << 8) | (uint64(data[offset+2]) << 16) | (uint64(data[offset+3]) << 24) |
-			(uint64(data[offset+4]) << 32) | (uint64(data[offset+5]) << 40) | (uint64(data[offset+6]) << 48) | (uint64(data[offset+7]) << 56)
-		offset += 8
-
-		return NewUInt64Value(name, value), offset, nil
+		return NewUInt64Value(name, binary.LittleEndian.Uint64(payload)), n, nil
 
 	case core.FloatValue:
 		// Deserialize Float32Value (type 10)
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for Float32Value")
+		// Format: [type:1][name_len:4][name][value_size:4=4][value:4]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 4)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		offset += 4 // Skip value_size
-
-		bits := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		value := math.Float32frombits(bits)
-		offset += 4
-
-		return NewFloat32Value(name, value), offset, nil
+		return NewFloat32Value(name, math.Float32frombits(binary.LittleEndian.Uint32(payload))), n, nil
 
 	case core.DoubleValue:
 		// Deserialize Float64Value (type 11)
-		if len(data) < 17 {
-			return nil, 0, fmt.Errorf("Insufficient data for Float64Value")
+		// Format: [type:1][name_len:4][name][value_size:4=8][value:8]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 8)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		offset += 4 // Skip value_size
-
-		bits := uint64(data[offset]) | (uint64(data[offset+1]) << 8) | (uint64(data[offset+2]) << 16) | (uint64(data[offset+3]) << 24) |
-			(uint64(data[offset+4]) << 32) | (uint64(data[offset+5]) << 40) | (uint64(data[offset+6]) << 48) | (uint64(data[offset+7]) << 56)
-		value := math.Float64frombits(bits)
-		offset += 8
-
-		return NewFloat64Value(name, value), offset, nil
+		return NewFloat64Value(name, math.Float64frombits(binary.LittleEndian.Uint64(payload))), n, nil
 
 	case core.BytesValue:
 		// Deserialize BytesValue (type 13) - matches C++ bytes_value position
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for BytesValue")
+		// Minimum: type(1) + name_len(4) + value_size(4); name and value may be empty
+		if len(data) < 9 {
+			return nil, 0, fmt.Errorf("Insufficient data for BytesValue: %w", core.ErrTruncatedData)
 		}
 
 		offset := 1
 		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
+		if uint64(offset)+uint64(nameLen)+4 > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for BytesValue: %w", core.ErrTruncatedData)
+		}
+
 		name := string(data[offset : offset+int(nameLen)])
 		offset += int(nameLen)
 
 		valueSize := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
+		if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for BytesValue: %w", core.ErrTruncatedData)
+		}
+
 		value := make([]byte, valueSize)
 		copy(value, data[offset:offset+int(valueSize)])
 		offset += int(valueSize)
 
 		return NewBytesValue(name, value), offset, nil
 
-	case core.LongValue:
-		// Deserialize LongValue (type 6) - 32-bit signed for C++ compatibility
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for LongValue")
+	case core.LongValue, core.ULongValue:
+		// Deserialize LongValue (type 6) / ULongValue (type 7) - 32-bit for C++
+		// compatibility. The payload goes through the range-checked
+		// constructors so out-of-range values from other languages are rejected.
+		if len(data) < 9 {
+			return nil, 0, fmt.Errorf("Insufficient data for %s: %w", typeID.TypeName(), core.ErrTruncatedData)
 		}
 
 		offset := 1
 		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
+		if uint64(offset)+uint64(nameLen)+4 > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for %s: %w", typeID.TypeName(), core.ErrTruncatedData)
+		}
 		name := string(data[offset : offset+int(nameLen)])
 		offset += int(nameLen)
 
-		offset += 4 // Skip value_size
-
-		value := int32(data[offset]) | (int32(data[offset+1]) << 8) | (int32(data[offset+2]) << 16) | (int32(data[offset+3]) << 24)
+		valueSize := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
-		return NewInt32Value(name, value), offset, nil
-
-	case core.ULongValue:
-		// Deserialize ULongValue (type 7) - 32-bit unsigned for C++ compatibility
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for ULongValue")
+		if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for %s: %w", typeID.TypeName(), core.ErrTruncatedData)
 		}
+		value, err := NewValueFromData(name, typeID, data[offset:offset+int(valueSize)])
+		if err != nil {
+			return nil, 0, err
+		}
+		offset += int(valueSize)
 
-		offset := 1
-		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		name := string(data[offset : offset+int(nameLen)])
-		offset += int(nameLen)
-
-		offset += 4 // Skip value_size
-
-		value := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
-		offset += 4
-
-		return NewUInt32Value(name, value), offset, nil
+		return value, offset, nil
 
 	case core.StringValue:
 		// Deserialize StringValue (type 12) - matches C++ string_value position
 		// Format: [type:1][name_len:4][name][value_size:4][string_bytes]
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for StringValue")
+		// Minimum: type(1) + name_len(4) + value_size(4); name and value may be empty
+		if len(data) < 9 {
+			return nil, 0, fmt.Errorf("Insufficient data for StringValue: %w", core.ErrTruncatedData)
 		}
 
 		offset := 1
 		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
+		if uint64(offset)+uint64(nameLen)+4 > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for StringValue: %w", core.ErrTruncatedData)
+		}
+
 		name := string(data[offset : offset+int(nameLen)])
 		offset += int(nameLen)
 
 		valueSize := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
+		if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for StringValue: %w", core.ErrTruncatedData)
+		}
+
 		strValue := string(data[offset : offset+int(valueSize)])
 		offset += int(valueSize)
 
 		return NewStringValue(name, strValue), offset, nil
 
-	case core.ContainerValue:
-		// Deserialize ContainerValue (type 14) - nested container
-		// Format: [type:1][name_len:4][name][value_size:4][child_count:4][children...]
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for ContainerValue")
+	case core.DateTimeValue:
+		// Deserialize DateTimeValue (type 16) - nanoseconds since Unix epoch
+		// Format: [type:1][name_len:4][name][value_size:4=8][value:8]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 8)
+		if err != nil {
+			return nil, 0, err
+		}
+		return NewDateTimeValue(name, time.Unix(0, int64(binary.LittleEndian.Uint64(payload))).UTC()), n, nil
+
+	case core.UUIDValue:
+		// Deserialize UUIDValue (type 17) - 16 bytes in RFC 4122 order
+		// Format: [type:1][name_len:4][name][value_size:4=16][value:16]
+		name, payload, n, err := fixedWidthFrame(data, typeID, 16)
+		if err != nil {
+			return nil, 0, err
+		}
+		var id [16]byte
+		copy(id[:], payload)
+		return NewUUIDValue(name, id), n, nil
+
+	case core.DecimalValue:
+		// Deserialize DecimalValue (type 18) - [scale:4][len:4][unscaled]
+		// Format: [type:1][name_len:4][name][value_size:4][payload]
+		if len(data) < 9 {
+			return nil, 0, fmt.Errorf("Insufficient data for DecimalValue: %w", core.ErrTruncatedData)
 		}
 
 		offset := 1
 		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
+		if uint64(offset)+uint64(nameLen)+4 > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for DecimalValue: %w", core.ErrTruncatedData)
+		}
 		name := string(data[offset : offset+int(nameLen)])
 		offset += int(nameLen)
 
 		valueSize := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
-		// Recursively deserialize container data
-		containerData := data[offset : offset+int(valueSize)]
-		container, err := deserializeContainerData(name, containerData)
+		if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for DecimalValue: %w", core.ErrTruncatedData)
+		}
+		unscaled, scale, err := core.DecodeDecimal(data[offset : offset+int(valueSize)])
 		if err != nil {
-			return nil, 0, fmt.Errorf("Failed to deserialize ContainerValue: %v", err)
+			return nil, 0, err
 		}
 		offset += int(valueSize)
 
-		return container, offset, nil
+		return NewDecimalValue(name, unscaled, scale), offset, nil
+
+	case core.ContainerValue:
+		// Deserialize ContainerValue (type 14) - nested container
+		// Format: [type:1][name_len:4][name][value_size:4][child_count:4][children...]
+		frameLen, err := nestedFrameLength(data)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Invalid ContainerValue frame: %w", err)
+		}
+
+		container, err := DeserializeContainerValue(data[:frameLen])
+		if err != nil {
+			return nil, 0, fmt.Errorf("Failed to deserialize ContainerValue: %w", err)
+		}
+
+		return container, frameLen, nil
 
 	case core.ArrayValue:
 		// Deserialize ArrayValue (type 15) - heterogeneous array
 		// Format: [type:1][name_len:4][name][value_size:4][count:4][elements...]
-		if len(data) < 13 {
-			return nil, 0, fmt.Errorf("Insufficient data for ArrayValue")
+		frameLen, err := nestedFrameLength(data)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Invalid ArrayValue frame: %w", err)
+		}
+
+		arr, err := DeserializeArrayValue(data[:frameLen])
+		if err != nil {
+			return nil, 0, fmt.Errorf("Failed to deserialize ArrayValue: %w", err)
+		}
+
+		return arr, frameLen, nil
+
+	case core.MapValue:
+		// Deserialize MapValue (type 19) - entries keyed by unique strings
+		// Format: [type:1][name_len:4][name][value_size:4][count:4][entries...]
+		frameLen, err := nestedFrameLength(data)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Invalid MapValue frame: %w", err)
+		}
+
+		m, err := DeserializeMapValue(data[:frameLen])
+		if err != nil {
+			return nil, 0, fmt.Errorf("Failed to deserialize MapValue: %w", err)
+		}
+
+		return m, frameLen, nil
+
+	default:
+		// Unknown type code: strict mode rejects it, lenient mode keeps the
+		// frame as an opaque value (see core.FactoryMode)
+		if len(data) < 9 {
+			return nil, 0, fmt.Errorf("Insufficient data for value type %d: %w", typeID, core.ErrTruncatedData)
 		}
 
 		offset := 1
 		nameLen := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
+		if uint64(offset)+uint64(nameLen)+4 > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for value type %d: %w", typeID, core.ErrTruncatedData)
+		}
 		name := string(data[offset : offset+int(nameLen)])
 		offset += int(nameLen)
 
 		valueSize := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
 		offset += 4
 
-		// Recursively deserialize array data
-		arrayData := data[offset : offset+int(valueSize)]
-		arr, err := deserializeArrayData(name, arrayData)
+		if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+			return nil, 0, fmt.Errorf("Data too short for value type %d: %w", typeID, core.ErrTruncatedData)
+		}
+		value, err := core.NewUnknownValue(name, typeID, data[offset:offset+int(valueSize)])
 		if err != nil {
-			return nil, 0, fmt.Errorf("Failed to deserialize ArrayValue: %v", err)
+			return nil, 0, err
 		}
 		offset += int(valueSize)
 
-		return arr, offset, nil
+		return value, offset, nil
+	}
+}
 
-	default:
-		return nil, 0, fmt.Errorf("Unsupported value type for deserialization: %d", typeID)
+// fixedWidthFrame parses the frame of a fixed-width value and returns its
+// name, its payload and the frame length. A value_size other than width is
+// rejected with ErrTruncatedData: the writer framed the value differently,
+// and trusting either length would misalign every value that follows.
+func fixedWidthFrame(data []byte, typeID core.ValueType, width int) (string, []byte, int, error) {
+	if len(data) < 9 {
+		return "", nil, 0, fmt.Errorf("Insufficient data for %s: %w", typeID.TypeName(), core.ErrTruncatedData)
 	}
+	nameLen := binary.LittleEndian.Uint32(data[1:5])
+	offset := 5
+	if uint64(offset)+uint64(nameLen)+4 > uint64(len(data)) {
+		return "", nil, 0, fmt.Errorf("Data too short for %s: %w", typeID.TypeName(), core.ErrTruncatedData)
+	}
+	name := string(data[offset : offset+int(nameLen)])
+	offset += int(nameLen)
+
+	valueSize := binary.LittleEndian.Uint32(data[offset:])
+	offset += 4
+	if valueSize != uint32(width) {
+		return "", nil, 0, fmt.Errorf("%s declares value_size %d, expected %d: %w", typeID.TypeName(), valueSize, width, core.ErrTruncatedData)
+	}
+	if offset+width > len(data) {
+		return "", nil, 0, fmt.Errorf("Data too short for %s: %w", typeID.TypeName(), core.ErrTruncatedData)
+	}
+	return name, data[offset : offset+width], offset + width, nil
+}
+
+// nestedFrameLength returns the total length of a container, array or map frame
+// ([type:1][name_len:4][name][value_size:4][payload]) so that the caller can
+// advance past it. The payload itself is not inspected.
+func nestedFrameLength(data []byte) (int, error) {
+	if len(data) < 13 { // type(1) + name_len(4) + value_size(4) + count(4)
+		return 0, fmt.Errorf("insufficient data: %d bytes: %w", len(data), core.ErrTruncatedData)
+	}
+
+	nameLen := uint32(data[1]) | (uint32(data[2]) << 8) | (uint32(data[3]) << 16) | (uint32(data[4]) << 24)
+	if 5+uint64(nameLen)+4 > uint64(len(data)) {
+		return 0, fmt.Errorf("name length %d exceeds data bounds: %w", nameLen, core.ErrTruncatedData)
+	}
+	offset := 5 + int(nameLen)
+
+	valueSize := uint32(data[offset]) | (uint32(data[offset+1]) << 8) | (uint32(data[offset+2]) << 16) | (uint32(data[offset+3]) << 24)
+	offset += 4
+	if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+		return 0, fmt.Errorf("value size %d exceeds data bounds: %w", valueSize, core.ErrTruncatedData)
+	}
+
+	return offset + int(valueSize), nil
 }
 
 // deserializeArrayData deserializes array element data (after header is parsed).
 // The data format is: [count:4 LE][element1][element2]...
 func deserializeArrayData(name string, data []byte) (*ArrayValue, error) {
 	if len(data) < 4 {
-		return nil, fmt.Errorf("Array data too short: %d bytes", len(data))
+		return nil, fmt.Errorf("Array data too short: %d bytes: %w", len(data), core.ErrTruncatedData)
 	}
 
 	offset := 0
@@ -729,7 +801,7 @@ func deserializeArrayData(name string, data []byte) (*ArrayValue, error) {
 	// Deserialize all elements
 	for i := uint32(0); i < count; i++ {
 		if offset >= len(data) {
-			return nil, fmt.Errorf("Unexpected end of data while reading element %d/%d", i+1, count)
+			return nil, fmt.Errorf("Unexpected end of data while reading element %d/%d: %w", i+1, count, core.ErrTruncatedData)
 		}
 
 		// Extract remaining data for element deserialization
@@ -738,13 +810,16 @@ func deserializeArrayData(name string, data []byte) (*ArrayValue, error) {
 		// Deserialize element using factory
 		element, bytesRead, err := deserializeValue(elementData)
 		if err != nil {
-			return nil, fmt.Errorf("Failed to deserialize element %d: %v", i, err)
+			return nil, fmt.Errorf("Failed to deserialize element %d: %w", i, err)
 		}
 
 		result.Append(element)
 		offset += bytesRead
 	}
 
+	if offset != len(data) {
+		return nil, fmt.Errorf("%d elements consumed %d of %d payload bytes: %w", count, offset, len(data), core.ErrTrailingData)
+	}
 	return result, nil
 }
 
@@ -752,7 +827,7 @@ func deserializeArrayData(name string, data []byte) (*ArrayValue, error) {
 // The data format is: [child_count:4 LE][child1][child2]...
 func deserializeContainerData(name string, data []byte) (*ContainerValue, error) {
 	if len(data) < 4 {
-		return nil, fmt.Errorf("Container data too short: %d bytes", len(data))
+		return nil, fmt.Errorf("Container data too short: %d bytes: %w", len(data), core.ErrTruncatedData)
 	}
 
 	offset := 0
@@ -770,7 +845,7 @@ func deserializeContainerData(name string, data []byte) (*ContainerValue, error)
 	// Deserialize all children
 	for i := uint32(0); i < childCount; i++ {
 		if offset >= len(data) {
-			return nil, fmt.Errorf("Unexpected end of data while reading child %d/%d", i+1, childCount)
+			return nil, fmt.Errorf("Unexpected end of data while reading child %d/%d: %w", i+1, childCount, core.ErrTruncatedData)
 		}
 
 		// Extract remaining data for child deserialization
@@ -779,12 +854,15 @@ func deserializeContainerData(name string, data []byte) (*ContainerValue, error)
 		// Deserialize child using factory
 		child, bytesRead, err := deserializeValue(childData)
 		if err != nil {
-			return nil, fmt.Errorf("Failed to deserialize child %d: %v", i, err)
+			return nil, fmt.Errorf("Failed to deserialize child %d: %w", i, err)
 		}
 
 		result.AddChild(child)
 		offset += bytesRead
 	}
 
+	if offset != len(data) {
+		return nil, fmt.Errorf("%d children consumed %d of %d payload bytes: %w", childCount, offset, len(data), core.ErrTrailingData)
+	}
 	return result, nil
 }
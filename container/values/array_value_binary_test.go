@@ -9,7 +9,11 @@ package values
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
 )
 
 func TestArrayValueBinarySerialization(t *testing.T) {
@@ -370,3 +374,174 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestArrayValueBinary_NestedArrays(t *testing.T) {
+	// Two levels of nesting: [[1, 2], [[3], "four"]]
+	leafA := NewArrayValue("a", NewInt32Value("", 1), NewInt32Value("", 2))
+	deepest := NewArrayValue("deep", NewInt32Value("", 3))
+	leafB := NewArrayValue("b", deepest, NewStringValue("", "four"))
+	root := NewArrayValue("root", leafA, leafB)
+
+	binaryData, err := root.ToBinaryBytes()
+	if err != nil {
+		t.Fatalf("Binary serialization failed: %v", err)
+	}
+
+	restored, err := DeserializeArrayValue(binaryData)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+	if restored.Count() != 2 {
+		t.Fatalf("Expected 2 elements, got %d", restored.Count())
+	}
+
+	first, _ := restored.At(0)
+	firstArr, ok := first.(*ArrayValue)
+	if !ok {
+		t.Fatalf("Expected first element to be ArrayValue, got %T", first)
+	}
+	if firstArr.Count() != 2 {
+		t.Fatalf("Expected 2 elements in first array, got %d", firstArr.Count())
+	}
+	for i, expected := range []int32{1, 2} {
+		elem, _ := firstArr.At(i)
+		if val, _ := elem.ToInt32(); val != expected {
+			t.Errorf("first[%d]: expected %d, got %d", i, expected, val)
+		}
+	}
+
+	second, _ := restored.At(1)
+	secondArr, ok := second.(*ArrayValue)
+	if !ok {
+		t.Fatalf("Expected second element to be ArrayValue, got %T", second)
+	}
+	if secondArr.Count() != 2 {
+		t.Fatalf("Expected 2 elements in second array, got %d", secondArr.Count())
+	}
+
+	inner, _ := secondArr.At(0)
+	innerArr, ok := inner.(*ArrayValue)
+	if !ok {
+		t.Fatalf("Expected nested element to be ArrayValue, got %T", inner)
+	}
+	if innerArr.Name() != "deep" || innerArr.Count() != 1 {
+		t.Fatalf("Unexpected nested array: name=%s count=%d", innerArr.Name(), innerArr.Count())
+	}
+	leaf, _ := innerArr.At(0)
+	if val, _ := leaf.ToInt32(); val != 3 {
+		t.Errorf("Expected deepest leaf 3, got %d", val)
+	}
+
+	// The element following a nested array must be read from the right offset
+	tail, _ := secondArr.At(1)
+	if str, _ := tail.ToString(); str != "four" {
+		t.Errorf("Expected 'four' after nested array, got '%s'", str)
+	}
+}
+
+func TestArrayValueBinary_ContainerElement(t *testing.T) {
+	array := NewArrayValue("records",
+		NewContainerValue("rec", NewStringValue("id", "x1")),
+		NewInt32Value("", 7),
+	)
+
+	binaryData, err := array.ToBinaryBytes()
+	if err != nil {
+		t.Fatalf("Binary serialization failed: %v", err)
+	}
+
+	restored, err := DeserializeArrayValue(binaryData)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+
+	first, _ := restored.At(0)
+	rec, ok := first.(*ContainerValue)
+	if !ok {
+		t.Fatalf("Expected ContainerValue element, got %T", first)
+	}
+	if id, _ := rec.GetChild("id", 0).ToString(); id != "x1" {
+		t.Errorf("Expected id 'x1', got '%s'", id)
+	}
+
+	second, _ := restored.At(1)
+	if val, _ := second.ToInt32(); val != 7 {
+		t.Errorf("Expected 7 after container element, got %d", val)
+	}
+}
+
+func TestArrayValueBinary_FixedWidthValueSizeMismatch(t *testing.T) {
+	id, _ := NewUUIDValueFromString("", "123e4567-e89b-12d3-a456-426614174000")
+	for _, value := range []core.Value{
+		NewBoolValue("b", true),
+		NewInt16Value("s", -3),
+		NewInt32Value("i", 42),
+		NewInt64Value("l", 1<<40),
+		NewFloat64Value("d", 2.5),
+		id,
+	} {
+		t.Run(value.Type().TypeName(), func(t *testing.T) {
+			frame, err := value.ToBytes()
+			if err != nil {
+				t.Fatalf("ToBytes failed: %v", err)
+			}
+			if _, _, err := deserializeValue(frame); err != nil {
+				t.Fatalf("Well-formed frame rejected: %v", err)
+			}
+
+			// value_size follows [type:1][name_len:4][name]
+			sizeAt := 5 + len(value.Name())
+			width := binary.LittleEndian.Uint32(frame[sizeAt:])
+			for _, declared := range []uint32{width - 1, width + 1, 0} {
+				bad := append([]byte(nil), frame...)
+				binary.LittleEndian.PutUint32(bad[sizeAt:], declared)
+				if _, _, err := deserializeValue(bad); !errors.Is(err, core.ErrTruncatedData) {
+					t.Errorf("value_size %d: expected ErrTruncatedData, got %v", declared, err)
+				}
+			}
+		})
+	}
+
+	// An int framed as 8 bytes by another writer must not be read as a
+	// 4-byte int followed by a stray value
+	frame := []byte{byte(core.IntValue), 0, 0, 0, 0, 8, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}
+	array := []byte{byte(core.ArrayValue), 0, 0, 0, 0}
+	array = binary.LittleEndian.AppendUint32(array, uint32(4+len(frame)))
+	array = binary.LittleEndian.AppendUint32(array, 1)
+	array = append(array, frame...)
+	if _, err := DeserializeArrayValue(array); !errors.Is(err, core.ErrTruncatedData) {
+		t.Errorf("Expected ErrTruncatedData for a mis-sized int element, got %v", err)
+	}
+}
+
+func TestArrayValueBinary_StopsAtValueSize(t *testing.T) {
+	first, _ := NewArrayValue("first", NewInt32Value("", 1), NewStringValue("", "one")).ToBytes()
+	second, _ := NewArrayValue("second", NewInt32Value("", 2)).ToBytes()
+	stream := append(append([]byte(nil), first...), second...)
+
+	restored, err := DeserializeArrayValue(stream)
+	if err != nil {
+		t.Fatalf("DeserializeArrayValue failed: %v", err)
+	}
+	if restored.Name() != "first" || restored.Count() != 2 {
+		t.Fatalf("Expected the first array with 2 elements, got %q with %d", restored.Name(), restored.Count())
+	}
+	if _, n, err := deserializeValue(stream); err != nil || n != len(first) {
+		t.Errorf("Expected the first frame to consume %d bytes, got %d (%v)", len(first), n, err)
+	}
+
+	// A count that overstates the elements must not read into the second array
+	countAt := 1 + 4 + len("first") + 4
+	overcounted := append([]byte(nil), stream...)
+	binary.LittleEndian.PutUint32(overcounted[countAt:], 3)
+	if _, err := DeserializeArrayValue(overcounted); !errors.Is(err, core.ErrTruncatedData) {
+		t.Errorf("Overstated count: expected ErrTruncatedData, got %v", err)
+	}
+
+	// Elements that do not fill value_size leave trailing bytes
+	undercounted := append([]byte(nil), first...)
+	binary.LittleEndian.PutUint32(undercounted[countAt:], 1)
+	if _, err := DeserializeArrayValue(undercounted); !errors.Is(err, core.ErrTrailingData) {
+		t.Errorf("Understated count: expected ErrTrailingData, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// arrayInts returns the int32 elements of an array of int values
+func arrayInts(t *testing.T, array *ArrayValue) []int32 {
+	t.Helper()
+	var result []int32
+	for _, element := range array.Elements() {
+		n, err := element.ToInt32()
+		if err != nil {
+			t.Fatalf("ToInt32 failed: %v", err)
+		}
+		result = append(result, n)
+	}
+	return result
+}
+
+func assertArrayInts(t *testing.T, array *ArrayValue, want ...int32) {
+	t.Helper()
+	got := arrayInts(t, array)
+	if len(got) != len(want) {
+		t.Fatalf("Expected elements %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected elements %v, got %v", want, got)
+		}
+	}
+}
+
+func newIntArray(elements ...int32) *ArrayValue {
+	array := NewArrayValue("list")
+	for _, n := range elements {
+		array.Append(NewInt32Value("", n))
+	}
+	return array
+}
+
+func TestArrayValueSet(t *testing.T) {
+	array := newIntArray(1, 2, 3)
+	if err := array.Set(1, NewInt32Value("", 20)); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	assertArrayInts(t, array, 1, 20, 3)
+
+	for _, index := range []int{-1, 3} {
+		if err := array.Set(index, NewInt32Value("", 0)); !errors.Is(err, core.ErrIndexOutOfRange) {
+			t.Errorf("Set(%d): expected ErrIndexOutOfRange, got %v", index, err)
+		}
+	}
+	assertArrayInts(t, array, 1, 20, 3)
+}
+
+func TestArrayValueInsertAt(t *testing.T) {
+	array := newIntArray(2, 4)
+	for _, step := range []struct {
+		index int
+		value int32
+	}{
+		{0, 1}, // front
+		{2, 3}, // middle
+		{4, 5}, // index == Count appends
+	} {
+		if err := array.InsertAt(step.index, NewInt32Value("", step.value)); err != nil {
+			t.Fatalf("InsertAt(%d) failed: %v", step.index, err)
+		}
+	}
+	assertArrayInts(t, array, 1, 2, 3, 4, 5)
+
+	for _, index := range []int{-1, 6} {
+		if err := array.InsertAt(index, NewInt32Value("", 0)); !errors.Is(err, core.ErrIndexOutOfRange) {
+			t.Errorf("InsertAt(%d): expected ErrIndexOutOfRange, got %v", index, err)
+		}
+	}
+	assertArrayInts(t, array, 1, 2, 3, 4, 5)
+
+	empty := NewArrayValue("empty")
+	if err := empty.InsertAt(0, NewInt32Value("", 9)); err != nil {
+		t.Fatalf("InsertAt(0) on empty array failed: %v", err)
+	}
+	assertArrayInts(t, empty, 9)
+}
+
+func TestArrayValueRemoveAt(t *testing.T) {
+	array := newIntArray(1, 2, 3, 4)
+	for _, index := range []int{1, 2, 0} {
+		if err := array.RemoveAt(index); err != nil {
+			t.Fatalf("RemoveAt(%d) failed: %v", index, err)
+		}
+	}
+	assertArrayInts(t, array, 3)
+
+	for _, index := range []int{-1, 1} {
+		if err := array.RemoveAt(index); !errors.Is(err, core.ErrIndexOutOfRange) {
+			t.Errorf("RemoveAt(%d): expected ErrIndexOutOfRange, got %v", index, err)
+		}
+	}
+	if err := array.RemoveAt(0); err != nil {
+		t.Fatalf("RemoveAt(0) failed: %v", err)
+	}
+	if !array.IsEmpty() {
+		t.Errorf("Expected empty array, got %d elements", array.Count())
+	}
+	if err := array.RemoveAt(0); !errors.Is(err, core.ErrIndexOutOfRange) {
+		t.Errorf("RemoveAt on empty array: expected ErrIndexOutOfRange, got %v", err)
+	}
+}
+
+func TestArrayValueMutationsSerialize(t *testing.T) {
+	array := newIntArray(1, 2, 3)
+	array.Set(0, NewStringValue("", "first"))
+	array.InsertAt(1, NewBoolValue("", true))
+	array.RemoveAt(3)
+
+	data, err := array.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	restored, err := DeserializeArrayValue(data)
+	if err != nil {
+		t.Fatalf("DeserializeArrayValue failed: %v", err)
+	}
+	if restored.Count() != 3 {
+		t.Fatalf("Expected 3 elements, got %d", restored.Count())
+	}
+	wantTypes := []core.ValueType{core.StringValue, core.BoolValue, core.IntValue}
+	for i, want := range wantTypes {
+		element, _ := restored.At(i)
+		if element.Type() != want {
+			t.Errorf("Element %d: expected %s, got %s", i, want.TypeName(), element.Type().TypeName())
+		}
+	}
+}
@@ -20,7 +20,11 @@ type BoolValue struct {
 }
 
 // NewBoolValue creates a new boolean value
+// While pooling is enabled the value is taken from a pool (see EnablePooling).
 func NewBoolValue(name string, value bool) *BoolValue {
+	if poolingEnabled.Load() {
+		return newPooledBoolValue(name, value)
+	}
 	data := make([]byte, 1)
 	if value {
 		data[0] = 1
@@ -74,6 +78,16 @@ func (v *BoolValue) Value() bool {
 	return v.value
 }
 
+// Clone returns a deep copy of the value
+func (v *BoolValue) Clone() core.Value {
+	return NewBoolValue(v.Name(), v.value)
+}
+
+// ToJSON returns the JSON representation with the value as a JSON boolean
+func (v *BoolValue) ToJSON() (string, error) {
+	return nativeJSON(v, v.value)
+}
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:1]
 func (v *BoolValue) ToBytes() ([]byte, error) {
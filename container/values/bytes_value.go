@@ -81,3 +81,15 @@ func (v *BytesValue) Value() []byte {
 	copy(result, v.value)
 	return result
 }
+
+// Clone returns a deep copy of the value.
+// The byte slice is copied so the clone does not alias the original.
+func (v *BytesValue) Clone() core.Value {
+	return NewBytesValue(v.Name(), v.value)
+}
+
+// ToJSON returns the JSON representation with the bytes as a base64 string,
+// marked with "encoding": "base64"
+func (v *BytesValue) ToJSON() (string, error) {
+	return nativeJSON(v, base64.StdEncoding.EncodeToString(v.value), "encoding", "base64")
+}
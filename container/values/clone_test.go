@@ -0,0 +1,124 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestClone_NestedContainer(t *testing.T) {
+	inner := NewContainerValue("address",
+		NewStringValue("city", "Seoul"),
+		NewBytesValue("raw", []byte{1, 2, 3}),
+	)
+	original := NewContainerValue("profile",
+		NewStringValue("name", "Alice"),
+		inner,
+	)
+
+	clone, ok := original.Clone().(*ContainerValue)
+	if !ok {
+		t.Fatalf("Expected *ContainerValue clone, got %T", original.Clone())
+	}
+
+	// Mutate the clone at both levels
+	clone.AddChild(NewInt32Value("age", 30))
+	clone.RemoveChild("name")
+	clonedInner := clone.GetChild("address", 0).(*ContainerValue)
+	clonedInner.RemoveChild("city")
+	clonedInner.AddChild(NewStringValue("country", "KR"))
+
+	if original.ChildCount() != 2 {
+		t.Errorf("Original child count changed: %d", original.ChildCount())
+	}
+	if name, _ := original.GetChild("name", 0).ToString(); name != "Alice" {
+		t.Errorf("Original name changed: '%s'", name)
+	}
+	if inner.ChildCount() != 2 {
+		t.Errorf("Original inner child count changed: %d", inner.ChildCount())
+	}
+	if city, _ := inner.GetChild("city", 0).ToString(); city != "Seoul" {
+		t.Errorf("Original city changed: '%s'", city)
+	}
+	if inner.GetChild("country", 0).Type() != core.NullValue {
+		t.Error("Original inner container gained a child added to the clone")
+	}
+
+	// Children must be distinct instances
+	if clonedInner == inner {
+		t.Error("Nested container was not cloned")
+	}
+	clonedRaw := clonedInner.GetChild("raw", 0).(*BytesValue)
+	if clonedRaw == inner.GetChild("raw", 0) {
+		t.Error("Nested bytes value was not cloned")
+	}
+	if !bytes.Equal(clonedRaw.Value(), []byte{1, 2, 3}) {
+		t.Errorf("Cloned bytes mismatch: %v", clonedRaw.Value())
+	}
+}
+
+func TestClone_NestedArray(t *testing.T) {
+	nested := NewArrayValue("row", NewInt32Value("", 1), NewInt32Value("", 2))
+	original := NewArrayValue("matrix", nested, NewContainerValue("meta", NewStringValue("k", "v")))
+
+	clone := original.Clone().(*ArrayValue)
+
+	clonedRow, _ := clone.At(0)
+	clonedRow.(*ArrayValue).Append(NewInt32Value("", 3))
+	clonedMeta, _ := clone.At(1)
+	clonedMeta.AddChild(NewStringValue("extra", "x"))
+	clone.Clear()
+
+	if original.Count() != 2 {
+		t.Errorf("Original element count changed: %d", original.Count())
+	}
+	if nested.Count() != 2 {
+		t.Errorf("Original nested array count changed: %d", nested.Count())
+	}
+	meta, _ := original.At(1)
+	if meta.ChildCount() != 1 {
+		t.Errorf("Original nested container child count changed: %d", meta.ChildCount())
+	}
+}
+
+func TestClone_Primitives(t *testing.T) {
+	long, _ := NewLongValue("long", -5)
+	ulong, _ := NewULongValue("ulong", 5)
+	primitives := []core.Value{
+		NewBoolValue("bool", true),
+		NewInt16Value("i16", -1),
+		NewUInt16Value("u16", 1),
+		NewInt32Value("i32", -2),
+		NewUInt32Value("u32", 2),
+		NewInt64Value("i64", -3),
+		NewUInt64Value("u64", 3),
+		NewFloat32Value("f32", 1.5),
+		NewFloat64Value("f64", 2.5),
+		long,
+		ulong,
+		NewStringValue("str", "text"),
+		NewBytesValue("bytes", []byte{9}),
+		NewNullValue("null"),
+	}
+
+	for _, original := range primitives {
+		clone := original.Clone()
+		if clone == original {
+			t.Errorf("%s: clone is the same instance", original.Name())
+		}
+		if clone.Name() != original.Name() || clone.Type() != original.Type() {
+			t.Errorf("%s: name/type mismatch after clone", original.Name())
+		}
+		if !bytes.Equal(clone.Data(), original.Data()) {
+			t.Errorf("%s: data mismatch after clone", original.Name())
+		}
+	}
+}
@@ -8,9 +8,11 @@ All rights reserved.
 package values
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"strings"
 
 	"github.com/kcenon/go_container_system/container/core"
 )
@@ -75,6 +77,18 @@ func (v *ContainerValue) RemoveChild(name string) error {
 	return nil
 }
 
+// Clone returns a deep copy of the container.
+// Every child is cloned recursively, so adding, removing or mutating
+// children of the clone never affects the original and vice versa.
+func (v *ContainerValue) Clone() core.Value {
+	clone := NewContainerValue(v.Name())
+	clone.children = make([]core.Value, len(v.children))
+	for i, child := range v.children {
+		clone.children[i] = child.Clone()
+	}
+	return clone
+}
+
 // Serialize serializes the container and all its children to C++ compatible format.
 //
 // Format: [name,type_code,child_count];[child1][child2]...
@@ -94,28 +108,30 @@ func (v *ContainerValue) Serialize() (string, error) {
 	return result, nil
 }
 
-// ToXML converts to XML representation
+// ToXML converts to XML representation, nesting each child's XML element
+// directly inside the container element
 func (v *ContainerValue) ToXML() (string, error) {
 	type XMLContainer struct {
 		XMLName  xml.Name `xml:"container"`
 		Name     string   `xml:"name,attr"`
 		Type     string   `xml:"type,attr"`
-		Children []string `xml:"child"`
+		Children string   `xml:",innerxml"`
 	}
 
 	xmlCont := XMLContainer{
-		Name:     v.Name(),
-		Type:     v.Type().TypeName(),
-		Children: make([]string, 0),
+		Name: v.Name(),
+		Type: v.Type().TypeName(),
 	}
 
+	var children strings.Builder
 	for _, child := range v.children {
 		childXML, err := child.ToXML()
 		if err != nil {
 			return "", err
 		}
-		xmlCont.Children = append(xmlCont.Children, childXML)
+		children.WriteString(childXML)
 	}
+	xmlCont.Children = children.String()
 
 	data, err := xml.MarshalIndent(xmlCont, "", "  ")
 	if err != nil {
@@ -129,20 +145,16 @@ func (v *ContainerValue) ToJSON() (string, error) {
 	jsonCont := map[string]interface{}{
 		"name":     v.Name(),
 		"type":     v.Type().TypeName(),
-		"children": make([]map[string]interface{}, 0),
+		"children": make([]json.RawMessage, 0),
 	}
 
-	children := make([]map[string]interface{}, 0)
+	children := make([]json.RawMessage, 0)
 	for _, child := range v.children {
 		childJSON, err := child.ToJSON()
 		if err != nil {
 			return "", err
 		}
-		var childMap map[string]interface{}
-		if err := json.Unmarshal([]byte(childJSON), &childMap); err != nil {
-			return "", err
-		}
-		children = append(children, childMap)
+		children = append(children, json.RawMessage(childJSON))
 	}
 	jsonCont["children"] = children
 
@@ -152,3 +164,100 @@ func (v *ContainerValue) ToJSON() (string, error) {
 	}
 	return string(data), nil
 }
+
+// SerializedSize returns the number of bytes ToBytes() produces,
+// computed from the children without serializing them
+func (v *ContainerValue) SerializedSize() int {
+	// type(1) + name_len(4) + name + value_size(4) + child_count(4) + children
+	size := 1 + 4 + len(v.Name()) + 4 + 4
+	for _, child := range v.children {
+		size += child.SerializedSize()
+	}
+	return size
+}
+
+// ToBytes serializes ContainerValue to binary format
+//
+// Binary format (little-endian):
+// [type:1=14][name_len:4 LE][name:UTF-8][value_size:4 LE][child_count:4 LE][child1_bytes][child2_bytes]...
+func (v *ContainerValue) ToBytes() ([]byte, error) {
+	return v.AppendFrame(make([]byte, 0, v.SerializedSize()))
+}
+
+// AppendFrame appends the ToBytes frame to dst, serializing the children in
+// place instead of building an intermediate slice per child
+func (v *ContainerValue) AppendFrame(dst []byte) ([]byte, error) {
+	// value_size = child_count(4) + all child bytes
+	valueSize := 4
+	for _, child := range v.children {
+		valueSize += child.SerializedSize()
+	}
+
+	dst = append(dst, byte(core.ContainerValue))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(v.Name())))
+	dst = append(dst, v.Name()...)
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(valueSize))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(v.children)))
+
+	var err error
+	for _, child := range v.children {
+		if dst, err = core.AppendValueFrame(dst, child); err != nil {
+			return dst, fmt.Errorf("Failed to serialize child: %w", err)
+		}
+	}
+	return dst, nil
+}
+
+// DeserializeContainerValue deserializes binary data into ContainerValue
+//
+// This function reads the binary format produced by C++, Rust, or Go ContainerValue.ToBytes()
+// and reconstructs the ContainerValue with all its nested children.
+//
+// Binary format:
+// [type:1=14][name_len:4 LE][name:UTF-8][value_size:4 LE][child_count:4 LE][child1][child2]...
+func DeserializeContainerValue(data []byte) (*ContainerValue, error) {
+	if len(data) < 13 { // type(1) + name_len(4) + value_size(4) + child_count(4)
+		return nil, fmt.Errorf("ContainerValue binary data too short: %d bytes: %w", len(data), core.ErrTruncatedData)
+	}
+
+	offset := 0
+
+	// Read type (1 byte)
+	typeID := core.ValueType(data[offset])
+	offset++
+
+	if typeID != core.ContainerValue {
+		return nil, fmt.Errorf("Expected ContainerValue type (14), got %d", typeID)
+	}
+
+	// Read name length (4 bytes, little-endian)
+	nameLen := uint32(data[offset]) |
+		(uint32(data[offset+1]) << 8) |
+		(uint32(data[offset+2]) << 16) |
+		(uint32(data[offset+3]) << 24)
+	offset += 4
+
+	// Read name
+	if uint64(offset)+uint64(nameLen) > uint64(len(data)) {
+		return nil, fmt.Errorf("Name length %d exceeds data bounds: %w", nameLen, core.ErrTruncatedData)
+	}
+	name := string(data[offset : offset+int(nameLen)])
+	offset += int(nameLen)
+
+	// Read value size (4 bytes, little-endian)
+	if offset+4 > len(data) {
+		return nil, fmt.Errorf("Insufficient data for value_size: %w", core.ErrTruncatedData)
+	}
+	valueSize := uint32(data[offset]) |
+		(uint32(data[offset+1]) << 8) |
+		(uint32(data[offset+2]) << 16) |
+		(uint32(data[offset+3]) << 24)
+	offset += 4
+
+	if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+		return nil, fmt.Errorf("Value size %d exceeds data bounds: %w", valueSize, core.ErrTruncatedData)
+	}
+
+	// Children are decoded through the shared value factory
+	return deserializeContainerData(name, data[offset:offset+int(valueSize)])
+}
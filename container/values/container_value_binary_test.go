@@ -0,0 +1,155 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestContainerValueBinary_Empty(t *testing.T) {
+	container := NewContainerValue("empty")
+
+	binaryData, err := container.ToBytes()
+	if err != nil {
+		t.Fatalf("Binary serialization failed: %v", err)
+	}
+
+	// type(1) + name_len(4) + name(5) + value_size(4) + child_count(4)
+	if len(binaryData) != 18 {
+		t.Errorf("Expected 18 bytes, got %d", len(binaryData))
+	}
+	if binaryData[0] != byte(core.ContainerValue) {
+		t.Errorf("Expected type byte 14, got %d", binaryData[0])
+	}
+
+	restored, err := DeserializeContainerValue(binaryData)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+	if restored.Name() != "empty" {
+		t.Errorf("Expected name 'empty', got '%s'", restored.Name())
+	}
+	if restored.ChildCount() != 0 {
+		t.Errorf("Expected 0 children, got %d", restored.ChildCount())
+	}
+}
+
+func TestContainerValueBinary_Primitives(t *testing.T) {
+	container := NewContainerValue("user",
+		NewStringValue("name", "Alice"),
+		NewInt32Value("age", 30),
+		NewBoolValue("active", true),
+		NewFloat64Value("score", 98.5),
+		NewBytesValue("avatar", []byte{0xDE, 0xAD, 0xBE, 0xEF}),
+	)
+
+	binaryData, err := container.ToBytes()
+	if err != nil {
+		t.Fatalf("Binary serialization failed: %v", err)
+	}
+
+	restored, err := DeserializeContainerValue(binaryData)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+
+	if restored.Name() != "user" {
+		t.Errorf("Expected name 'user', got '%s'", restored.Name())
+	}
+	if restored.ChildCount() != 5 {
+		t.Fatalf("Expected 5 children, got %d", restored.ChildCount())
+	}
+
+	name, _ := restored.GetChild("name", 0).ToString()
+	if name != "Alice" {
+		t.Errorf("Expected name 'Alice', got '%s'", name)
+	}
+	age, _ := restored.GetChild("age", 0).ToInt32()
+	if age != 30 {
+		t.Errorf("Expected age 30, got %d", age)
+	}
+	active, _ := restored.GetChild("active", 0).ToBool()
+	if !active {
+		t.Error("Expected active to be true")
+	}
+	score, _ := restored.GetChild("score", 0).ToFloat64()
+	if score != 98.5 {
+		t.Errorf("Expected score 98.5, got %f", score)
+	}
+	avatar, ok := restored.GetChild("avatar", 0).(*BytesValue)
+	if !ok {
+		t.Fatal("Expected avatar to be a BytesValue")
+	}
+	if !bytes.Equal(avatar.Value(), []byte{0xDE, 0xAD, 0xBE, 0xEF}) {
+		t.Errorf("Avatar bytes mismatch: %v", avatar.Value())
+	}
+
+	// Re-serialization must be byte-identical
+	again, err := restored.ToBytes()
+	if err != nil {
+		t.Fatalf("Re-serialization failed: %v", err)
+	}
+	if !bytes.Equal(binaryData, again) {
+		t.Error("Re-serialized bytes differ from original")
+	}
+}
+
+func TestContainerValueBinary_NestedContainer(t *testing.T) {
+	inner := NewContainerValue("address",
+		NewStringValue("city", "Seoul"),
+		NewArrayValue("zip", NewInt32Value("", 12), NewInt32Value("", 345)),
+	)
+	outer := NewContainerValue("profile",
+		NewStringValue("name", "Bob"),
+		inner,
+	)
+
+	binaryData, err := outer.ToBytes()
+	if err != nil {
+		t.Fatalf("Binary serialization failed: %v", err)
+	}
+
+	restored, err := DeserializeContainerValue(binaryData)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+	if restored.ChildCount() != 2 {
+		t.Fatalf("Expected 2 children, got %d", restored.ChildCount())
+	}
+
+	nested, ok := restored.GetChild("address", 0).(*ContainerValue)
+	if !ok {
+		t.Fatal("Expected address to be a ContainerValue")
+	}
+	city, _ := nested.GetChild("city", 0).ToString()
+	if city != "Seoul" {
+		t.Errorf("Expected city 'Seoul', got '%s'", city)
+	}
+
+	zip, ok := nested.GetChild("zip", 0).(*ArrayValue)
+	if !ok {
+		t.Fatal("Expected zip to be an ArrayValue")
+	}
+	if zip.Count() != 2 {
+		t.Fatalf("Expected 2 zip elements, got %d", zip.Count())
+	}
+	second, _ := zip.At(1)
+	if val, _ := second.ToInt32(); val != 345 {
+		t.Errorf("Expected second zip element 345, got %d", val)
+	}
+}
+
+func TestContainerValueBinary_WrongType(t *testing.T) {
+	data, _ := NewArrayValue("arr").ToBytes()
+	if _, err := DeserializeContainerValue(data); err == nil {
+		t.Error("Expected error when deserializing ArrayValue bytes as ContainerValue")
+	}
+}
@@ -0,0 +1,152 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// DateTimeValue represents a point in time (type 16).
+//
+// The instant is stored as nanoseconds since the Unix epoch (8 bytes,
+// little-endian), which covers the years 1678 through 2262. The time zone
+// is kept in memory for formatting but is not part of the binary form, so
+// a deserialized value always reports UTC.
+type DateTimeValue struct {
+	*core.BaseValue
+	value time.Time
+}
+
+// NewDateTimeValue creates a new datetime value
+func NewDateTimeValue(name string, t time.Time) *DateTimeValue {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, uint64(t.UnixNano()))
+	return &DateTimeValue{
+		BaseValue: core.NewBaseValue(name, core.DateTimeValue, data),
+		value:     t,
+	}
+}
+
+// ToTime returns the underlying time
+func (v *DateTimeValue) ToTime() (time.Time, error) {
+	return v.value, nil
+}
+
+// ToInt64 returns nanoseconds since the Unix epoch
+func (v *DateTimeValue) ToInt64() (int64, error) {
+	return v.value.UnixNano(), nil
+}
+
+// ToString returns the time formatted as RFC3339 with nanosecond precision
+func (v *DateTimeValue) ToString() (string, error) {
+	return v.value.Format(time.RFC3339Nano), nil
+}
+
+// Value returns the underlying time
+func (v *DateTimeValue) Value() time.Time {
+	return v.value
+}
+
+// Clone returns a deep copy of the value
+func (v *DateTimeValue) Clone() core.Value {
+	return NewDateTimeValue(v.Name(), v.value)
+}
+
+// ToBytes implements complete binary format with header
+// Format: [type:1][name_len:4][name][value_size:4][unix_nanos:8]
+func (v *DateTimeValue) ToBytes() ([]byte, error) {
+	name := v.Name()
+	nameBytes := []byte(name)
+	nameLen := uint32(len(nameBytes))
+	valueSize := uint32(8) // int64 nanoseconds = 8 bytes
+
+	// Total: type(1) + name_len(4) + name + value_size(4) + value(8)
+	totalSize := 1 + 4 + len(nameBytes) + 4 + 8
+	result := make([]byte, 0, totalSize)
+
+	// Type (1 byte)
+	result = append(result, byte(core.DateTimeValue))
+
+	// Name length (4 bytes, little-endian)
+	result = append(result,
+		byte(nameLen&0xFF),
+		byte((nameLen>>8)&0xFF),
+		byte((nameLen>>16)&0xFF),
+		byte((nameLen>>24)&0xFF),
+	)
+
+	// Name
+	result = append(result, nameBytes...)
+
+	// Value size (4 bytes, little-endian)
+	result = append(result,
+		byte(valueSize&0xFF),
+		byte((valueSize>>8)&0xFF),
+		byte((valueSize>>16)&0xFF),
+		byte((valueSize>>24)&0xFF),
+	)
+
+	// Value (8 bytes, little-endian)
+	nanos := v.value.UnixNano()
+	result = append(result,
+		byte(nanos&0xFF),
+		byte((nanos>>8)&0xFF),
+		byte((nanos>>16)&0xFF),
+		byte((nanos>>24)&0xFF),
+		byte((nanos>>32)&0xFF),
+		byte((nanos>>40)&0xFF),
+		byte((nanos>>48)&0xFF),
+		byte((nanos>>56)&0xFF),
+	)
+
+	return result, nil
+}
+
+// ToJSON returns the JSON representation with the time as RFC3339
+func (v *DateTimeValue) ToJSON() (string, error) {
+	str, _ := v.ToString()
+	jsonVal := map[string]interface{}{
+		"name": v.Name(),
+		"type": v.Type().TypeName(),
+		"data": str,
+	}
+
+	data, err := json.MarshalIndent(jsonVal, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ToXML returns the XML representation with the time as RFC3339
+func (v *DateTimeValue) ToXML() (string, error) {
+	type XMLDateTimeValue struct {
+		XMLName xml.Name `xml:"value"`
+		Name    string   `xml:"name,attr"`
+		Type    string   `xml:"type,attr"`
+		Data    string   `xml:",chardata"`
+	}
+
+	str, _ := v.ToString()
+	xmlVal := XMLDateTimeValue{
+		Name: v.Name(),
+		Type: v.Type().TypeName(),
+		Data: str,
+	}
+
+	data, err := xml.MarshalIndent(xmlVal, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,148 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestDateTimeValue_TypeMetadata(t *testing.T) {
+	if core.DateTimeValue != 16 {
+		t.Errorf("Expected DateTimeValue type code 16, got %d", core.DateTimeValue)
+	}
+	if core.DateTimeValue.String() != "16" {
+		t.Errorf("Expected String() '16', got '%s'", core.DateTimeValue.String())
+	}
+	if core.ParseValueType("16") != core.DateTimeValue {
+		t.Errorf("ParseValueType(\"16\") returned %v", core.ParseValueType("16"))
+	}
+	if core.DateTimeValue.TypeName() != "datetime" {
+		t.Errorf("Expected TypeName 'datetime', got '%s'", core.DateTimeValue.TypeName())
+	}
+}
+
+func TestDateTimeValue_BinaryRoundTripUTC(t *testing.T) {
+	ts := time.Date(2025, 3, 14, 15, 9, 26, 535897932, time.UTC)
+	dv := NewDateTimeValue("created_at", ts)
+
+	data, err := dv.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	// type(1) + name_len(4) + name(10) + value_size(4) + value(8)
+	if len(data) != 27 {
+		t.Errorf("Expected 27 bytes, got %d", len(data))
+	}
+
+	restored, bytesRead, err := deserializeValue(data)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+	if bytesRead != len(data) {
+		t.Errorf("Expected %d bytes read, got %d", len(data), bytesRead)
+	}
+
+	restoredDT, ok := restored.(*DateTimeValue)
+	if !ok {
+		t.Fatalf("Expected *DateTimeValue, got %T", restored)
+	}
+	if restoredDT.Name() != "created_at" {
+		t.Errorf("Expected name 'created_at', got '%s'", restoredDT.Name())
+	}
+	got, _ := restoredDT.ToTime()
+	if !got.Equal(ts) {
+		t.Errorf("Expected %v, got %v", ts, got)
+	}
+}
+
+func TestDateTimeValue_BinaryRoundTripNonUTC(t *testing.T) {
+	zone := time.FixedZone("KST", 9*60*60)
+	ts := time.Date(2024, 12, 31, 23, 59, 59, 123456789, zone)
+	dv := NewDateTimeValue("deadline", ts)
+
+	str, _ := dv.ToString()
+	if str != "2024-12-31T23:59:59.123456789+09:00" {
+		t.Errorf("Unexpected RFC3339 string: %s", str)
+	}
+
+	data, err := dv.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+
+	restored, _, err := deserializeValue(data)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+	got, _ := restored.(*DateTimeValue).ToTime()
+
+	// The instant survives; the zone is normalized to UTC
+	if !got.Equal(ts) {
+		t.Errorf("Expected instant %v, got %v", ts, got)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("Expected UTC location after round-trip, got %v", got.Location())
+	}
+}
+
+func TestDateTimeValue_JSONAndXML(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	dv := NewDateTimeValue("ts", ts)
+
+	jsonStr, err := dv.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if parsed["type"] != "datetime" {
+		t.Errorf("Expected type 'datetime', got %v", parsed["type"])
+	}
+	if parsed["data"] != "2025-01-02T03:04:05Z" {
+		t.Errorf("Expected RFC3339 data, got %v", parsed["data"])
+	}
+
+	xmlStr, err := dv.ToXML()
+	if err != nil {
+		t.Fatalf("ToXML failed: %v", err)
+	}
+	if !strings.Contains(xmlStr, `type="datetime"`) || !strings.Contains(xmlStr, "2025-01-02T03:04:05Z") {
+		t.Errorf("Unexpected XML: %s", xmlStr)
+	}
+}
+
+func TestDateTimeValue_InsideArray(t *testing.T) {
+	ts := time.Unix(1700000000, 42).UTC()
+	array := NewArrayValue("events", NewDateTimeValue("", ts), NewInt32Value("", 1))
+
+	data, err := array.ToBinaryBytes()
+	if err != nil {
+		t.Fatalf("ToBinaryBytes failed: %v", err)
+	}
+	restored, err := DeserializeArrayValue(data)
+	if err != nil {
+		t.Fatalf("DeserializeArrayValue failed: %v", err)
+	}
+
+	first, _ := restored.At(0)
+	got, _ := first.(*DateTimeValue).ToTime()
+	if !got.Equal(ts) {
+		t.Errorf("Expected %v, got %v", ts, got)
+	}
+	second, _ := restored.At(1)
+	if val, _ := second.ToInt32(); val != 1 {
+		t.Errorf("Expected 1 after datetime element, got %d", val)
+	}
+}
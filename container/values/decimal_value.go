@@ -0,0 +1,138 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/xml"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// DecimalValue represents an exact fixed-point decimal (type 18), the value
+// unscaled × 10^-scale.
+//
+// The binary payload is [scale:4 LE][len:4 LE][unscaled], where unscaled is
+// the minimal big-endian two's-complement encoding of the unscaled integer
+// (empty for zero).
+type DecimalValue struct {
+	*core.BaseValue
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimalValue creates a new decimal value equal to unscaled × 10^-scale.
+// A nil unscaled is treated as zero; the big.Int is copied.
+func NewDecimalValue(name string, unscaled *big.Int, scale int32) *DecimalValue {
+	u := new(big.Int)
+	if unscaled != nil {
+		u.Set(unscaled)
+	}
+	return &DecimalValue{
+		BaseValue: core.NewBaseValue(name, core.DecimalValue, core.EncodeDecimal(u, scale)),
+		unscaled:  u,
+		scale:     scale,
+	}
+}
+
+// NewDecimalValueFromString parses a decimal such as "123.45", "-0.001" or
+// "+7". The scale is the number of digits after the decimal point, so
+// trailing zeros are preserved ("1.50" has scale 2).
+func NewDecimalValueFromString(name string, s string) (*DecimalValue, error) {
+	unscaled, scale, err := core.ParseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimalValue(name, unscaled, scale), nil
+}
+
+// Unscaled returns a copy of the unscaled integer
+func (v *DecimalValue) Unscaled() *big.Int {
+	return new(big.Int).Set(v.unscaled)
+}
+
+// Scale returns the number of decimal digits after the point
+func (v *DecimalValue) Scale() int32 {
+	return v.scale
+}
+
+// ToString returns the exact decimal in plain notation, e.g. "-123.450".
+// A scale whose magnitude exceeds core.MaxDecimalScale is rendered as
+// unscaled E exponent ("-12E-5000") rather than padded with zeros.
+func (v *DecimalValue) ToString() (string, error) {
+	digits := new(big.Int).Abs(v.unscaled).String()
+
+	var sb strings.Builder
+	if v.unscaled.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	switch {
+	case v.scale > core.MaxDecimalScale || v.scale < -core.MaxDecimalScale:
+		sb.WriteString(digits)
+		sb.WriteByte('E')
+		sb.WriteString(strconv.FormatInt(-int64(v.scale), 10))
+	case v.scale <= 0:
+		sb.WriteString(digits)
+		if v.unscaled.Sign() != 0 {
+			sb.WriteString(strings.Repeat("0", int(-int64(v.scale))))
+		}
+	case len(digits) > int(v.scale):
+		point := len(digits) - int(v.scale)
+		sb.WriteString(digits[:point])
+		sb.WriteByte('.')
+		sb.WriteString(digits[point:])
+	default:
+		sb.WriteString("0.")
+		sb.WriteString(strings.Repeat("0", int(v.scale)-len(digits)))
+		sb.WriteString(digits)
+	}
+	return sb.String(), nil
+}
+
+// ToBytes implements complete binary format with header
+// Format: [type:1][name_len:4][name][value_size:4][scale:4][len:4][unscaled]
+func (v *DecimalValue) ToBytes() ([]byte, error) {
+	return core.FrameBytes(v)
+}
+
+// Clone returns a deep copy of the value
+func (v *DecimalValue) Clone() core.Value {
+	return NewDecimalValue(v.Name(), v.unscaled, v.scale)
+}
+
+// ToJSON returns the JSON representation with the decimal as a numeric
+// string, so no precision is lost to float64 rounding
+func (v *DecimalValue) ToJSON() (string, error) {
+	str, _ := v.ToString()
+	return nativeJSON(v, str)
+}
+
+// ToXML returns the XML representation with the decimal in plain notation
+func (v *DecimalValue) ToXML() (string, error) {
+	type XMLDecimalValue struct {
+		XMLName xml.Name `xml:"value"`
+		Name    string   `xml:"name,attr"`
+		Type    string   `xml:"type,attr"`
+		Data    string   `xml:",chardata"`
+	}
+
+	str, _ := v.ToString()
+	xmlVal := XMLDecimalValue{
+		Name: v.Name(),
+		Type: v.Type().TypeName(),
+		Data: str,
+	}
+
+	data, err := xml.MarshalIndent(xmlVal, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,217 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestDecimalValue_TypeMetadata(t *testing.T) {
+	if core.DecimalValue != 18 {
+		t.Errorf("Expected DecimalValue type code 18, got %d", core.DecimalValue)
+	}
+	if core.ParseValueType("18") != core.DecimalValue {
+		t.Errorf("ParseValueType(\"18\") returned %v", core.ParseValueType("18"))
+	}
+	if core.DecimalValue.TypeName() != "decimal" {
+		t.Errorf("Expected TypeName 'decimal', got '%s'", core.DecimalValue.TypeName())
+	}
+	if !core.DecimalValue.IsDefined() {
+		t.Error("DecimalValue should be a defined type")
+	}
+}
+
+func TestDecimalValue_ParseAndFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		unscaled string
+		scale    int32
+		output   string
+	}{
+		{"0.1", "1", 1, "0.1"},
+		{"123456789.123456789", "123456789123456789", 9, "123456789.123456789"},
+		{"-42.5", "-425", 1, "-42.5"},
+		{"-0.001", "-1", 3, "-0.001"},
+		{"1.50", "150", 2, "1.50"},
+		{"+7", "7", 0, "7"},
+		{".5", "5", 1, "0.5"},
+		{"0", "0", 0, "0"},
+		{"98765432109876543210.0123456789", "987654321098765432100123456789", 10, "98765432109876543210.0123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			dv, err := NewDecimalValueFromString("amount", tt.input)
+			if err != nil {
+				t.Fatalf("NewDecimalValueFromString failed: %v", err)
+			}
+			if dv.Unscaled().String() != tt.unscaled || dv.Scale() != tt.scale {
+				t.Errorf("Expected %s scale %d, got %s scale %d", tt.unscaled, tt.scale, dv.Unscaled(), dv.Scale())
+			}
+			if str, _ := dv.ToString(); str != tt.output {
+				t.Errorf("Expected %s, got %s", tt.output, str)
+			}
+		})
+	}
+}
+
+func TestDecimalValue_ParseErrors(t *testing.T) {
+	for _, input := range []string{"", "-", ".", "1.2.3", "1e5", "abc", " 1"} {
+		if _, err := NewDecimalValueFromString("amount", input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestDecimalValue_NegativeScale(t *testing.T) {
+	dv := NewDecimalValue("amount", big.NewInt(-12), -3)
+	if str, _ := dv.ToString(); str != "-12000" {
+		t.Errorf("Expected -12000, got %s", str)
+	}
+}
+
+func TestDecimalValue_BinaryPayload(t *testing.T) {
+	tests := []struct {
+		input   string
+		payload []byte
+	}{
+		{"0", []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{"1.27", []byte{2, 0, 0, 0, 1, 0, 0, 0, 0x7F}},
+		{"1.28", []byte{2, 0, 0, 0, 2, 0, 0, 0, 0x00, 0x80}},
+		{"-1.28", []byte{2, 0, 0, 0, 1, 0, 0, 0, 0x80}},
+		{"-1.29", []byte{2, 0, 0, 0, 2, 0, 0, 0, 0xFF, 0x7F}},
+		{"-0.1", []byte{1, 0, 0, 0, 1, 0, 0, 0, 0xFF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			dv, err := NewDecimalValueFromString("d", tt.input)
+			if err != nil {
+				t.Fatalf("NewDecimalValueFromString failed: %v", err)
+			}
+			if !bytes.Equal(dv.Data(), tt.payload) {
+				t.Errorf("Expected payload %x, got %x", tt.payload, dv.Data())
+			}
+
+			rebuilt, err := NewValueFromData("d", core.DecimalValue, dv.Data())
+			if err != nil {
+				t.Fatalf("NewValueFromData failed: %v", err)
+			}
+			if str, _ := rebuilt.ToString(); str != tt.input {
+				t.Errorf("Expected %s after round trip, got %s", tt.input, str)
+			}
+		})
+	}
+}
+
+func TestDecimalValue_BinaryRoundTrip(t *testing.T) {
+	dv, _ := NewDecimalValueFromString("price", "-123456789.123456789")
+
+	data, err := dv.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	if len(data) != dv.SerializedSize() {
+		t.Errorf("SerializedSize %d does not match ToBytes length %d", dv.SerializedSize(), len(data))
+	}
+
+	value, consumed, err := deserializeValue(data)
+	if err != nil {
+		t.Fatalf("deserializeValue failed: %v", err)
+	}
+	if consumed != len(data) {
+		t.Errorf("Expected %d bytes consumed, got %d", len(data), consumed)
+	}
+	decoded, ok := value.(*DecimalValue)
+	if !ok {
+		t.Fatalf("Expected *DecimalValue, got %T", value)
+	}
+	if str, _ := decoded.ToString(); str != "-123456789.123456789" || decoded.Name() != "price" {
+		t.Errorf("Round trip mismatch: %s named %s", str, decoded.Name())
+	}
+}
+
+func TestDecimalValue_InvalidPayload(t *testing.T) {
+	for _, payload := range [][]byte{
+		{1, 0, 0},                   // shorter than the header
+		{1, 0, 0, 0, 2, 0, 0, 0, 1}, // length field exceeds data
+		{1, 0, 0, 0, 0, 0, 0, 0, 1}, // trailing bytes
+	} {
+		if _, err := NewValueFromData("d", core.DecimalValue, payload); err == nil {
+			t.Errorf("Expected error for payload %x", payload)
+		}
+	}
+}
+
+func TestDecimalValue_RejectsHugeScale(t *testing.T) {
+	for _, payload := range [][]byte{
+		{0, 0, 0, 0x40, 1, 0, 0, 0, 1},    // scale 2^30
+		{0, 0, 0, 0xC0, 1, 0, 0, 0, 1},    // scale -2^30
+		{0x01, 0x10, 0, 0, 1, 0, 0, 0, 1}, // scale MaxDecimalScale+1
+	} {
+		if _, err := NewValueFromData("d", core.DecimalValue, payload); err == nil {
+			t.Errorf("Expected error for payload %x", payload)
+		}
+	}
+
+	if _, err := NewDecimalValueFromString("d", "0."+strings.Repeat("1", core.MaxDecimalScale+1)); err == nil {
+		t.Error("Expected error for a fraction longer than MaxDecimalScale")
+	}
+
+	// Constructed directly, an out-of-range scale renders in E notation
+	// instead of allocating |scale| zeros
+	dv := NewDecimalValue("d", big.NewInt(-12), 1<<30)
+	if str, _ := dv.ToString(); str != "-12E-1073741824" {
+		t.Errorf("Expected -12E-1073741824, got %s", str)
+	}
+	dv = NewDecimalValue("d", big.NewInt(5), -(1 << 30))
+	if str, _ := dv.ToString(); str != "5E1073741824" {
+		t.Errorf("Expected 5E1073741824, got %s", str)
+	}
+}
+
+func TestDecimalValue_JSONIsNumericString(t *testing.T) {
+	dv, _ := NewDecimalValueFromString("price", "0.1")
+	jsonStr, err := dv.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if parsed["type"] != "decimal" || parsed["data"] != "0.1" {
+		t.Errorf("Expected decimal data \"0.1\", got %v", parsed)
+	}
+
+	xmlStr, err := dv.ToXML()
+	if err != nil {
+		t.Fatalf("ToXML failed: %v", err)
+	}
+	if !strings.Contains(xmlStr, `type="decimal"`) || !strings.Contains(xmlStr, ">0.1<") {
+		t.Errorf("Unexpected XML: %s", xmlStr)
+	}
+}
+
+func TestDecimalValue_CloneIsIndependent(t *testing.T) {
+	unscaled := big.NewInt(500)
+	dv := NewDecimalValue("amount", unscaled, 2)
+	unscaled.SetInt64(1)
+	dv.Unscaled().SetInt64(2)
+
+	clone := dv.Clone().(*DecimalValue)
+	if str, _ := clone.ToString(); str != "5.00" {
+		t.Errorf("Expected 5.00, got %s", str)
+	}
+}
@@ -0,0 +1,207 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func init() {
+	core.RegisterValueFactory(NewValueFromData)
+}
+
+// NewValueFromData creates a typed value from its name, type and raw payload.
+//
+// For primitive types the payload is the value's Data() (little-endian for
+// numerics). For ContainerValue and ArrayValue it is the body of the binary
+// frame: [count:4 LE][child1][child2]..., and for MapValue its Data():
+// [count:4 LE][key_len:4 LE][key][value]...
+//
+// This is the shared (name, type, rawData) factory registered with core.
+func NewValueFromData(name string, vtype core.ValueType, data []byte) (core.Value, error) {
+	switch vtype {
+	case core.NullValue:
+		return NewNullValue(name), nil
+
+	case core.BoolValue:
+		if len(data) != 1 {
+			return nil, payloadSizeError(vtype, 1, len(data))
+		}
+		return NewBoolValue(name, data[0] != 0), nil
+
+	case core.ShortValue:
+		if len(data) != 2 {
+			return nil, payloadSizeError(vtype, 2, len(data))
+		}
+		return NewInt16Value(name, int16(binary.LittleEndian.Uint16(data))), nil
+
+	case core.UShortValue:
+		if len(data) != 2 {
+			return nil, payloadSizeError(vtype, 2, len(data))
+		}
+		return NewUInt16Value(name, binary.LittleEndian.Uint16(data)), nil
+
+	case core.IntValue:
+		if len(data) != 4 {
+			return nil, payloadSizeError(vtype, 4, len(data))
+		}
+		return NewInt32Value(name, int32(binary.LittleEndian.Uint32(data))), nil
+
+	case core.UIntValue:
+		if len(data) != 4 {
+			return nil, payloadSizeError(vtype, 4, len(data))
+		}
+		return NewUInt32Value(name, binary.LittleEndian.Uint32(data)), nil
+
+	case core.LongValue:
+		// Writers where long is 64-bit send 8 bytes; the range check in
+		// NewLongValue rejects anything outside int32.
+		switch len(data) {
+		case 4:
+			return NewLongValue(name, int64(int32(binary.LittleEndian.Uint32(data))))
+		case 8:
+			return NewLongValue(name, int64(binary.LittleEndian.Uint64(data)))
+		default:
+			return nil, payloadSizeError(vtype, 4, len(data))
+		}
+
+	case core.ULongValue:
+		switch len(data) {
+		case 4:
+			return NewULongValue(name, uint64(binary.LittleEndian.Uint32(data)))
+		case 8:
+			return NewULongValue(name, binary.LittleEndian.Uint64(data))
+		default:
+			return nil, payloadSizeError(vtype, 4, len(data))
+		}
+
+	case core.LLongValue:
+		if len(data) != 8 {
+			return nil, payloadSizeError(vtype, 8, len(data))
+		}
+		return NewInt64Value(name, int64(binary.LittleEndian.Uint64(data))), nil
+
+	case core.ULLongValue:
+		if len(data) != 8 {
+			return nil, payloadSizeError(vtype, 8, len(data))
+		}
+		return NewUInt64Value(name, binary.LittleEndian.Uint64(data)), nil
+
+	case core.FloatValue:
+		if len(data) != 4 {
+			return nil, payloadSizeError(vtype, 4, len(data))
+		}
+		return NewFloat32Value(name, math.Float32frombits(binary.LittleEndian.Uint32(data))), nil
+
+	case core.DoubleValue:
+		if len(data) != 8 {
+			return nil, payloadSizeError(vtype, 8, len(data))
+		}
+		return NewFloat64Value(name, math.Float64frombits(binary.LittleEndian.Uint64(data))), nil
+
+	case core.StringValue:
+		return NewStringValue(name, string(data)), nil
+
+	case core.BytesValue:
+		return NewBytesValue(name, data), nil
+
+	case core.DateTimeValue:
+		if len(data) != 8 {
+			return nil, payloadSizeError(vtype, 8, len(data))
+		}
+		return NewDateTimeValue(name, time.Unix(0, int64(binary.LittleEndian.Uint64(data))).UTC()), nil
+
+	case core.UUIDValue:
+		if len(data) != 16 {
+			return nil, payloadSizeError(vtype, 16, len(data))
+		}
+		var id [16]byte
+		copy(id[:], data)
+		return NewUUIDValue(name, id), nil
+
+	case core.DecimalValue:
+		unscaled, scale, err := core.DecodeDecimal(data)
+		if err != nil {
+			return nil, err
+		}
+		return NewDecimalValue(name, unscaled, scale), nil
+
+	case core.ContainerValue:
+		return deserializeContainerData(name, data)
+
+	case core.ArrayValue:
+		return deserializeArrayData(name, data)
+
+	case core.MapValue:
+		return deserializeMapData(name, data)
+
+	default:
+		return core.NewUnknownValue(name, vtype, data)
+	}
+}
+
+// ZeroValue creates a zero-initialized value of the given type: 0 for
+// numerics, false, "", empty bytes, an empty container, array or map, the
+// Unix epoch for DateTimeValue (a zero nanosecond payload), the nil UUID and
+// a zero decimal with scale 0.
+func ZeroValue(name string, vtype core.ValueType) (core.Value, error) {
+	switch vtype {
+	case core.NullValue:
+		return NewNullValue(name), nil
+	case core.BoolValue:
+		return NewBoolValue(name, false), nil
+	case core.ShortValue:
+		return NewInt16Value(name, 0), nil
+	case core.UShortValue:
+		return NewUInt16Value(name, 0), nil
+	case core.IntValue:
+		return NewInt32Value(name, 0), nil
+	case core.UIntValue:
+		return NewUInt32Value(name, 0), nil
+	case core.LongValue:
+		return NewLongValue(name, 0)
+	case core.ULongValue:
+		return NewULongValue(name, 0)
+	case core.LLongValue:
+		return NewInt64Value(name, 0), nil
+	case core.ULLongValue:
+		return NewUInt64Value(name, 0), nil
+	case core.FloatValue:
+		return NewFloat32Value(name, 0), nil
+	case core.DoubleValue:
+		return NewFloat64Value(name, 0), nil
+	case core.StringValue:
+		return NewStringValue(name, ""), nil
+	case core.BytesValue:
+		return NewBytesValue(name, []byte{}), nil
+	case core.DateTimeValue:
+		return NewDateTimeValue(name, time.Unix(0, 0).UTC()), nil
+	case core.UUIDValue:
+		return NewUUIDValue(name, [16]byte{}), nil
+	case core.DecimalValue:
+		return NewDecimalValue(name, nil, 0), nil
+	case core.ContainerValue:
+		return NewContainerValue(name), nil
+	case core.ArrayValue:
+		return NewArrayValue(name), nil
+	case core.MapValue:
+		return NewMapValue(name), nil
+	default:
+		return nil, fmt.Errorf("Unsupported value type for zero value: %d", vtype)
+	}
+}
+
+// payloadSizeError reports a raw payload whose length does not match its type
+func payloadSizeError(vtype core.ValueType, expected, actual int) error {
+	return fmt.Errorf("invalid payload size for %s: expected %d bytes, got %d", vtype.TypeName(), expected, actual)
+}
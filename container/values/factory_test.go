@@ -0,0 +1,241 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestZeroValue_AllTypes(t *testing.T) {
+	for vtype := core.NullValue; vtype <= core.MapValue; vtype++ {
+		t.Run(vtype.TypeName(), func(t *testing.T) {
+			value, err := ZeroValue("field", vtype)
+			if err != nil {
+				t.Fatalf("ZeroValue failed: %v", err)
+			}
+			if value.Type() != vtype || value.Name() != "field" {
+				t.Fatalf("Expected %s named field, got %s named %s", vtype.TypeName(), value.Type().TypeName(), value.Name())
+			}
+
+			// Every zero value has an all-zero payload (empty for variable-length types)
+			payload, err := core.RawPayload(value)
+			if err != nil {
+				t.Fatalf("RawPayload failed: %v", err)
+			}
+			for i, b := range payload {
+				if b != 0 {
+					t.Fatalf("Payload byte %d is %#x, expected zero", i, b)
+				}
+			}
+
+			switch {
+			case vtype.IsFloat():
+				if f, err := value.ToFloat64(); err != nil || f != 0 {
+					t.Errorf("Expected numeric zero, got %v (%v)", f, err)
+				}
+			case vtype.IsInteger() && vtype.IsSigned():
+				if n, err := value.ToInt64(); err != nil || n != 0 {
+					t.Errorf("Expected numeric zero, got %v (%v)", n, err)
+				}
+			case vtype.IsInteger():
+				if n, err := value.ToUInt64(); err != nil || n != 0 {
+					t.Errorf("Expected numeric zero, got %v (%v)", n, err)
+				}
+			case vtype == core.BoolValue:
+				if b, _ := value.ToBool(); b {
+					t.Error("Expected false")
+				}
+			case vtype == core.StringValue, vtype == core.BytesValue:
+				if value.Size() != 0 {
+					t.Errorf("Expected empty value, got %d bytes", value.Size())
+				}
+			case vtype == core.ContainerValue:
+				if value.ChildCount() != 0 {
+					t.Errorf("Expected empty container, got %d children", value.ChildCount())
+				}
+			case vtype == core.ArrayValue:
+				if !value.(*ArrayValue).IsEmpty() {
+					t.Error("Expected empty array")
+				}
+			case vtype == core.DateTimeValue:
+				if ts, _ := value.ToInt64(); ts != 0 {
+					t.Errorf("Expected Unix epoch, got %d", ts)
+				}
+			case vtype == core.UUIDValue:
+				if s, _ := value.ToString(); s != "00000000-0000-0000-0000-000000000000" {
+					t.Errorf("Expected nil UUID, got %s", s)
+				}
+			case vtype == core.DecimalValue:
+				if s, _ := value.ToString(); s != "0" {
+					t.Errorf("Expected decimal zero, got %s", s)
+				}
+			case vtype == core.MapValue:
+				if value.(*MapValue).Count() != 0 {
+					t.Error("Expected empty map")
+				}
+			}
+
+			// Zero values survive the shared factory unchanged
+			rebuilt, err := NewValueFromData("field", vtype, payload)
+			if err != nil {
+				t.Fatalf("NewValueFromData failed: %v", err)
+			}
+			if rebuilt.Type() != vtype {
+				t.Errorf("Rebuilt type %s, expected %s", rebuilt.Type().TypeName(), vtype.TypeName())
+			}
+		})
+	}
+}
+
+func TestZeroValue_UnknownType(t *testing.T) {
+	if _, err := ZeroValue("field", core.ValueType(200)); err == nil {
+		t.Error("Expected error for unknown type")
+	}
+}
+
+// unknownTypeFrame is a binary value frame with the undefined type code 99
+func unknownTypeFrame() []byte {
+	frame := []byte{99}
+	frame = binary.LittleEndian.AppendUint32(frame, 3)
+	frame = append(frame, "new"...)
+	frame = binary.LittleEndian.AppendUint32(frame, 2)
+	return append(frame, 0xCA, 0xFE)
+}
+
+// unknownTypeArray wraps unknownTypeFrame as the single element of an array
+func unknownTypeArray() []byte {
+	element := unknownTypeFrame()
+	array := []byte{byte(core.ArrayValue), 1, 0, 0, 0, 'a'}
+	array = binary.LittleEndian.AppendUint32(array, uint32(4+len(element)))
+	array = binary.LittleEndian.AppendUint32(array, 1)
+	return append(array, element...)
+}
+
+func TestFactoryMode_StrictRejectsUnknownType(t *testing.T) {
+	if core.CurrentFactoryMode() != core.FactoryStrict {
+		t.Fatal("Strict mode should be the default")
+	}
+
+	if _, err := NewValueFromData("new", 99, []byte{0xCA, 0xFE}); !errors.Is(err, core.ErrUnknownValueType) {
+		t.Errorf("NewValueFromData: expected ErrUnknownValueType, got %v", err)
+	}
+	if _, err := core.NewValueFromData("new", 99, []byte{0xCA, 0xFE}); !errors.Is(err, core.ErrUnknownValueType) {
+		t.Errorf("core.NewValueFromData: expected ErrUnknownValueType, got %v", err)
+	}
+	if _, _, err := deserializeValue(unknownTypeFrame()); !errors.Is(err, core.ErrUnknownValueType) {
+		t.Errorf("deserializeValue: expected ErrUnknownValueType, got %v", err)
+	}
+	if _, err := DeserializeArrayValue(unknownTypeArray()); !errors.Is(err, core.ErrUnknownValueType) {
+		t.Errorf("DeserializeArrayValue: expected ErrUnknownValueType, got %v", err)
+	}
+}
+
+func TestFactoryMode_LenientKeepsUnknownType(t *testing.T) {
+	core.SetFactoryMode(core.FactoryLenient)
+	defer core.SetFactoryMode(core.FactoryStrict)
+
+	checkUnknown := func(label string, value core.Value) {
+		t.Helper()
+		if value.Type() != 99 || value.Name() != "new" || !bytes.Equal(value.Data(), []byte{0xCA, 0xFE}) {
+			t.Errorf("%s: expected opaque type 99 value, got type %d name %q data %x",
+				label, value.Type(), value.Name(), value.Data())
+		}
+	}
+
+	value, err := NewValueFromData("new", 99, []byte{0xCA, 0xFE})
+	if err != nil {
+		t.Fatalf("NewValueFromData failed: %v", err)
+	}
+	checkUnknown("NewValueFromData", value)
+
+	value, err = core.NewValueFromData("new", 99, []byte{0xCA, 0xFE})
+	if err != nil {
+		t.Fatalf("core.NewValueFromData failed: %v", err)
+	}
+	checkUnknown("core.NewValueFromData", value)
+
+	value, consumed, err := deserializeValue(unknownTypeFrame())
+	if err != nil {
+		t.Fatalf("deserializeValue failed: %v", err)
+	}
+	if consumed != len(unknownTypeFrame()) {
+		t.Errorf("Expected %d bytes consumed, got %d", len(unknownTypeFrame()), consumed)
+	}
+	checkUnknown("deserializeValue", value)
+
+	array, err := DeserializeArrayValue(unknownTypeArray())
+	if err != nil {
+		t.Fatalf("DeserializeArrayValue failed: %v", err)
+	}
+	if len(array.Elements()) != 1 {
+		t.Fatalf("Expected 1 element, got %d", len(array.Elements()))
+	}
+	checkUnknown("DeserializeArrayValue", array.Elements()[0])
+
+	roundTrip, err := array.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	if !bytes.Equal(roundTrip, unknownTypeArray()) {
+		t.Errorf("Unknown element did not round-trip:\n got %x\nwant %x", roundTrip, unknownTypeArray())
+	}
+}
+
+// TestFactory_NestedNullRoundTrip checks that null children and elements,
+// a defined type, are decoded in strict mode like every other type
+func TestFactory_NestedNullRoundTrip(t *testing.T) {
+	if core.CurrentFactoryMode() != core.FactoryStrict {
+		t.Fatal("Strict mode should be the default")
+	}
+
+	container := NewContainerValue("outer", NewNullValue("nothing"), NewInt32Value("n", 1))
+	data, err := container.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	restored, err := DeserializeContainerValue(data)
+	if err != nil {
+		t.Fatalf("DeserializeContainerValue failed: %v", err)
+	}
+	children := restored.Children()
+	if len(children) != 2 || children[0].Type() != core.NullValue || children[0].Name() != "nothing" {
+		t.Fatalf("Null child did not round-trip: %v", children)
+	}
+
+	array := NewArrayValue("list", NewNullValue(""), NewBoolValue("", true))
+	data, err = array.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	restoredArray, err := DeserializeArrayValue(data)
+	if err != nil {
+		t.Fatalf("DeserializeArrayValue failed: %v", err)
+	}
+	if elements := restoredArray.Elements(); len(elements) != 2 || elements[0].Type() != core.NullValue {
+		t.Fatalf("Null element did not round-trip: %v", elements)
+	}
+
+	store := core.NewValueStore()
+	store.Add("outer", container)
+	data, err = store.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+	loaded, err := core.LoadValueStore(data)
+	if err != nil {
+		t.Fatalf("LoadValueStore failed: %v", err)
+	}
+	if outer := loaded.Get("outer"); outer == nil || len(outer.Children()) != 2 || outer.Children()[0].Type() != core.NullValue {
+		t.Errorf("Null child did not round-trip through the value store: %v", outer)
+	}
+}
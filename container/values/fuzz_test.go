@@ -0,0 +1,78 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// fuzzSeedFrames are the Rust-generated value frames from
+// tests/binary_interop_test.go
+var fuzzSeedFrames = []string{
+	"04" + "07000000" + "74657374693332" + "04000000" + "2A000000",               // Int32 "testi32" = 42
+	"01" + "04000000" + "626F6F6C" + "01000000" + "01",                           // Bool "bool" = true
+	"0C" + "05000000" + "6D79737472" + "0D000000" + "48656C6C6F2C20576F726C6421", // String "mystr" = "Hello, World!"
+}
+
+// decodeFuzzSeeds returns the seed frames as bytes
+func decodeFuzzSeeds(f *testing.F) [][]byte {
+	frames := make([][]byte, 0, len(fuzzSeedFrames))
+	for _, s := range fuzzSeedFrames {
+		frame, err := hex.DecodeString(s)
+		if err != nil {
+			f.Fatalf("invalid seed %q: %v", s, err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+func FuzzDeserializeValue(f *testing.F) {
+	for _, frame := range decodeFuzzSeeds(f) {
+		f.Add(frame)
+	}
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, n, err := deserializeValue(data)
+		if err != nil {
+			if value != nil || n != 0 {
+				t.Fatalf("error %v returned with value %v and %d bytes", err, value, n)
+			}
+			return
+		}
+		if value == nil {
+			t.Fatal("nil value without an error")
+		}
+		if n <= 0 || n > len(data) {
+			t.Fatalf("consumed %d of %d bytes", n, len(data))
+		}
+	})
+}
+
+func FuzzDeserializeArrayValue(f *testing.F) {
+	// An array holding every seed frame, plus an empty array
+	frames := decodeFuzzSeeds(f)
+	payload := binary.LittleEndian.AppendUint32(nil, uint32(len(frames)))
+	for _, frame := range frames {
+		payload = append(payload, frame...)
+	}
+	array := []byte{0x0F, 5, 0, 0, 0, 'a', 'r', 'r', 'a', 'y'}
+	array = binary.LittleEndian.AppendUint32(array, uint32(len(payload)))
+	f.Add(append(array, payload...))
+	f.Add([]byte{0x0F, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, err := DeserializeArrayValue(data)
+		if (value == nil) == (err == nil) {
+			t.Fatalf("expected either a value or an error, got %v and %v", value, err)
+		}
+	})
+}
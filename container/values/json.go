@@ -0,0 +1,49 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// nativeJSON renders a value as {"name", "type", "data"} with data in its
+// native JSON form, plus any extra fields (e.g. an encoding marker)
+func nativeJSON(v core.Value, data interface{}, extra ...string) (string, error) {
+	jsonVal := map[string]interface{}{
+		"name": v.Name(),
+		"type": v.Type().TypeName(),
+		"data": data,
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		jsonVal[extra[i]] = extra[i+1]
+	}
+
+	out, err := json.MarshalIndent(jsonVal, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// floatJSON returns f as a JSON number, or as the string "NaN", "+Inf" or
+// "-Inf" since JSON has no representation for non-finite numbers
+func floatJSON(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return f
+	}
+}
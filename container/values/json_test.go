@@ -0,0 +1,90 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// parseJSONData decodes a value's ToJSON output into a generic map
+func parseJSONData(t *testing.T, v core.Value) map[string]interface{} {
+	t.Helper()
+	jsonStr, err := v.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, jsonStr)
+	}
+	return parsed
+}
+
+func TestToJSON_IntIsNumber(t *testing.T) {
+	parsed := parseJSONData(t, NewInt32Value("count", 42))
+	if parsed["data"] != float64(42) {
+		t.Errorf("Expected JSON number 42, got %T %v", parsed["data"], parsed["data"])
+	}
+	if parsed["type"] != "int" {
+		t.Errorf("Expected type 'int', got %v", parsed["type"])
+	}
+}
+
+func TestToJSON_BoolIsBoolean(t *testing.T) {
+	parsed := parseJSONData(t, NewBoolValue("flag", true))
+	if parsed["data"] != true {
+		t.Errorf("Expected JSON boolean true, got %T %v", parsed["data"], parsed["data"])
+	}
+}
+
+func TestToJSON_BytesIsBase64WithMarker(t *testing.T) {
+	parsed := parseJSONData(t, NewBytesValue("blob", []byte{0xDE, 0xAD, 0xBE, 0xEF}))
+	if parsed["data"] != "3q2+7w==" {
+		t.Errorf("Expected base64 data, got %v", parsed["data"])
+	}
+	if parsed["encoding"] != "base64" {
+		t.Errorf("Expected base64 encoding marker, got %v", parsed["encoding"])
+	}
+}
+
+func TestToJSON_FloatNonFinite(t *testing.T) {
+	if parsed := parseJSONData(t, NewFloat64Value("pi", 3.5)); parsed["data"] != 3.5 {
+		t.Errorf("Expected JSON number 3.5, got %v", parsed["data"])
+	}
+	if parsed := parseJSONData(t, NewFloat64Value("nan", math.NaN())); parsed["data"] != "NaN" {
+		t.Errorf("Expected \"NaN\", got %v", parsed["data"])
+	}
+	if parsed := parseJSONData(t, NewFloat32Value("inf", float32(math.Inf(-1)))); parsed["data"] != "-Inf" {
+		t.Errorf("Expected \"-Inf\", got %v", parsed["data"])
+	}
+}
+
+func TestToJSON_NestedInt64KeepsPrecision(t *testing.T) {
+	const big = int64(9007199254740993) // 2^53 + 1, not representable as float64
+	array := NewArrayValue("list", NewInt64Value("n", big))
+
+	jsonStr, err := array.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var parsed struct {
+		Elements []struct {
+			Data json.Number `json:"data"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(parsed.Elements) != 1 || parsed.Elements[0].Data.String() != "9007199254740993" {
+		t.Errorf("Expected exact int64 in nested JSON, got %s", jsonStr)
+	}
+}
@@ -8,7 +8,10 @@ All rights reserved.
 package values
 
 import (
+	"encoding/binary"
 	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
 )
 
 // =============================================================================
@@ -322,6 +325,79 @@ func TestULongValue_BoundaryValues(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Deserialization Validation Tests
+// =============================================================================
+
+// longFrame builds a binary value frame with an 8-byte payload, as written by
+// languages where long is 64-bit
+func longFrame(vtype core.ValueType, name string, payload uint64) []byte {
+	frame := []byte{byte(vtype)}
+	frame = binary.LittleEndian.AppendUint32(frame, uint32(len(name)))
+	frame = append(frame, name...)
+	frame = binary.LittleEndian.AppendUint32(frame, 8)
+	return binary.LittleEndian.AppendUint64(frame, payload)
+}
+
+func TestLongValue_DeserializeRejectsOutOfRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []byte
+		want  string
+	}{
+		{"Long", longFrame(core.LongValue, "big", uint64(int64(int32Max)+1)), "LongValue"},
+		{"ULong", longFrame(core.ULongValue, "big", uint64(uint32Max)+1), "ULongValue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := deserializeValue(tt.frame)
+			if err == nil || !contains(err.Error(), tt.want) || !contains(err.Error(), "32-bit") {
+				t.Errorf("deserializeValue: expected %s range error, got %v", tt.want, err)
+			}
+
+			array := append([]byte{byte(core.ArrayValue), 1, 0, 0, 0, 'a'},
+				binary.LittleEndian.AppendUint32(nil, uint32(4+len(tt.frame)))...)
+			array = binary.LittleEndian.AppendUint32(array, 1)
+			array = append(array, tt.frame...)
+			if _, err := DeserializeArrayValue(array); err == nil {
+				t.Error("DeserializeArrayValue: expected range error")
+			}
+
+			_, err = core.NewValueFromData("big", core.ValueType(tt.frame[0]), tt.frame[len(tt.frame)-8:])
+			if err == nil || !contains(err.Error(), tt.want) {
+				t.Errorf("NewValueFromData: expected %s range error, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLongValue_DeserializeAcceptsInRange(t *testing.T) {
+	lv, _ := NewLongValue("n", int32Min)
+	frame, err := core.FrameBytes(lv)
+	if err != nil {
+		t.Fatalf("FrameBytes failed: %v", err)
+	}
+	value, consumed, err := deserializeValue(frame)
+	if err != nil {
+		t.Fatalf("deserializeValue failed: %v", err)
+	}
+	if consumed != len(frame) {
+		t.Errorf("Expected %d bytes consumed, got %d", len(frame), consumed)
+	}
+	if restored, ok := value.(*LongValue); !ok || restored.Value() != int32Min {
+		t.Errorf("Expected *LongValue %d, got %T %v", int32Min, value, value)
+	}
+
+	value, _, err = deserializeValue(longFrame(core.ULongValue, "n", uint32Max))
+	if err != nil {
+		t.Fatalf("deserializeValue failed for 8-byte in-range payload: %v", err)
+	}
+	if restored, ok := value.(*ULongValue); !ok || restored.Value() != uint32Max {
+		t.Errorf("Expected *ULongValue %d, got %T %v", uint32Max, value, value)
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && indexString(s, substr) >= 0)
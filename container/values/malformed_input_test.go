@@ -0,0 +1,122 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// malformedInputSeeds returns a well-formed frame of every value type
+func malformedInputSeeds(t *testing.T) [][]byte {
+	t.Helper()
+	id, _ := NewUUIDValueFromString("id", "123e4567-e89b-12d3-a456-426614174000")
+	long, _ := NewLongValue("long", -5)
+	m := NewMapValue("map")
+	m.Set("k", NewStringValue("", "v"))
+	seeds := []core.Value{
+		NewNullValue("null"),
+		NewBoolValue("bool", true),
+		NewInt16Value("short", -2),
+		NewUInt16Value("ushort", 2),
+		NewInt32Value("int", -4),
+		NewUInt32Value("uint", 4),
+		long,
+		NewInt64Value("llong", -8),
+		NewUInt64Value("ullong", 8),
+		NewFloat32Value("float", 1.5),
+		NewFloat64Value("double", 2.5),
+		NewStringValue("string", "text"),
+		NewBytesValue("bytes", []byte{1, 2, 3}),
+		NewContainerValue("container", NewInt32Value("child", 1)),
+		NewArrayValue("array", NewStringValue("", "a"), NewArrayValue("", NewBoolValue("", false))),
+		id,
+		m,
+	}
+	frames := make([][]byte, 0, len(seeds))
+	for _, seed := range seeds {
+		frame, err := core.FrameBytes(seed)
+		if err != nil {
+			t.Fatalf("%s: FrameBytes failed: %v", seed.Type().TypeName(), err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// decodeWithoutPanic runs every binary decoder on data and fails the test
+// if one panics
+func decodeWithoutPanic(t *testing.T, data []byte) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("panic on input %x: %v", data, r)
+		}
+	}()
+	deserializeValue(data)
+	DeserializeArrayValue(data)
+	DeserializeContainerValue(data)
+	DeserializeMapValue(data)
+	if len(data) > 0 {
+		NewValueFromData("v", core.ValueType(data[0]), data[1:])
+	}
+}
+
+func TestDeserializeValueTruncatedInput(t *testing.T) {
+	for _, frame := range malformedInputSeeds(t) {
+		for n := 0; n < len(frame); n++ {
+			decodeWithoutPanic(t, frame[:n])
+			if _, _, err := deserializeValue(frame[:n]); !errors.Is(err, core.ErrTruncatedData) {
+				t.Errorf("%s truncated to %d of %d bytes: expected ErrTruncatedData, got %v",
+					core.ValueType(frame[0]).TypeName(), n, len(frame), err)
+			}
+		}
+	}
+}
+
+func TestDeserializeValueHugeLengths(t *testing.T) {
+	for _, frame := range malformedInputSeeds(t) {
+		// name_len follows the type byte; value_size follows the name
+		nameLen := binary.LittleEndian.Uint32(frame[1:])
+		for _, at := range []int{1, 5 + int(nameLen)} {
+			for _, huge := range []uint32{0xFFFFFFFF, 0x80000000, 0x7FFFFFFF} {
+				bad := append([]byte(nil), frame...)
+				binary.LittleEndian.PutUint32(bad[at:], huge)
+				decodeWithoutPanic(t, bad)
+				if _, _, err := deserializeValue(bad); err == nil {
+					t.Errorf("%s with length %#x at offset %d: expected an error",
+						core.ValueType(frame[0]).TypeName(), huge, at)
+				}
+			}
+		}
+	}
+}
+
+func TestDeserializeValueRandomCorruption(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, frame := range malformedInputSeeds(t) {
+		for i := 0; i < 500; i++ {
+			bad := append([]byte(nil), frame...)
+			for flips := rng.Intn(4) + 1; flips > 0; flips-- {
+				bad[rng.Intn(len(bad))] = byte(rng.Intn(256))
+			}
+			decodeWithoutPanic(t, bad[:rng.Intn(len(bad)+1)])
+		}
+	}
+
+	// Pure noise of every length up to 64 bytes
+	for i := 0; i < 2000; i++ {
+		noise := make([]byte, rng.Intn(65))
+		rng.Read(noise)
+		decodeWithoutPanic(t, noise)
+	}
+}
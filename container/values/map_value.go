@@ -0,0 +1,282 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// MapValue represents a map of values keyed by unique strings (type 19).
+// Unlike ContainerValue, setting an existing key replaces its value, and
+// entries are kept in key order rather than insertion order, so equal maps
+// always serialize to the same bytes. A key is independent of the name of
+// the value stored under it.
+//
+// Wire format (binary):
+// [type:1=19][name_len:4 LE][name:UTF-8][value_size:4 LE][count:4 LE]
+// then per entry [key_len:4 LE][key:UTF-8][value frame]
+//
+// Data() returns the payload after value_size. MapValue is not safe for
+// concurrent use.
+type MapValue struct {
+	*core.BaseValue
+	keys    []string // sorted
+	entries map[string]core.Value
+}
+
+// NewMapValue creates a new empty map value
+func NewMapValue(name string) *MapValue {
+	return &MapValue{
+		BaseValue: core.NewBaseValue(name, core.MapValue, nil),
+		keys:      make([]string, 0),
+		entries:   make(map[string]core.Value),
+	}
+}
+
+// Set stores value under key, replacing any value already stored there
+func (v *MapValue) Set(key string, value core.Value) {
+	if _, ok := v.entries[key]; !ok {
+		i := sort.SearchStrings(v.keys, key)
+		v.keys = append(v.keys, "")
+		copy(v.keys[i+1:], v.keys[i:])
+		v.keys[i] = key
+	}
+	v.entries[key] = value
+}
+
+// Get returns the value stored under key and whether there is one
+func (v *MapValue) Get(key string) (core.Value, bool) {
+	value, ok := v.entries[key]
+	return value, ok
+}
+
+// Delete removes key and reports whether it was present
+func (v *MapValue) Delete(key string) bool {
+	if _, ok := v.entries[key]; !ok {
+		return false
+	}
+	delete(v.entries, key)
+	i := sort.SearchStrings(v.keys, key)
+	v.keys = append(v.keys[:i], v.keys[i+1:]...)
+	return true
+}
+
+// Keys returns the keys in sorted order. The slice is a copy.
+func (v *MapValue) Keys() []string {
+	return append([]string(nil), v.keys...)
+}
+
+// Count returns the number of entries
+func (v *MapValue) Count() int {
+	return len(v.keys)
+}
+
+// Clone returns a deep copy of the map.
+// Every entry value is cloned recursively.
+func (v *MapValue) Clone() core.Value {
+	clone := NewMapValue(v.Name())
+	clone.keys = append(clone.keys, v.keys...)
+	for key, value := range v.entries {
+		clone.entries[key] = value.Clone()
+	}
+	return clone
+}
+
+// Data returns the binary payload: [count:4 LE] then
+// [key_len:4 LE][key][value frame] per entry. It is nil if an entry value
+// cannot be serialized.
+func (v *MapValue) Data() []byte {
+	payload, err := v.appendPayload(make([]byte, 0, v.Size()))
+	if err != nil {
+		return nil
+	}
+	return payload
+}
+
+// Size returns the size of the binary payload in bytes
+func (v *MapValue) Size() int {
+	size := 4 // count
+	for _, key := range v.keys {
+		size += 4 + len(key) + v.entries[key].SerializedSize()
+	}
+	return size
+}
+
+// SerializedSize returns the number of bytes ToBytes() produces
+func (v *MapValue) SerializedSize() int {
+	// type(1) + name_len(4) + name + value_size(4) + payload
+	return 1 + 4 + len(v.Name()) + 4 + v.Size()
+}
+
+// ToBytes serializes MapValue to binary format
+func (v *MapValue) ToBytes() ([]byte, error) {
+	return v.AppendFrame(make([]byte, 0, v.SerializedSize()))
+}
+
+// AppendFrame appends the ToBytes frame to dst, serializing the entry
+// values in place
+func (v *MapValue) AppendFrame(dst []byte) ([]byte, error) {
+	dst = append(dst, byte(core.MapValue))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(v.Name())))
+	dst = append(dst, v.Name()...)
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(v.Size()))
+	return v.appendPayload(dst)
+}
+
+// appendPayload appends the Data() payload to dst
+func (v *MapValue) appendPayload(dst []byte) ([]byte, error) {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(v.keys)))
+	var err error
+	for _, key := range v.keys {
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(len(key)))
+		dst = append(dst, key...)
+		if dst, err = core.AppendValueFrame(dst, v.entries[key]); err != nil {
+			return dst, fmt.Errorf("Failed to serialize map entry %q: %w", key, err)
+		}
+	}
+	return dst, nil
+}
+
+// Serialize serializes the map and its entries in key order, each entry
+// written as [key]; followed by the serialized value
+func (v *MapValue) Serialize() (string, error) {
+	var result strings.Builder
+	fmt.Fprintf(&result, "[%s,%s,%d];", v.Name(), v.Type().String(), len(v.keys))
+	for _, key := range v.keys {
+		entrySer, err := v.entries[key].Serialize()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&result, "[%s];%s", key, entrySer)
+	}
+	return result.String(), nil
+}
+
+// ToXML converts to XML representation, wrapping each entry value's XML
+// element in an <entry key="..."> element
+func (v *MapValue) ToXML() (string, error) {
+	type XMLEntry struct {
+		XMLName xml.Name `xml:"entry"`
+		Key     string   `xml:"key,attr"`
+		Value   string   `xml:",innerxml"`
+	}
+	type XMLMap struct {
+		XMLName xml.Name   `xml:"map"`
+		Name    string     `xml:"name,attr"`
+		Type    string     `xml:"type,attr"`
+		Entries []XMLEntry `xml:"entry"`
+	}
+
+	xmlMap := XMLMap{
+		Name:    v.Name(),
+		Type:    v.Type().TypeName(),
+		Entries: make([]XMLEntry, 0, len(v.keys)),
+	}
+	for _, key := range v.keys {
+		entryXML, err := v.entries[key].ToXML()
+		if err != nil {
+			return "", err
+		}
+		xmlMap.Entries = append(xmlMap.Entries, XMLEntry{Key: key, Value: entryXML})
+	}
+
+	data, err := xml.MarshalIndent(xmlMap, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ToJSON converts to JSON representation. The data is a JSON object that
+// maps each key to the entry value's JSON.
+func (v *MapValue) ToJSON() (string, error) {
+	entries := make(map[string]json.RawMessage, len(v.keys))
+	for _, key := range v.keys {
+		entryJSON, err := v.entries[key].ToJSON()
+		if err != nil {
+			return "", err
+		}
+		entries[key] = json.RawMessage(entryJSON)
+	}
+	return nativeJSON(v, entries)
+}
+
+// DeserializeMapValue deserializes binary data produced by MapValue.ToBytes
+func DeserializeMapValue(data []byte) (*MapValue, error) {
+	if len(data) < 13 { // type(1) + name_len(4) + value_size(4) + count(4)
+		return nil, fmt.Errorf("MapValue binary data too short: %d bytes: %w", len(data), core.ErrTruncatedData)
+	}
+	if typeID := core.ValueType(data[0]); typeID != core.MapValue {
+		return nil, fmt.Errorf("Expected MapValue type (19), got %d", typeID)
+	}
+
+	nameLen := binary.LittleEndian.Uint32(data[1:5])
+	offset := 5
+	if uint64(offset)+uint64(nameLen)+4 > uint64(len(data)) {
+		return nil, fmt.Errorf("Name length %d exceeds data bounds: %w", nameLen, core.ErrTruncatedData)
+	}
+	name := string(data[offset : offset+int(nameLen)])
+	offset += int(nameLen)
+
+	valueSize := binary.LittleEndian.Uint32(data[offset : offset+4])
+	offset += 4
+	if uint64(offset)+uint64(valueSize) > uint64(len(data)) {
+		return nil, fmt.Errorf("Value size %d exceeds data bounds: %w", valueSize, core.ErrTruncatedData)
+	}
+
+	return deserializeMapData(name, data[offset:offset+int(valueSize)])
+}
+
+// deserializeMapData deserializes map entries (after the header is parsed).
+// The data format is: [count:4 LE] then [key_len:4 LE][key][value frame]
+// per entry. A repeated key keeps the last value.
+func deserializeMapData(name string, data []byte) (*MapValue, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("Map data too short: %d bytes: %w", len(data), core.ErrTruncatedData)
+	}
+
+	count := binary.LittleEndian.Uint32(data)
+	offset := 4
+
+	result := NewMapValue(name)
+	for i := uint32(0); i < count; i++ {
+		if offset+4 > len(data) {
+			return nil, fmt.Errorf("Unexpected end of data while reading entry %d/%d: %w", i+1, count, core.ErrTruncatedData)
+		}
+		keyLen := binary.LittleEndian.Uint32(data[offset:])
+		offset += 4
+		if uint64(offset)+uint64(keyLen) > uint64(len(data)) {
+			return nil, fmt.Errorf("Key length %d of entry %d exceeds data bounds: %w", keyLen, i, core.ErrTruncatedData)
+		}
+		key := string(data[offset : offset+int(keyLen)])
+		offset += int(keyLen)
+
+		if offset >= len(data) {
+			return nil, fmt.Errorf("Unexpected end of data while reading entry %q: %w", key, core.ErrTruncatedData)
+		}
+		value, bytesRead, err := deserializeValue(data[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("Failed to deserialize entry %q: %w", key, err)
+		}
+		offset += bytesRead
+
+		result.Set(key, value)
+	}
+
+	if offset != len(data) {
+		return nil, fmt.Errorf("%d entries consumed %d of %d payload bytes: %w", count, offset, len(data), core.ErrTrailingData)
+	}
+	return result, nil
+}
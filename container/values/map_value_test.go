@@ -0,0 +1,195 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func newTestMap() *MapValue {
+	m := NewMapValue("settings")
+	m.Set("timeout", NewInt32Value("", 30))
+	m.Set("host", NewStringValue("", "localhost"))
+	m.Set("tags", NewArrayValue("", NewStringValue("", "a"), NewStringValue("", "b")))
+	return m
+}
+
+func TestMapValueSetGetKeys(t *testing.T) {
+	m := newTestMap()
+
+	if got := m.Keys(); !reflect.DeepEqual(got, []string{"host", "tags", "timeout"}) {
+		t.Errorf("Expected sorted keys, got %v", got)
+	}
+	if m.Count() != 3 {
+		t.Errorf("Expected 3 entries, got %d", m.Count())
+	}
+	value, ok := m.Get("host")
+	if !ok {
+		t.Fatal("Expected host entry")
+	}
+	if s, _ := value.ToString(); s != "localhost" {
+		t.Errorf("Expected localhost, got %q", s)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("Expected no entry for missing key")
+	}
+
+	if !m.Delete("tags") || m.Delete("tags") {
+		t.Error("Expected Delete to report the first removal only")
+	}
+	if got := m.Keys(); !reflect.DeepEqual(got, []string{"host", "timeout"}) {
+		t.Errorf("Expected keys after delete, got %v", got)
+	}
+}
+
+func TestMapValueDuplicateKeyOverwrites(t *testing.T) {
+	m := NewMapValue("m")
+	m.Set("k", NewInt32Value("", 1))
+	m.Set("k", NewStringValue("", "two"))
+
+	if m.Count() != 1 {
+		t.Fatalf("Expected 1 entry after overwrite, got %d", m.Count())
+	}
+	value, _ := m.Get("k")
+	if value.Type() != core.StringValue {
+		t.Errorf("Expected the later value to win, got %s", value.Type().TypeName())
+	}
+
+	// A payload with a repeated key keeps the last value too
+	payload := binary32(2)
+	for _, n := range []int32{1, 2} {
+		payload = append(payload, binary32(1)...)
+		payload = append(payload, 'k')
+		frame, _ := NewInt32Value("", n).ToBytes()
+		payload = append(payload, frame...)
+	}
+	decoded, err := NewValueFromData("m", core.MapValue, payload)
+	if err != nil {
+		t.Fatalf("NewValueFromData failed: %v", err)
+	}
+	decodedMap := decoded.(*MapValue)
+	if decodedMap.Count() != 1 {
+		t.Fatalf("Expected 1 entry, got %d", decodedMap.Count())
+	}
+	value, _ = decodedMap.Get("k")
+	if n, _ := value.ToInt32(); n != 2 {
+		t.Errorf("Expected last value 2, got %d", n)
+	}
+}
+
+// binary32 returns n as 4 little-endian bytes
+func binary32(n uint32) []byte {
+	return []byte{byte(n), byte(n >> 8), byte(n >> 16), byte(n >> 24)}
+}
+
+func TestMapValueBinaryRoundTrip(t *testing.T) {
+	m := newTestMap()
+	data, err := m.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	if len(data) != m.SerializedSize() {
+		t.Errorf("SerializedSize %d, ToBytes produced %d bytes", m.SerializedSize(), len(data))
+	}
+	if data[0] != byte(core.MapValue) {
+		t.Errorf("Expected type byte 19, got %d", data[0])
+	}
+
+	restored, err := DeserializeMapValue(data)
+	if err != nil {
+		t.Fatalf("DeserializeMapValue failed: %v", err)
+	}
+	if !core.ValuesEqual(m, restored) {
+		t.Error("Restored map differs from the original")
+	}
+	tags, _ := restored.Get("tags")
+	if strs, err := tags.(*ArrayValue).AsStringSlice(); err != nil || !reflect.DeepEqual(strs, []string{"a", "b"}) {
+		t.Errorf("Expected nested array [a b], got %v, %v", strs, err)
+	}
+
+	// Data() is the payload after value_size, accepted by the factory
+	fromData, err := NewValueFromData("settings", core.MapValue, m.Data())
+	if err != nil {
+		t.Fatalf("NewValueFromData failed: %v", err)
+	}
+	again, _ := fromData.ToBytes()
+	if !bytes.Equal(again, data) {
+		t.Error("Expected the same frame when rebuilt from Data()")
+	}
+
+	// Insertion order does not affect the encoding
+	reordered := NewMapValue("settings")
+	for _, key := range []string{"timeout", "tags", "host"} {
+		value, _ := m.Get(key)
+		reordered.Set(key, value)
+	}
+	if other, _ := reordered.ToBytes(); !bytes.Equal(other, data) {
+		t.Error("Expected identical bytes regardless of insertion order")
+	}
+
+	if _, err := DeserializeMapValue(data[:len(data)-2]); !errors.Is(err, core.ErrTruncatedData) {
+		t.Errorf("Expected ErrTruncatedData for truncated frame, got %v", err)
+	}
+}
+
+func TestMapValueNestedInArray(t *testing.T) {
+	array := NewArrayValue("list", newTestMap(), NewInt32Value("", 1))
+	data, err := array.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	restored, err := DeserializeArrayValue(data)
+	if err != nil {
+		t.Fatalf("DeserializeArrayValue failed: %v", err)
+	}
+	if !core.ValuesEqual(array, restored) {
+		t.Error("Restored array differs from the original")
+	}
+}
+
+func TestMapValueJSONIsObject(t *testing.T) {
+	s, err := newTestMap().ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var doc struct {
+		Type string                     `json:"type"`
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		t.Fatalf("ToJSON produced invalid JSON: %v\n%s", err, s)
+	}
+	if doc.Type != "map" || len(doc.Data) != 3 {
+		t.Fatalf("Expected a map with an object of 3 entries, got %s", s)
+	}
+	var timeout struct {
+		Type string `json:"type"`
+		Data int    `json:"data"`
+	}
+	if err := json.Unmarshal(doc.Data["timeout"], &timeout); err != nil || timeout.Type != "int" || timeout.Data != 30 {
+		t.Errorf("Unexpected timeout entry %s", doc.Data["timeout"])
+	}
+}
+
+func TestMapValueCloneIsIndependent(t *testing.T) {
+	m := newTestMap()
+	clone := m.Clone().(*MapValue)
+	clone.Set("host", NewStringValue("", "remote"))
+	clone.Delete("timeout")
+
+	value, _ := m.Get("host")
+	if s, _ := value.ToString(); s != "localhost" || m.Count() != 3 {
+		t.Errorf("Mutating the clone changed the original: host=%q count=%d", s, m.Count())
+	}
+}
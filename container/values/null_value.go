@@ -71,6 +71,11 @@ func (v *NullValue) ToString() (string, error) {
 	return "null", nil
 }
 
+// Clone returns a copy of the null value.
+func (v *NullValue) Clone() core.Value {
+	return NewNullValue(v.Name())
+}
+
 // ToJSON returns the JSON representation of the null value.
 func (v *NullValue) ToJSON() (string, error) {
 	jsonVal := map[string]interface{}{
@@ -109,5 +114,5 @@ func (v *NullValue) ToXML() (string, error) {
 // Serialize returns the string representation for the null value.
 func (v *NullValue) Serialize() (string, error) {
 	// Format: name|type|size (size is always 0 for null)
-	return v.Name() + "|0|0", nil
+	return core.EscapeTextField(v.Name()) + "|0|0", nil
 }
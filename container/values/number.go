@@ -0,0 +1,42 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"math"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// NewNumber returns v as the smallest signed integer value that holds it:
+// an *Int16Value (short), *Int32Value (int) or *Int64Value (llong).
+// The platform-dependent long type is never chosen.
+func NewNumber(name string, v int64) core.Value {
+	switch {
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return NewInt16Value(name, int16(v))
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return NewInt32Value(name, int32(v))
+	default:
+		return NewInt64Value(name, v)
+	}
+}
+
+// NewUNumber returns v as the smallest unsigned integer value that holds
+// it: a *UInt16Value (ushort), *UInt32Value (uint) or *UInt64Value (ullong).
+// The platform-dependent ulong type is never chosen.
+func NewUNumber(name string, v uint64) core.Value {
+	switch {
+	case v <= math.MaxUint16:
+		return NewUInt16Value(name, uint16(v))
+	case v <= math.MaxUint32:
+		return NewUInt32Value(name, uint32(v))
+	default:
+		return NewUInt64Value(name, v)
+	}
+}
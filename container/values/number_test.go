@@ -0,0 +1,70 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestNewNumberSelectsSmallestType(t *testing.T) {
+	tests := []struct {
+		value int64
+		want  core.ValueType
+	}{
+		{0, core.ShortValue},
+		{math.MaxInt16, core.ShortValue},
+		{math.MinInt16, core.ShortValue},
+		{math.MaxInt16 + 1, core.IntValue},
+		{math.MinInt16 - 1, core.IntValue},
+		{math.MaxInt32, core.IntValue},
+		{math.MinInt32, core.IntValue},
+		{math.MaxInt32 + 1, core.LLongValue},
+		{math.MinInt32 - 1, core.LLongValue},
+		{math.MaxInt64, core.LLongValue},
+		{math.MinInt64, core.LLongValue},
+	}
+	for _, tt := range tests {
+		value := NewNumber("n", tt.value)
+		if value.Type() != tt.want {
+			t.Errorf("NewNumber(%d): expected %s, got %s", tt.value, tt.want.TypeName(), value.Type().TypeName())
+		}
+		if got, err := value.ToInt64(); err != nil || got != tt.value {
+			t.Errorf("NewNumber(%d): ToInt64 returned %d, %v", tt.value, got, err)
+		}
+	}
+
+	if _, ok := NewNumber("n", 70000).(*Int32Value); !ok {
+		t.Error("Expected a concrete *Int32Value")
+	}
+}
+
+func TestNewUNumberSelectsSmallestType(t *testing.T) {
+	tests := []struct {
+		value uint64
+		want  core.ValueType
+	}{
+		{0, core.UShortValue},
+		{math.MaxUint16, core.UShortValue},
+		{math.MaxUint16 + 1, core.UIntValue},
+		{math.MaxUint32, core.UIntValue},
+		{math.MaxUint32 + 1, core.ULLongValue},
+		{math.MaxUint64, core.ULLongValue},
+	}
+	for _, tt := range tests {
+		value := NewUNumber("n", tt.value)
+		if value.Type() != tt.want {
+			t.Errorf("NewUNumber(%d): expected %s, got %s", tt.value, tt.want.TypeName(), value.Type().TypeName())
+		}
+		if got, err := value.ToUInt64(); err != nil || got != tt.value {
+			t.Errorf("NewUNumber(%d): ToUInt64 returned %d, %v", tt.value, got, err)
+		}
+	}
+}
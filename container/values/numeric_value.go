@@ -36,6 +36,12 @@ func (v *Int16Value) ToInt32() (int32, error) { return int32(v.value), nil }
 func (v *Int16Value) ToInt64() (int64, error) { return int64(v.value), nil }
 func (v *Int16Value) Value() int16             { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *Int16Value) Clone() core.Value { return NewInt16Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *Int16Value) ToJSON() (string, error) { return nativeJSON(v, v.value) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:2]
 func (v *Int16Value) ToBytes() ([]byte, error) {
@@ -100,6 +106,12 @@ func (v *UInt16Value) ToUInt32() (uint32, error) { return uint32(v.value), nil }
 func (v *UInt16Value) ToUInt64() (uint64, error) { return uint64(v.value), nil }
 func (v *UInt16Value) Value() uint16              { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *UInt16Value) Clone() core.Value { return NewUInt16Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *UInt16Value) ToJSON() (string, error) { return nativeJSON(v, v.value) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:2]
 func (v *UInt16Value) ToBytes() ([]byte, error) {
@@ -150,7 +162,11 @@ type Int32Value struct {
 }
 
 // NewInt32Value creates a new int32 value
+// While pooling is enabled the value is taken from a pool (see EnablePooling).
 func NewInt32Value(name string, value int32) *Int32Value {
+	if poolingEnabled.Load() {
+		return newPooledInt32Value(name, value)
+	}
 	data := make([]byte, 4)
 	binary.LittleEndian.PutUint32(data, uint32(value))
 	return &Int32Value{
@@ -163,6 +179,12 @@ func (v *Int32Value) ToInt32() (int32, error) { return v.value, nil }
 func (v *Int32Value) ToInt64() (int64, error) { return int64(v.value), nil }
 func (v *Int32Value) Value() int32             { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *Int32Value) Clone() core.Value { return NewInt32Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *Int32Value) ToJSON() (string, error) { return nativeJSON(v, v.value) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:4]
 func (v *Int32Value) ToBytes() ([]byte, error) {
@@ -228,6 +250,12 @@ func (v *UInt32Value) ToUInt32() (uint32, error) { return v.value, nil }
 func (v *UInt32Value) ToUInt64() (uint64, error) { return uint64(v.value), nil }
 func (v *UInt32Value) Value() uint32              { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *UInt32Value) Clone() core.Value { return NewUInt32Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *UInt32Value) ToJSON() (string, error) { return nativeJSON(v, v.value) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:4]
 func (v *UInt32Value) ToBytes() ([]byte, error) {
@@ -286,6 +314,12 @@ func NewInt64Value(name string, value int64) *Int64Value {
 func (v *Int64Value) ToInt64() (int64, error) { return v.value, nil }
 func (v *Int64Value) Value() int64             { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *Int64Value) Clone() core.Value { return NewInt64Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *Int64Value) ToJSON() (string, error) { return nativeJSON(v, v.value) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:8]
 func (v *Int64Value) ToBytes() ([]byte, error) {
@@ -354,6 +388,12 @@ func NewUInt64Value(name string, value uint64) *UInt64Value {
 func (v *UInt64Value) ToUInt64() (uint64, error) { return v.value, nil }
 func (v *UInt64Value) Value() uint64              { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *UInt64Value) Clone() core.Value { return NewUInt64Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *UInt64Value) ToJSON() (string, error) { return nativeJSON(v, v.value) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:8]
 func (v *UInt64Value) ToBytes() ([]byte, error) {
@@ -417,6 +457,12 @@ func (v *Float32Value) ToFloat32() (float32, error) { return v.value, nil }
 func (v *Float32Value) ToFloat64() (float64, error) { return float64(v.value), nil }
 func (v *Float32Value) Value() float32               { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *Float32Value) Clone() core.Value { return NewFloat32Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *Float32Value) ToJSON() (string, error) { return nativeJSON(v, floatJSON(float64(v.value))) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:4]
 func (v *Float32Value) ToBytes() ([]byte, error) {
@@ -477,6 +523,12 @@ func NewFloat64Value(name string, value float64) *Float64Value {
 func (v *Float64Value) ToFloat64() (float64, error) { return v.value, nil }
 func (v *Float64Value) Value() float64               { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *Float64Value) Clone() core.Value { return NewFloat64Value(v.Name(), v.value) }
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *Float64Value) ToJSON() (string, error) { return nativeJSON(v, floatJSON(v.value)) }
+
 // ToBytes implements complete binary format with header
 // Format: [type:1][name_len:4][name][value_size:4][value:8]
 func (v *Float64Value) ToBytes() ([]byte, error) {
@@ -565,6 +617,14 @@ func (v *LongValue) ToInt32() (int32, error) { return v.value, nil }
 func (v *LongValue) ToInt64() (int64, error) { return int64(v.value), nil }
 func (v *LongValue) Value() int32             { return v.value }
 
+// Clone returns a deep copy of the value
+func (v *LongValue) Clone() core.Value {
+	return &LongValue{BaseValue: v.CloneBase(), value: v.value}
+}
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *LongValue) ToJSON() (string, error) { return nativeJSON(v, v.value) }
+
 // ULongValue represents a 32-bit unsigned integer (type 7).
 // Policy: Enforces 32-bit range [0, 2^32-1].
 // Values exceeding this range should use UInt64Value.
@@ -600,3 +660,11 @@ func NewULongValue(name string, value uint64) (*ULongValue, error) {
 func (v *ULongValue) ToUInt32() (uint32, error) { return v.value, nil }
 func (v *ULongValue) ToUInt64() (uint64, error) { return uint64(v.value), nil }
 func (v *ULongValue) Value() uint32              { return v.value }
+
+// Clone returns a deep copy of the value
+func (v *ULongValue) Clone() core.Value {
+	return &ULongValue{BaseValue: v.CloneBase(), value: v.value}
+}
+
+// ToJSON returns the JSON representation with the value as a JSON number
+func (v *ULongValue) ToJSON() (string, error) { return nativeJSON(v, v.value) }
@@ -0,0 +1,110 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// Value pooling
+//
+// With pooling enabled, NewInt32Value, NewBoolValue and NewStringValue take
+// their values (including the BaseValue and its payload buffer) from
+// sync.Pools, and Release returns them. Hot paths that build and discard
+// thousands of values then allocate almost nothing per value.
+//
+// Contract: after Release(v), v and anything obtained from it (its Data()
+// slice in particular) must not be used or retained; the next constructor
+// call may hand the same object out with new contents. Only release values
+// that are no longer referenced by a container, array or other goroutine.
+// Values that are never released are simply garbage-collected.
+
+var poolingEnabled atomic.Bool
+
+var (
+	int32Pool  = sync.Pool{New: func() any { return &Int32Value{BaseValue: &core.BaseValue{}} }}
+	boolPool   = sync.Pool{New: func() any { return &BoolValue{BaseValue: &core.BaseValue{}} }}
+	stringPool = sync.Pool{New: func() any { return &StringValue{BaseValue: &core.BaseValue{}} }}
+)
+
+// EnablePooling makes NewInt32Value, NewBoolValue and NewStringValue reuse
+// values returned with Release
+func EnablePooling() {
+	poolingEnabled.Store(true)
+}
+
+// DisablePooling restores plain allocation in the pooled constructors.
+// Release becomes a no-op; values already released are dropped with their
+// pools.
+func DisablePooling() {
+	poolingEnabled.Store(false)
+}
+
+// PoolingEnabled reports whether EnablePooling is in effect
+func PoolingEnabled() bool {
+	return poolingEnabled.Load()
+}
+
+// Release returns a value to its pool so a later constructor call can
+// reuse it. Only *Int32Value, *BoolValue and *StringValue are pooled; other
+// values, nil, and every value while pooling is disabled are ignored.
+// v must not be used after Release (see the pooling contract above).
+func Release(v core.Value) {
+	if !poolingEnabled.Load() {
+		return
+	}
+	switch v := v.(type) {
+	case *Int32Value:
+		resetBaseValue(v.BaseValue, "", core.IntValue, v.Data()[:0])
+		v.value = 0
+		int32Pool.Put(v)
+	case *BoolValue:
+		resetBaseValue(v.BaseValue, "", core.BoolValue, v.Data()[:0])
+		v.value = false
+		boolPool.Put(v)
+	case *StringValue:
+		resetBaseValue(v.BaseValue, "", core.StringValue, v.Data()[:0])
+		v.value = ""
+		stringPool.Put(v)
+	}
+}
+
+// resetBaseValue reinitializes a pooled value's BaseValue in place with a
+// new name, type and data, dropping its parent and children
+func resetBaseValue(b *core.BaseValue, name string, vtype core.ValueType, data []byte) {
+	*b = *core.NewBaseValue(name, vtype, data)
+}
+
+func newPooledInt32Value(name string, value int32) *Int32Value {
+	v := int32Pool.Get().(*Int32Value)
+	resetBaseValue(v.BaseValue, name, core.IntValue, binary.LittleEndian.AppendUint32(v.Data()[:0], uint32(value)))
+	v.value = value
+	return v
+}
+
+func newPooledBoolValue(name string, value bool) *BoolValue {
+	v := boolPool.Get().(*BoolValue)
+	var b byte
+	if value {
+		b = 1
+	}
+	resetBaseValue(v.BaseValue, name, core.BoolValue, append(v.Data()[:0], b))
+	v.value = value
+	return v
+}
+
+func newPooledStringValue(name string, value string) *StringValue {
+	v := stringPool.Get().(*StringValue)
+	resetBaseValue(v.BaseValue, name, core.StringValue, append(v.Data()[:0], value...))
+	v.value = value
+	return v
+}
@@ -0,0 +1,66 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestPooling_ValuesAreReusedWithNewContents(t *testing.T) {
+	EnablePooling()
+	defer DisablePooling()
+
+	for i := 0; i < 100; i++ {
+		iv := NewInt32Value("n", int32(i))
+		if got, _ := iv.ToInt32(); got != int32(i) || iv.Name() != "n" || iv.Type() != core.IntValue {
+			t.Fatalf("Pooled int32 = %s %d, want n %d", iv.Name(), got, i)
+		}
+		if len(iv.Data()) != 4 {
+			t.Fatalf("Pooled int32 payload is %d bytes, want 4", len(iv.Data()))
+		}
+
+		bv := NewBoolValue("b", i%2 == 0)
+		if got, _ := bv.ToBool(); got != (i%2 == 0) || len(bv.Data()) != 1 {
+			t.Fatalf("Pooled bool = %v (%d bytes), want %v", got, len(bv.Data()), i%2 == 0)
+		}
+
+		text := string(rune('a' + i%26))
+		sv := NewStringValue("s", text)
+		if got, _ := sv.ToString(); got != text || string(sv.Data()) != text {
+			t.Fatalf("Pooled string = %q (data %q), want %q", got, sv.Data(), text)
+		}
+
+		Release(iv)
+		Release(bv)
+		Release(sv)
+	}
+}
+
+func TestPooling_ReleasedValueIsReset(t *testing.T) {
+	EnablePooling()
+	defer DisablePooling()
+
+	v := NewInt32Value("child", 7)
+	Release(v)
+
+	if v.Name() != "" || v.Size() != 0 {
+		t.Errorf("Released value should be reset, got name %q size %d", v.Name(), v.Size())
+	}
+}
+
+func TestPooling_DisabledReleaseIsNoOp(t *testing.T) {
+	v := NewInt32Value("n", 5)
+	Release(v)
+	if got, _ := v.ToInt32(); got != 5 || v.Name() != "n" {
+		t.Error("Release should not touch values while pooling is disabled")
+	}
+	Release(NewFloat64Value("f", 1))
+	Release(nil)
+}
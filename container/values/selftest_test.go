@@ -0,0 +1,31 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// The values package cannot import wireprotocol, so the wire codec is
+// never registered in this test binary
+func TestSelfTest_FailsWithoutWireCodec(t *testing.T) {
+	err := core.SelfTest()
+	if !errors.Is(err, core.ErrSelfTestFailed) {
+		t.Fatalf("Expected ErrSelfTestFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "wire: codec not registered") {
+		t.Errorf("Expected the missing wire codec to be reported, got %v", err)
+	}
+	if strings.Contains(err.Error(), "binary:") {
+		t.Errorf("Expected the binary codec to pass, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestSerializedSize_MatchesFrameBytes(t *testing.T) {
+	longValue, err := NewLongValue("long", -42)
+	if err != nil {
+		t.Fatalf("NewLongValue failed: %v", err)
+	}
+	ulongValue, err := NewULongValue("ulong", 42)
+	if err != nil {
+		t.Fatalf("NewULongValue failed: %v", err)
+	}
+	settings := NewMapValue("map")
+	settings.Set("b", NewInt32Value("b", 2))
+	settings.Set("a", NewArrayValue("a", NewStringValue("", "x")))
+
+	testCases := []core.Value{
+		NewNullValue("null"),
+		NewBoolValue("bool", true),
+		NewInt16Value("short", -1),
+		NewUInt16Value("ushort", 1),
+		NewInt32Value("int", -1),
+		NewUInt32Value("uint", 1),
+		longValue,
+		ulongValue,
+		NewInt64Value("llong", -1),
+		NewUInt64Value("ullong", 1),
+		NewFloat32Value("float", 1.5),
+		NewFloat64Value("double", 2.5),
+		NewStringValue("string", "héllo"),
+		NewStringValue("", ""),
+		NewBytesValue("bytes", []byte{0x01, 0x02, 0x03}),
+		NewDateTimeValue("datetime", time.Unix(1700000000, 0)),
+		NewUUIDValue("uuid", [16]byte{1, 2, 3}),
+		NewDecimalValue("decimal", big.NewInt(-123456789), 4),
+		settings,
+		NewArrayValue("empty_array"),
+		NewArrayValue("array", NewInt32Value("", 1), NewStringValue("", "two")),
+		NewContainerValue("empty_container"),
+		NewContainerValue("container",
+			NewStringValue("name", "value"),
+			NewArrayValue("list", NewBoolValue("", false)),
+			NewContainerValue("nested", NewFloat64Value("pi", 3.14)),
+		),
+	}
+
+	for _, value := range testCases {
+		t.Run(value.Type().TypeName()+"/"+value.Name(), func(t *testing.T) {
+			data, err := core.FrameBytes(value)
+			if err != nil {
+				t.Fatalf("FrameBytes failed: %v", err)
+			}
+			if got := value.SerializedSize(); got != len(data) {
+				t.Errorf("SerializedSize() = %d, len(FrameBytes()) = %d", got, len(data))
+			}
+			if got := core.FramedSize(value); got != len(data) {
+				t.Errorf("FramedSize() = %d, len(FrameBytes()) = %d", got, len(data))
+			}
+		})
+	}
+
+	if got := core.FramedSize(nil); got != 0 {
+		t.Errorf("FramedSize(nil) = %d, want 0", got)
+	}
+}
+
+func TestFrameBytes_BaseValueToBytesIsPayload(t *testing.T) {
+	payload := []byte{0x01, 0x02, 0x03}
+	base := core.NewBaseValue("raw", core.BytesValue, payload)
+
+	data, err := base.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	if string(data) != string(payload) {
+		t.Errorf("ToBytes() = %v, want payload %v", data, payload)
+	}
+
+	frame, err := core.FrameBytes(base)
+	if err != nil {
+		t.Fatalf("FrameBytes failed: %v", err)
+	}
+	if len(frame) != 1+4+3+4+3 || string(frame[len(frame)-3:]) != string(payload) {
+		t.Errorf("FrameBytes() = %v, want framed payload", frame)
+	}
+}
@@ -0,0 +1,54 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestSetNameAppearsInToBytes(t *testing.T) {
+	m := NewMapValue("before")
+	m.Set("k", NewInt32Value("", 1))
+	for _, value := range []core.Value{
+		NewInt32Value("before", 7),
+		NewStringValue("before", "text"),
+		NewBytesValue("before", []byte{1, 2}),
+		NewArrayValue("before", NewBoolValue("", true)),
+		NewContainerValue("before", NewInt32Value("child", 1)),
+		m,
+	} {
+		t.Run(value.Type().TypeName(), func(t *testing.T) {
+			value.SetName("renamed")
+			if value.Name() != "renamed" {
+				t.Fatalf("Expected Name() renamed, got %q", value.Name())
+			}
+
+			frame, err := value.ToBytes()
+			if err != nil {
+				t.Fatalf("ToBytes failed: %v", err)
+			}
+			if n := binary.LittleEndian.Uint32(frame[1:]); n != uint32(len("renamed")) || string(frame[5:5+n]) != "renamed" {
+				t.Errorf("Expected the new name in the frame, got %q", frame[5:5+n])
+			}
+			if len(frame) != value.SerializedSize() {
+				t.Errorf("SerializedSize %d, ToBytes produced %d bytes", value.SerializedSize(), len(frame))
+			}
+
+			restored, _, err := deserializeValue(frame)
+			if err != nil {
+				t.Fatalf("deserializeValue failed: %v", err)
+			}
+			if restored.Name() != "renamed" {
+				t.Errorf("Expected decoded name renamed, got %q", restored.Name())
+			}
+		})
+	}
+}
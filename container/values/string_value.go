@@ -18,7 +18,11 @@ type StringValue struct {
 }
 
 // NewStringValue creates a new string value
+// While pooling is enabled the value is taken from a pool (see EnablePooling).
 func NewStringValue(name string, value string) *StringValue {
+	if poolingEnabled.Load() {
+		return newPooledStringValue(name, value)
+	}
 	return &StringValue{
 		BaseValue: core.NewBaseValue(name, core.StringValue, []byte(value)),
 		value:     value,
@@ -76,3 +80,13 @@ func (v *StringValue) ToBytes() ([]byte, error) {
 func (v *StringValue) Value() string {
 	return v.value
 }
+
+// ToJSON returns the JSON representation with the value as a JSON string
+func (v *StringValue) ToJSON() (string, error) {
+	return nativeJSON(v, v.value)
+}
+
+// Clone returns a deep copy of the value
+func (v *StringValue) Clone() core.Value {
+	return NewStringValue(v.Name(), v.value)
+}
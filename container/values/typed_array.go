@@ -0,0 +1,185 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"fmt"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// newTypedArray builds an array of unnamed elements from a Go slice
+func newTypedArray[T any, V core.Value](name string, xs []T, newValue func(string, T) V) *ArrayValue {
+	av := NewArrayValue(name)
+	av.elements = make([]core.Value, len(xs))
+	for i, x := range xs {
+		av.elements[i] = newValue("", x)
+	}
+	return av
+}
+
+// NewBoolArray creates an array of bool elements
+func NewBoolArray(name string, xs []bool) *ArrayValue {
+	return newTypedArray(name, xs, NewBoolValue)
+}
+
+// NewInt16Array creates an array of short elements
+func NewInt16Array(name string, xs []int16) *ArrayValue {
+	return newTypedArray(name, xs, NewInt16Value)
+}
+
+// NewUInt16Array creates an array of ushort elements
+func NewUInt16Array(name string, xs []uint16) *ArrayValue {
+	return newTypedArray(name, xs, NewUInt16Value)
+}
+
+// NewInt32Array creates an array of int elements
+func NewInt32Array(name string, xs []int32) *ArrayValue {
+	return newTypedArray(name, xs, NewInt32Value)
+}
+
+// NewUInt32Array creates an array of uint elements
+func NewUInt32Array(name string, xs []uint32) *ArrayValue {
+	return newTypedArray(name, xs, NewUInt32Value)
+}
+
+// NewInt64Array creates an array of llong elements
+func NewInt64Array(name string, xs []int64) *ArrayValue {
+	return newTypedArray(name, xs, NewInt64Value)
+}
+
+// NewUInt64Array creates an array of ullong elements
+func NewUInt64Array(name string, xs []uint64) *ArrayValue {
+	return newTypedArray(name, xs, NewUInt64Value)
+}
+
+// NewFloat32Array creates an array of float elements
+func NewFloat32Array(name string, xs []float32) *ArrayValue {
+	return newTypedArray(name, xs, NewFloat32Value)
+}
+
+// NewFloat64Array creates an array of double elements
+func NewFloat64Array(name string, xs []float64) *ArrayValue {
+	return newTypedArray(name, xs, NewFloat64Value)
+}
+
+// NewStringArray creates an array of string elements
+func NewStringArray(name string, xs []string) *ArrayValue {
+	return newTypedArray(name, xs, NewStringValue)
+}
+
+// NewBytesArray creates an array of bytes elements
+func NewBytesArray(name string, xs [][]byte) *ArrayValue {
+	return newTypedArray(name, xs, NewBytesValue)
+}
+
+// ElementType returns the type shared by all elements and true, or false if
+// the elements have different types. An empty array has no element type and
+// reports NullValue and true.
+func (v *ArrayValue) ElementType() (core.ValueType, bool) {
+	elements := v.snapshot()
+	if len(elements) == 0 {
+		return core.NullValue, true
+	}
+	vtype := elements[0].Type()
+	for _, element := range elements[1:] {
+		if element.Type() != vtype {
+			return core.NullValue, false
+		}
+	}
+	return vtype, true
+}
+
+// IsHomogeneous reports whether all elements have the same type.
+// An empty array is homogeneous.
+func (v *ArrayValue) IsHomogeneous() bool {
+	_, ok := v.ElementType()
+	return ok
+}
+
+// typedSlice extracts the elements of v as a Go slice. Every element must
+// have exactly the type vtype; conversions between types are not applied,
+// so an int element is not read into an []int64.
+func typedSlice[T any](v *ArrayValue, vtype core.ValueType, convert func(core.Value) (T, error)) ([]T, error) {
+	elements := v.snapshot()
+	result := make([]T, len(elements))
+	for i, element := range elements {
+		if element.Type() != vtype {
+			return nil, fmt.Errorf("ArrayValue %q element %d is %s, expected %s: %w",
+				v.Name(), i, element.Type().TypeName(), vtype.TypeName(), core.ErrTypeConversion)
+		}
+		x, err := convert(element)
+		if err != nil {
+			return nil, fmt.Errorf("ArrayValue %q element %d: %w", v.Name(), i, err)
+		}
+		result[i] = x
+	}
+	return result, nil
+}
+
+// AsBoolSlice returns the elements as bools; every element must be a bool
+func (v *ArrayValue) AsBoolSlice() ([]bool, error) {
+	return typedSlice(v, core.BoolValue, core.Value.ToBool)
+}
+
+// AsInt16Slice returns the elements as int16s; every element must be a short
+func (v *ArrayValue) AsInt16Slice() ([]int16, error) {
+	return typedSlice(v, core.ShortValue, core.Value.ToInt16)
+}
+
+// AsUInt16Slice returns the elements as uint16s; every element must be a ushort
+func (v *ArrayValue) AsUInt16Slice() ([]uint16, error) {
+	return typedSlice(v, core.UShortValue, core.Value.ToUInt16)
+}
+
+// AsInt32Slice returns the elements as int32s; every element must be an int
+func (v *ArrayValue) AsInt32Slice() ([]int32, error) {
+	return typedSlice(v, core.IntValue, core.Value.ToInt32)
+}
+
+// AsUInt32Slice returns the elements as uint32s; every element must be a uint
+func (v *ArrayValue) AsUInt32Slice() ([]uint32, error) {
+	return typedSlice(v, core.UIntValue, core.Value.ToUInt32)
+}
+
+// AsInt64Slice returns the elements as int64s; every element must be an llong
+func (v *ArrayValue) AsInt64Slice() ([]int64, error) {
+	return typedSlice(v, core.LLongValue, core.Value.ToInt64)
+}
+
+// AsUInt64Slice returns the elements as uint64s; every element must be a ullong
+func (v *ArrayValue) AsUInt64Slice() ([]uint64, error) {
+	return typedSlice(v, core.ULLongValue, core.Value.ToUInt64)
+}
+
+// AsFloat32Slice returns the elements as float32s; every element must be a float
+func (v *ArrayValue) AsFloat32Slice() ([]float32, error) {
+	return typedSlice(v, core.FloatValue, core.Value.ToFloat32)
+}
+
+// AsFloat64Slice returns the elements as float64s; every element must be a double
+func (v *ArrayValue) AsFloat64Slice() ([]float64, error) {
+	return typedSlice(v, core.DoubleValue, core.Value.ToFloat64)
+}
+
+// AsStringSlice returns the elements as strings; every element must be a string
+func (v *ArrayValue) AsStringSlice() ([]string, error) {
+	return typedSlice(v, core.StringValue, core.Value.ToString)
+}
+
+// AsBytesSlice returns the elements as byte slices; every element must be
+// a bytes value
+func (v *ArrayValue) AsBytesSlice() ([][]byte, error) {
+	return typedSlice(v, core.BytesValue, bytesPayload)
+}
+
+// bytesPayload returns a copy of a bytes element's payload. Value.ToBytes
+// is not used as it returns the serialized frame.
+func bytesPayload(element core.Value) ([]byte, error) {
+	return append([]byte(nil), element.Data()...), nil
+}
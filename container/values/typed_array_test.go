@@ -0,0 +1,137 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// roundTripArray serializes an array and deserializes it again
+func roundTripArray(t *testing.T, array *ArrayValue) *ArrayValue {
+	t.Helper()
+	data, err := array.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	restored, err := DeserializeArrayValue(data)
+	if err != nil {
+		t.Fatalf("DeserializeArrayValue failed: %v", err)
+	}
+	return restored
+}
+
+func TestTypedArrayRoundTrip(t *testing.T) {
+	ints := []int32{-7, 0, 42, 1 << 30}
+	array := NewInt32Array("ints", ints)
+	if array.Name() != "ints" || array.Count() != len(ints) {
+		t.Fatalf("Unexpected array %q with %d elements", array.Name(), array.Count())
+	}
+	got, err := roundTripArray(t, array).AsInt32Slice()
+	if err != nil {
+		t.Fatalf("AsInt32Slice failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, ints) {
+		t.Errorf("Expected %v, got %v", ints, got)
+	}
+
+	strs := []string{"alpha", "", "γ"}
+	gotStrs, err := roundTripArray(t, NewStringArray("strs", strs)).AsStringSlice()
+	if err != nil {
+		t.Fatalf("AsStringSlice failed: %v", err)
+	}
+	if !reflect.DeepEqual(gotStrs, strs) {
+		t.Errorf("Expected %v, got %v", strs, gotStrs)
+	}
+
+	floats := []float64{-1.5, 0, 3.141592653589793}
+	gotFloats, err := roundTripArray(t, NewFloat64Array("floats", floats)).AsFloat64Slice()
+	if err != nil {
+		t.Fatalf("AsFloat64Slice failed: %v", err)
+	}
+	if !reflect.DeepEqual(gotFloats, floats) {
+		t.Errorf("Expected %v, got %v", floats, gotFloats)
+	}
+
+	blobs := [][]byte{{0x00, 0xff}, {}, []byte("abc")}
+	gotBlobs, err := roundTripArray(t, NewBytesArray("blobs", blobs)).AsBytesSlice()
+	if err != nil {
+		t.Fatalf("AsBytesSlice failed: %v", err)
+	}
+	if len(gotBlobs) != len(blobs) {
+		t.Fatalf("Expected %d blobs, got %d", len(blobs), len(gotBlobs))
+	}
+	for i := range blobs {
+		if !bytes.Equal(gotBlobs[i], blobs[i]) {
+			t.Errorf("Blob %d: expected %x, got %x", i, blobs[i], gotBlobs[i])
+		}
+	}
+
+	bools := []bool{true, false, true}
+	gotBools, err := roundTripArray(t, NewBoolArray("flags", bools)).AsBoolSlice()
+	if err != nil {
+		t.Fatalf("AsBoolSlice failed: %v", err)
+	}
+	if !reflect.DeepEqual(gotBools, bools) {
+		t.Errorf("Expected %v, got %v", bools, gotBools)
+	}
+
+	empty, err := NewInt64Array("empty", nil).AsInt64Slice()
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected empty slice, got %v, %v", empty, err)
+	}
+}
+
+func TestTypedArrayHeterogeneousExtraction(t *testing.T) {
+	array := NewInt32Array("mixed", []int32{1, 2})
+	array.Append(NewStringValue("", "three"))
+
+	if _, err := array.AsInt32Slice(); !errors.Is(err, core.ErrTypeConversion) {
+		t.Fatalf("Expected ErrTypeConversion, got %v", err)
+	} else if !strings.Contains(err.Error(), "element 2 is string") {
+		t.Errorf("Expected the offending element in the error, got %v", err)
+	}
+
+	// Elements of a convertible but different type are still rejected
+	if _, err := NewInt32Array("ints", []int32{1}).AsInt64Slice(); !errors.Is(err, core.ErrTypeConversion) {
+		t.Errorf("AsInt64Slice of int elements: expected ErrTypeConversion, got %v", err)
+	}
+}
+
+func TestArrayValueElementType(t *testing.T) {
+	empty := NewArrayValue("empty")
+	if vtype, ok := empty.ElementType(); vtype != core.NullValue || !ok {
+		t.Errorf("Empty array: expected (null, true), got (%s, %v)", vtype.TypeName(), ok)
+	}
+	if !empty.IsHomogeneous() {
+		t.Error("Expected empty array to be homogeneous")
+	}
+
+	ints := NewInt32Array("ints", []int32{1, 2, 3})
+	if vtype, ok := ints.ElementType(); vtype != core.IntValue || !ok {
+		t.Errorf("Homogeneous array: expected (int, true), got (%s, %v)", vtype.TypeName(), ok)
+	}
+	if !ints.IsHomogeneous() {
+		t.Error("Expected int array to be homogeneous")
+	}
+
+	// int and llong are distinct types even though both are integers
+	mixed := NewInt32Array("mixed", []int32{1, 2})
+	mixed.Append(NewInt64Value("", 3))
+	if vtype, ok := mixed.ElementType(); vtype != core.NullValue || ok {
+		t.Errorf("Mixed array: expected (null, false), got (%s, %v)", vtype.TypeName(), ok)
+	}
+	if mixed.IsHomogeneous() {
+		t.Error("Expected mixed array not to be homogeneous")
+	}
+}
@@ -0,0 +1,141 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// UUIDValue represents a 128-bit UUID (type 17).
+//
+// The binary payload is the 16 UUID bytes in RFC 4122 (network) order.
+type UUIDValue struct {
+	*core.BaseValue
+	value [16]byte
+}
+
+// NewUUIDValue creates a new UUID value
+func NewUUIDValue(name string, id [16]byte) *UUIDValue {
+	data := make([]byte, 16)
+	copy(data, id[:])
+	return &UUIDValue{
+		BaseValue: core.NewBaseValue(name, core.UUIDValue, data),
+		value:     id,
+	}
+}
+
+// NewUUIDValueFromString parses a UUID in canonical dashed form
+// (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx) or as 32 hex digits without dashes.
+// Hex digits may be upper or lower case.
+func NewUUIDValueFromString(name string, s string) (*UUIDValue, error) {
+	id, err := parseUUID(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewUUIDValue(name, id), nil
+}
+
+// parseUUID decodes the dashed or undashed text form of a UUID
+func parseUUID(s string) ([16]byte, error) {
+	var id [16]byte
+
+	var digits string
+	switch len(s) {
+	case 36:
+		for _, i := range []int{8, 13, 18, 23} {
+			if s[i] != '-' {
+				return id, fmt.Errorf("Invalid UUID %q: expected '-' at position %d", s, i)
+			}
+		}
+		digits = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	case 32:
+		digits = s
+	default:
+		return id, fmt.Errorf("Invalid UUID %q: expected 36 characters (or 32 without dashes), got %d", s, len(s))
+	}
+
+	if _, err := hex.Decode(id[:], []byte(digits)); err != nil {
+		return id, fmt.Errorf("Invalid UUID %q: %v", s, err)
+	}
+	return id, nil
+}
+
+// ToString returns the UUID in lower-case canonical dashed form
+func (v *UUIDValue) ToString() (string, error) {
+	var buf [36]byte
+	hex.Encode(buf[0:8], v.value[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], v.value[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], v.value[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], v.value[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], v.value[10:16])
+	return string(buf[:]), nil
+}
+
+// Value returns the 16 UUID bytes
+func (v *UUIDValue) Value() [16]byte {
+	return v.value
+}
+
+// ToBytes implements complete binary format with header
+// Format: [type:1][name_len:4][name][value_size:4][uuid:16]
+func (v *UUIDValue) ToBytes() ([]byte, error) {
+	return core.FrameBytes(v)
+}
+
+// Clone returns a deep copy of the value
+func (v *UUIDValue) Clone() core.Value {
+	return NewUUIDValue(v.Name(), v.value)
+}
+
+// ToJSON returns the JSON representation with the UUID in canonical form
+func (v *UUIDValue) ToJSON() (string, error) {
+	str, _ := v.ToString()
+	jsonVal := map[string]interface{}{
+		"name": v.Name(),
+		"type": v.Type().TypeName(),
+		"data": str,
+	}
+
+	data, err := json.MarshalIndent(jsonVal, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ToXML returns the XML representation with the UUID in canonical form
+func (v *UUIDValue) ToXML() (string, error) {
+	type XMLUUIDValue struct {
+		XMLName xml.Name `xml:"value"`
+		Name    string   `xml:"name,attr"`
+		Type    string   `xml:"type,attr"`
+		Data    string   `xml:",chardata"`
+	}
+
+	str, _ := v.ToString()
+	xmlVal := XMLUUIDValue{
+		Name: v.Name(),
+		Type: v.Type().TypeName(),
+		Data: str,
+	}
+
+	data, err := xml.MarshalIndent(xmlVal, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,134 @@
+/****************************************************************************
+BSD 3-Clause License
+
+Copyright (c) 2021, 🍀☀🌕🌥 🌊
+All rights reserved.
+****************************************************************************/
+
+package values
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+var testUUID = [16]byte{
+	0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3,
+	0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00,
+}
+
+const testUUIDString = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestUUIDValue_TypeMetadata(t *testing.T) {
+	if core.UUIDValue != 17 {
+		t.Errorf("Expected UUIDValue type code 17, got %d", core.UUIDValue)
+	}
+	if core.UUIDValue.String() != "17" {
+		t.Errorf("Expected String() '17', got '%s'", core.UUIDValue.String())
+	}
+	if core.ParseValueType("17") != core.UUIDValue {
+		t.Errorf("ParseValueType(\"17\") returned %v", core.ParseValueType("17"))
+	}
+	if core.UUIDValue.TypeName() != "uuid" {
+		t.Errorf("Expected TypeName 'uuid', got '%s'", core.UUIDValue.TypeName())
+	}
+	if !core.UUIDValue.IsDefined() {
+		t.Error("UUIDValue should be a defined type")
+	}
+}
+
+func TestUUIDValue_ToString(t *testing.T) {
+	uv := NewUUIDValue("correlation_id", testUUID)
+	str, err := uv.ToString()
+	if err != nil {
+		t.Fatalf("ToString failed: %v", err)
+	}
+	if str != testUUIDString {
+		t.Errorf("Expected %s, got %s", testUUIDString, str)
+	}
+	if !bytes.Equal(uv.Data(), testUUID[:]) {
+		t.Errorf("Expected 16-byte payload %x, got %x", testUUID, uv.Data())
+	}
+}
+
+func TestUUIDValue_BinaryRoundTrip(t *testing.T) {
+	uv := NewUUIDValue("correlation_id", testUUID)
+
+	data, err := uv.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+	// type(1) + name_len(4) + name(14) + value_size(4) + value(16)
+	if len(data) != 39 {
+		t.Errorf("Expected 39 bytes, got %d", len(data))
+	}
+	if uv.SerializedSize() != len(data) {
+		t.Errorf("SerializedSize %d does not match ToBytes length %d", uv.SerializedSize(), len(data))
+	}
+
+	restored, bytesRead, err := deserializeValue(data)
+	if err != nil {
+		t.Fatalf("Deserialization failed: %v", err)
+	}
+	if bytesRead != len(data) {
+		t.Errorf("Expected %d bytes read, got %d", len(data), bytesRead)
+	}
+	restoredUUID, ok := restored.(*UUIDValue)
+	if !ok {
+		t.Fatalf("Expected *UUIDValue, got %T", restored)
+	}
+	if restoredUUID.Name() != "correlation_id" || restoredUUID.Value() != testUUID {
+		t.Errorf("Round trip mismatch: %s %x", restoredUUID.Name(), restoredUUID.Value())
+	}
+
+	rebuilt, err := NewValueFromData("correlation_id", core.UUIDValue, testUUID[:])
+	if err != nil {
+		t.Fatalf("NewValueFromData failed: %v", err)
+	}
+	if str, _ := rebuilt.ToString(); str != testUUIDString {
+		t.Errorf("Factory round trip: expected %s, got %s", testUUIDString, str)
+	}
+	if _, err := NewValueFromData("bad", core.UUIDValue, []byte{1, 2, 3}); err == nil {
+		t.Error("Expected payload size error")
+	}
+}
+
+func TestUUIDValue_Parse(t *testing.T) {
+	valid := []string{
+		testUUIDString,
+		strings.ToUpper(testUUIDString),
+		strings.ReplaceAll(testUUIDString, "-", ""),
+	}
+	for _, s := range valid {
+		uv, err := NewUUIDValueFromString("id", s)
+		if err != nil {
+			t.Errorf("Parse %q failed: %v", s, err)
+			continue
+		}
+		if uv.Value() != testUUID {
+			t.Errorf("Parse %q: expected %x, got %x", s, testUUID, uv.Value())
+		}
+	}
+
+	invalid := map[string]string{
+		"Empty":          "",
+		"TooShort":       "123e4567-e89b-12d3-a456-42661417400",
+		"TooLong":        testUUIDString + "0",
+		"MisplacedDash":  "123e4567e-89b-12d3-a456-426614174000",
+		"NonHex":         "123e4567-e89b-12d3-a456-42661417400g",
+		"Braced":         "{" + testUUIDString + "}",
+		"UndashedNonHex": "zz3e4567e89b12d3a456426614174000",
+	}
+	for name, s := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewUUIDValueFromString("id", s); err == nil {
+				t.Errorf("Expected parse error for %q", s)
+			} else if !strings.Contains(err.Error(), "Invalid UUID") {
+				t.Errorf("Unexpected error message: %v", err)
+			}
+		})
+	}
+}
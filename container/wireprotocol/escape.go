@@ -0,0 +1,120 @@
+package wireprotocol
+
+import (
+	"strings"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// cppSpecialChars are the characters percent-encoded in names, string data
+// and header fields: the frame delimiters, '%' itself, and the line breaks
+// that DeserializeCppWire strips.
+//
+// The encoding is specific to this package. A frame is escaped only when
+// one of its fields holds a special character, and it then carries the
+// escapingField header marker; every other frame is written and read
+// verbatim, as before, so existing data keeps its meaning. Readers without
+// marker support see the escaped fields percent-encoded.
+const cppSpecialChars = "%,;[]{}\r\n"
+
+// escapeCppField percent-encodes the wire delimiters in s (e.g. ';' becomes
+// "%3B") so that a field cannot end its [name,type,data]; cell or section
+// early. Fields without special characters are returned unchanged.
+func escapeCppField(s string) string {
+	if !strings.ContainsAny(s, cppSpecialChars) {
+		return s
+	}
+	const hexDigits = "0123456789ABCDEF"
+	var sb strings.Builder
+	sb.Grow(len(s) + 8)
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; strings.IndexByte(cppSpecialChars, c) >= 0 {
+			sb.WriteByte('%')
+			sb.WriteByte(hexDigits[c>>4])
+			sb.WriteByte(hexDigits[c&0x0F])
+		} else {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// unescapeCppField reverses escapeCppField. It is applied only to frames
+// whose header carries the escapingField marker; a '%' that is not followed
+// by two hex digits is kept as is.
+func unescapeCppField(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) && isHexDigit(s[i+1]) && isHexDigit(s[i+2]) {
+			sb.WriteByte(hexValue(s[i+1])<<4 | hexValue(s[i+2]))
+			i += 2
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func hexValue(c byte) byte {
+	switch {
+	case c <= '9':
+		return c - '0'
+	case c <= 'F':
+		return c - 'A' + 10
+	default:
+		return c - 'a' + 10
+	}
+}
+
+// headerNeedsEscaping reports whether a header field written to the wire
+// holds a special character
+func headerNeedsEscaping(h core.Header) bool {
+	for _, f := range []string{h.SourceID, h.SourceSubID, h.TargetID, h.TargetSubID, h.MessageType, h.Version} {
+		if strings.ContainsAny(f, cppSpecialChars) {
+			return true
+		}
+	}
+	return false
+}
+
+// valuesNeedEscaping reports whether a name or string data in vals, or in
+// their nested children, elements and map entries, holds a special character
+func valuesNeedEscaping(vals []core.Value) bool {
+	for _, v := range vals {
+		if strings.ContainsAny(v.Name(), cppSpecialChars) {
+			return true
+		}
+		switch v.Type() {
+		case core.StringValue:
+			if s, err := v.ToString(); err == nil && strings.ContainsAny(s, cppSpecialChars) {
+				return true
+			}
+		case core.ContainerValue:
+			if valuesNeedEscaping(v.Children()) {
+				return true
+			}
+		case core.ArrayValue:
+			if arr, ok := v.(interface{ Elements() []core.Value }); ok && valuesNeedEscaping(arr.Elements()) {
+				return true
+			}
+		case core.MapValue:
+			if m, ok := v.(cppMap); ok {
+				for _, key := range m.Keys() {
+					entry, _ := m.Get(key)
+					if strings.ContainsAny(key, cppSpecialChars) || valuesNeedEscaping([]core.Value{entry}) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package wireprotocol
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+// Format identifies a serialized container layout that can be split into
+// header and payload without decoding the values
+type Format int
+
+const (
+	// FormatCppWire is the C++ wire protocol: @header={{...}};@data={{...}};
+	FormatCppWire Format = iota
+	// FormatText is the legacy pipe-delimited text format: header\nvalues
+	FormatText
+)
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case FormatCppWire:
+		return "cpp_wire"
+	case FormatText:
+		return "text"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// ErrHeaderNotFound is returned when the header section cannot be located
+var ErrHeaderNotFound = errors.New("header section not found")
+
+var cppHeaderSectionRegex = regexp.MustCompile(`^\s*@header=\s*\{\{?\s*(.*?)\s*\}\}?;`)
+
+// SplitHeaderPayload extracts the header from serialized container data and
+// returns the remaining value bytes untouched.
+//
+// This lets a broker inspect or rewrite routing fields without deserializing
+// the values. The payload is a sub-slice of data and must be passed to
+// JoinHeaderPayload with the same format.
+func SplitHeaderPayload(data []byte, format Format) (core.Header, []byte, error) {
+	switch format {
+	case FormatCppWire:
+		match := cppHeaderSectionRegex.FindSubmatchIndex(data)
+		if match == nil {
+			return core.Header{}, nil, ErrHeaderNotFound
+		}
+		header := parseCppHeader(string(data[match[2]:match[3]]))
+		return header, data[match[1]:], nil
+
+	case FormatText:
+		newline := bytes.IndexByte(data, '\n')
+		if newline == -1 {
+			return core.Header{}, nil, ErrHeaderNotFound
+		}
+		fields := core.SplitTextFields(string(data[:newline]))
+		if len(fields) < 6 {
+			return core.Header{}, nil, fmt.Errorf("text header has %d fields, expected 6", len(fields))
+		}
+		header := core.Header{
+			SourceID:    fields[0],
+			SourceSubID: fields[1],
+			TargetID:    fields[2],
+			TargetSubID: fields[3],
+			MessageType: fields[4],
+			Version:     fields[5],
+		}
+		return header, data[newline+1:], nil
+
+	default:
+		return core.Header{}, nil, fmt.Errorf("unsupported format: %v", format)
+	}
+}
+
+// JoinHeaderPayload serializes the header in the given format and appends
+// the payload previously returned by SplitHeaderPayload.
+//
+// For FormatCppWire the header keeps the WireEscaped flag reported by
+// SplitHeaderPayload, so an escaped payload is still unescaped when read.
+// A header field holding a special character also turns the marker on,
+// and the marker then applies to the payload as well.
+func JoinHeaderPayload(h core.Header, payload []byte, format Format) []byte {
+	var result strings.Builder
+
+	switch format {
+	case FormatText:
+		result.Grow(64 + len(payload))
+		result.WriteString(strings.Join([]string{
+			core.EscapeTextField(h.SourceID),
+			core.EscapeTextField(h.SourceSubID),
+			core.EscapeTextField(h.TargetID),
+			core.EscapeTextField(h.TargetSubID),
+			core.EscapeTextField(h.MessageType),
+			core.EscapeTextField(h.Version),
+		}, "|"))
+		result.WriteByte('\n')
+	default:
+		result.Grow(128 + len(payload))
+		h.WireEscaped = h.WireEscaped || headerNeedsEscaping(h)
+		writeCppHeader(&result, h)
+	}
+
+	result.Write(payload)
+	return []byte(result.String())
+}
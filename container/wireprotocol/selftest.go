@@ -0,0 +1,21 @@
+package wireprotocol
+
+import "github.com/kcenon/go_container_system/container/core"
+
+func init() {
+	core.RegisterSelfTestCodec(core.SelfTestCodec{
+		Name: "wire",
+		Encode: func(c *core.ValueContainer) ([]byte, error) {
+			wire, err := SerializeCppWire(c)
+			return []byte(wire), err
+		},
+		Decode: func(data []byte) (*core.ValueContainer, error) {
+			return DeserializeCppWire(string(data))
+		},
+		Supports: func(vtype core.ValueType) bool {
+			// valueTypeToCppName falls back to null_value for types the
+			// C++ wire format has no name for
+			return vtype == core.NullValue || valueTypeToCppName(vtype) != "null_value"
+		},
+	})
+}
@@ -1,11 +1,15 @@
 package wireprotocol
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kcenon/go_container_system/container/core"
 	"github.com/kcenon/go_container_system/container/values"
@@ -19,6 +23,22 @@ const (
 	sourceSubIDField   = 4
 	messageTypeField   = 5
 	messageVersionField = 6
+	// createdAtField carries the optional creation timestamp in epoch
+	// milliseconds (reserved; not written by the C++ implementation)
+	createdAtField = 7
+	// escapingField marks a frame whose names, string data and header
+	// fields are percent-encoded; its value names the scheme (reserved; not
+	// written by the C++ implementation)
+	escapingField = 8
+)
+
+// percentEscaping is the escapingField value for escapeCppField encoding
+const percentEscaping = "percent"
+
+// Section markers of the wire frame
+const (
+	cppDataStart  = "@data={{"
+	cppSectionEnd = "}};"
 )
 
 // SerializeCppWire serializes a ValueContainer to C++ wire protocol format
@@ -37,55 +57,192 @@ const (
 func SerializeCppWire(c *core.ValueContainer) (string, error) {
 	var result strings.Builder
 	result.Grow(512) // Pre-allocate buffer
+	if _, err := WriteCppWire(&result, c); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
 
-	// Serialize header
-	result.WriteString("@header={{")
+// WriteCppWire writes the SerializeCppWire form of c to w, one value at a
+// time, and returns the number of bytes written. Values that fail to
+// serialize are skipped, as in SerializeCppWire.
+func WriteCppWire(w io.Writer, c *core.ValueContainer) (int, error) {
+	h := c.Header()
+	vals := c.Values()
+	h.WireEscaped = headerNeedsEscaping(h) || valuesNeedEscaping(vals)
 
-	// Only include routing fields if message_type is not "data_container"
-	messageType := c.MessageType()
-	if messageType != "data_container" {
-		targetID := c.TargetID()
-		targetSubID := c.TargetSubID()
-		if targetID != "" || targetSubID != "" {
-			result.WriteString(fmt.Sprintf("[%d,%s];", targetIDField, targetID))
-			result.WriteString(fmt.Sprintf("[%d,%s];", targetSubIDField, targetSubID))
+	var header strings.Builder
+	writeCppHeader(&header, h)
+	header.WriteString(cppDataStart)
+
+	written, err := io.WriteString(w, header.String())
+	if err != nil {
+		return written, err
+	}
+	for _, value := range vals {
+		serialized, err := serializeValueCpp(value)
+		if err != nil {
+			// Skip values that fail to serialize
+			continue
 		}
-		sourceID := c.SourceID()
-		sourceSubID := c.SourceSubID()
-		if sourceID != "" || sourceSubID != "" {
-			result.WriteString(fmt.Sprintf("[%d,%s];", sourceIDField, sourceID))
-			result.WriteString(fmt.Sprintf("[%d,%s];", sourceSubIDField, sourceSubID))
+		n, err := io.WriteString(w, serialized)
+		written += n
+		if err != nil {
+			return written, err
 		}
 	}
+	n, err := io.WriteString(w, cppSectionEnd)
+	return written + n, err
+}
 
-	// Always include message_type and version
-	result.WriteString(fmt.Sprintf("[%d,%s];", messageTypeField, messageType))
-	result.WriteString(fmt.Sprintf("[%d,%s];", messageVersionField, c.Version()))
-	result.WriteString("}};")
+// DefaultMaxFrameSize is the frame size limit used by ReadCppWire
+const DefaultMaxFrameSize = 64 << 20 // 64 MiB
 
-	// Serialize data
-	result.WriteString("@data={{")
+// ReadCppWire reads one @header={{...}};@data={{...}}; frame from r and
+// deserializes it like DeserializeCppWire. The frame ends at the first
+// "}};" after "@data={{", and r is read one byte at a time so nothing
+// beyond the frame is consumed; wrap unbuffered readers such as network
+// connections in a bufio.Reader (it is used as an io.ByteReader).
+//
+// io.EOF is returned when r ends before a frame starts (only whitespace
+// was read), and io.ErrUnexpectedEOF when it ends inside a frame. A frame
+// longer than DefaultMaxFrameSize fails with core.ErrFrameTooLarge; use
+// ReadCppWireLimit to choose another limit.
+func ReadCppWire(r io.Reader) (*core.ValueContainer, error) {
+	return ReadCppWireLimit(r, DefaultMaxFrameSize)
+}
 
-	// Serialize all values
-	for _, value := range c.Values() {
-		serialized, err := serializeValueCpp(value)
+// ReadCppWireLimit is ReadCppWire with a maximum frame size in bytes. Once
+// maxSize bytes have been read without reaching the end of the frame it
+// returns an error wrapping core.ErrFrameTooLarge, leaving r positioned
+// inside that frame. A maxSize of zero or less means no limit.
+func ReadCppWireLimit(r io.Reader, maxSize int) (*core.ValueContainer, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+
+	var frame []byte
+	dataStart := newSuffixMatcher(cppDataStart)
+	sectionEnd := newSuffixMatcher(cppSectionEnd)
+	inData := false // "@data={{" seen
+	for {
+		if maxSize > 0 && len(frame) >= maxSize {
+			return nil, fmt.Errorf("%w: more than %d bytes", core.ErrFrameTooLarge, maxSize)
+		}
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			if len(bytes.TrimSpace(frame)) == 0 {
+				return nil, io.EOF
+			}
+			return nil, io.ErrUnexpectedEOF
+		}
 		if err != nil {
-			// Skip values that fail to serialize
-			continue
+			return nil, err
+		}
+		frame = append(frame, b)
+
+		if !inData {
+			inData = dataStart.next(b)
+		} else if sectionEnd.next(b) {
+			return DeserializeCppWire(string(frame))
 		}
-		result.WriteString(serialized)
 	}
+}
 
-	result.WriteString("}};")
+// suffixMatcher reports when the bytes fed to it end with pattern, using the
+// KMP failure table so each byte is examined in constant amortized time
+type suffixMatcher struct {
+	pattern string
+	fail    []int
+	matched int
+}
 
-	return result.String(), nil
+func newSuffixMatcher(pattern string) *suffixMatcher {
+	fail := make([]int, len(pattern))
+	for i, k := 1, 0; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = fail[k-1]
+		}
+		if pattern[i] == pattern[k] {
+			k++
+		}
+		fail[i] = k
+	}
+	return &suffixMatcher{pattern: pattern, fail: fail}
+}
+
+// next feeds one byte and reports whether the input now ends with pattern
+func (m *suffixMatcher) next(b byte) bool {
+	if m.matched == len(m.pattern) {
+		m.matched = m.fail[m.matched-1]
+	}
+	for m.matched > 0 && m.pattern[m.matched] != b {
+		m.matched = m.fail[m.matched-1]
+	}
+	if m.pattern[m.matched] == b {
+		m.matched++
+	}
+	return m.matched == len(m.pattern)
+}
+
+// byteReader reads single bytes from an io.Reader without buffering ahead
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(b.r, b.buf[:]); err != nil {
+		return 0, err
+	}
+	return b.buf[0], nil
+}
+
+// writeCppHeader writes the @header={{...}}; section for the given header.
+// When h.WireEscaped is set the escapingField marker is written, and with
+// it readers unescape the fields; otherwise no field needs escaping and the
+// frame matches the C++ output byte for byte.
+func writeCppHeader(result *strings.Builder, h core.Header) {
+	field := func(s string) string {
+		if h.WireEscaped {
+			return escapeCppField(s)
+		}
+		return s
+	}
+	result.WriteString("@header={{")
+
+	// Only include routing fields if message_type is not "data_container"
+	if h.MessageType != "data_container" {
+		if h.TargetID != "" || h.TargetSubID != "" {
+			result.WriteString(fmt.Sprintf("[%d,%s];", targetIDField, field(h.TargetID)))
+			result.WriteString(fmt.Sprintf("[%d,%s];", targetSubIDField, field(h.TargetSubID)))
+		}
+		if h.SourceID != "" || h.SourceSubID != "" {
+			result.WriteString(fmt.Sprintf("[%d,%s];", sourceIDField, field(h.SourceID)))
+			result.WriteString(fmt.Sprintf("[%d,%s];", sourceSubIDField, field(h.SourceSubID)))
+		}
+	}
+
+	// Always include message_type and version
+	result.WriteString(fmt.Sprintf("[%d,%s];", messageTypeField, field(h.MessageType)))
+	result.WriteString(fmt.Sprintf("[%d,%s];", messageVersionField, field(h.Version)))
+	if !h.CreatedAt.IsZero() {
+		result.WriteString(fmt.Sprintf("[%d,%d];", createdAtField, h.CreatedAt.UnixMilli()))
+	}
+	if h.WireEscaped {
+		result.WriteString(fmt.Sprintf("[%d,%s];", escapingField, percentEscaping))
+	}
+	result.WriteString("}};")
 }
 
 // serializeValueCpp serializes a single value to C++ wire protocol format
 //
 // Format: [name,type_name,data];
+// Delimiter characters in the name and in string data are percent-encoded;
+// the caller marks the frame as escaped whenever valuesNeedEscaping holds.
 func serializeValueCpp(value core.Value) (string, error) {
-	name := value.Name()
+	name := escapeCppField(value.Name())
 	valueType := value.Type()
 	typeName := valueTypeToCppName(valueType)
 
@@ -155,19 +312,19 @@ func serializeValueCpp(value core.Value) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		dataStr = fmt.Sprintf("%g", val)
+		dataStr = formatCppFloat(float64(val), 32)
 	case core.DoubleValue:
 		val, err := value.ToFloat64()
 		if err != nil {
 			return "", err
 		}
-		dataStr = fmt.Sprintf("%g", val)
+		dataStr = formatCppFloat(val, 64)
 	case core.StringValue:
 		val, err := value.ToString()
 		if err != nil {
 			return "", err
 		}
-		dataStr = val
+		dataStr = escapeCppField(val)
 	case core.BytesValue:
 		// Convert raw bytes to hex string (matching C++ hex encoding)
 		// Use Data() to get raw bytes, not ToBytes() which returns binary format
@@ -175,38 +332,71 @@ func serializeValueCpp(value core.Value) (string, error) {
 		dataStr = hex.EncodeToString(rawBytes) // lowercase hex
 	case core.ContainerValue:
 		// For containers, serialize with child count and all children recursively
-		if containerVal, ok := value.(*values.ContainerValue); ok {
-			childCount := containerVal.ChildCount()
-			// Container header
-			result := fmt.Sprintf("[%s,%s,%d];", name, typeName, childCount)
-			// Serialize all children recursively
-			for _, child := range containerVal.Children() {
-				childSer, err := serializeValueCpp(child)
-				if err != nil {
-					continue
-				}
-				result += childSer
+		// (matches C++ container_value::serialize). A child that fails to
+		// serialize fails the whole container so the count stays accurate.
+		children := value.Children()
+		// Container header
+		result := fmt.Sprintf("[%s,%s,%d];", name, typeName, len(children))
+		// Serialize all children recursively
+		for _, child := range children {
+			childSer, err := serializeValueCpp(child)
+			if err != nil {
+				return "", fmt.Errorf("container %s: %w", name, err)
 			}
-			return result, nil
+			result += childSer
 		}
-		dataStr = "0"
+		return result, nil
 	case core.ArrayValue:
-		// For arrays, serialize with element count and all elements recursively
-		if arrayVal, ok := value.(*values.ArrayValue); ok {
-			elementCount := arrayVal.Count()
-			// Array header
-			result := fmt.Sprintf("[%s,%s,%d];", name, typeName, elementCount)
-			// Serialize all elements recursively
-			for _, element := range arrayVal.Elements() {
-				elemSer, err := serializeValueCpp(element)
-				if err != nil {
-					continue
-				}
-				result += elemSer
+		// For arrays, serialize with element count and all elements inline
+		// using the same [name,type,data]; cell grammar as top-level values
+		arrayVal, ok := value.(interface{ Elements() []core.Value })
+		if !ok {
+			return "", fmt.Errorf("array %s does not expose its elements", name)
+		}
+		elements := arrayVal.Elements()
+		// Array header
+		result := fmt.Sprintf("[%s,%s,%d];", name, typeName, len(elements))
+		// Serialize all elements recursively
+		for _, element := range elements {
+			elemSer, err := serializeValueCpp(element)
+			if err != nil {
+				return "", fmt.Errorf("array %s: %w", name, err)
+			}
+			result += elemSer
+		}
+		return result, nil
+	case core.DateTimeValue:
+		// Nanoseconds since the Unix epoch, as in the binary payload
+		val, err := value.ToInt64()
+		if err != nil {
+			return "", err
+		}
+		dataStr = strconv.FormatInt(val, 10)
+	case core.UUIDValue, core.DecimalValue:
+		// Canonical text form: 8-4-4-4-12 hex UUID, or plain decimal digits
+		val, err := value.ToString()
+		if err != nil {
+			return "", err
+		}
+		dataStr = val
+	case core.MapValue:
+		// For maps, serialize with entry count, then per entry (in sorted key
+		// order) a [key,string_value,<key>]; cell followed by the entry value
+		mapVal, ok := value.(cppMap)
+		if !ok {
+			return "", fmt.Errorf("map %s does not expose its entries", name)
+		}
+		keys := mapVal.Keys()
+		result := fmt.Sprintf("[%s,%s,%d];", name, typeName, len(keys))
+		for _, key := range keys {
+			entry, _ := mapVal.Get(key)
+			entrySer, err := serializeValueCpp(entry)
+			if err != nil {
+				return "", fmt.Errorf("map %s: %w", name, err)
 			}
-			return result, nil
+			result += fmt.Sprintf("[%s,string_value,%s];", cppMapKeyName, escapeCppField(key)) + entrySer
 		}
-		dataStr = "0"
+		return result, nil
 	case core.NullValue:
 		dataStr = ""
 	default:
@@ -216,6 +406,28 @@ func serializeValueCpp(value core.Value) (string, error) {
 	return fmt.Sprintf("[%s,%s,%s];", name, typeName, dataStr), nil
 }
 
+// formatCppFloat formats a float or double the way C++ std::to_string
+// does: fixed notation with six decimals ("%f"), and "nan", "inf" or "-inf"
+// for non-finite values. std::to_string drops digits beyond the sixth
+// decimal, so when its text would not parse back to v (e.g. 1e-7) the
+// shortest fixed notation that does is written instead; C++ readers parse
+// both with strtod. bitSize is 32 for float_value and 64 for double_value.
+func formatCppFloat(v float64, bitSize int) string {
+	switch {
+	case math.IsNaN(v):
+		return "nan"
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	}
+	fixed := strconv.FormatFloat(v, 'f', 6, 64)
+	if parsed, err := strconv.ParseFloat(fixed, bitSize); err == nil && parsed == v {
+		return fixed
+	}
+	return strconv.FormatFloat(v, 'f', -1, bitSize)
+}
+
 // valueTypeToCppName converts ValueType to C++ type name string
 func valueTypeToCppName(vt core.ValueType) string {
 	switch vt {
@@ -251,11 +463,28 @@ func valueTypeToCppName(vt core.ValueType) string {
 		return "array_value"
 	case core.NullValue:
 		return "null_value"
+	case core.DateTimeValue:
+		return "datetime_value"
+	case core.UUIDValue:
+		return "uuid_value"
+	case core.DecimalValue:
+		return "decimal_value"
+	case core.MapValue:
+		return "map_value"
 	default:
 		return "null_value"
 	}
 }
 
+// cppMapKeyName names the string cell that carries a map entry's key
+const cppMapKeyName = "key"
+
+// cppMap is the part of values.MapValue the wire codec needs
+type cppMap interface {
+	Keys() []string
+	Get(key string) (core.Value, bool)
+}
+
 // cppNameToValueType converts C++ type name string to ValueType
 func cppNameToValueType(name string) (core.ValueType, error) {
 	switch name {
@@ -291,8 +520,16 @@ func cppNameToValueType(name string) (core.ValueType, error) {
 		return core.ArrayValue, nil
 	case "null_value":
 		return core.NullValue, nil
+	case "datetime_value":
+		return core.DateTimeValue, nil
+	case "uuid_value":
+		return core.UUIDValue, nil
+	case "decimal_value":
+		return core.DecimalValue, nil
+	case "map_value":
+		return core.MapValue, nil
 	default:
-		return core.NullValue, fmt.Errorf("unknown C++ type name: %s", name)
+		return core.NullValue, fmt.Errorf("%w: unknown C++ type name %q", core.ErrUnknownValueType, name)
 	}
 }
 
@@ -302,6 +539,10 @@ func cppNameToValueType(name string) (core.ValueType, error) {
 // Rust container_system, or any other system using the C++ wire protocol.
 //
 // Format: @header={{[id,value];...}};@data={{[name,type,data];...}};
+//
+// An error is returned if a long_value or ulong_value lies outside the
+// 32-bit range enforced by NewLongValue and NewULongValue, or if a value has
+// a type name this parser does not know (wrapping core.ErrUnknownValueType).
 func DeserializeCppWire(wireData string) (*core.ValueContainer, error) {
 	// Remove newlines for easier parsing
 	cleanData := strings.ReplaceAll(wireData, "\r\n", "")
@@ -313,52 +554,22 @@ func DeserializeCppWire(wireData string) (*core.ValueContainer, error) {
 	headerRegex := regexp.MustCompile(`@header=\s*\{\{?\s*(.*?)\s*\}\}?;`)
 	headerMatch := headerRegex.FindStringSubmatch(cleanData)
 
-	var targetID, targetSubID, sourceID, sourceSubID, messageType string
-
+	var header core.Header
 	if len(headerMatch) > 1 {
-		headerContent := headerMatch[1]
-
-		// Parse header pairs: [id,value];
-		pairRegex := regexp.MustCompile(`\[(\d+),(.*?)\];`)
-		pairMatches := pairRegex.FindAllStringSubmatch(headerContent, -1)
-
-		for _, match := range pairMatches {
-			if len(match) < 3 {
-				continue
-			}
-
-			id, err := strconv.Atoi(match[1])
-			if err != nil {
-				continue
-			}
-			value := strings.TrimSpace(match[2])
-
-			switch id {
-			case targetIDField:
-				targetID = value
-			case targetSubIDField:
-				targetSubID = value
-			case sourceIDField:
-				sourceID = value
-			case sourceSubIDField:
-				sourceSubID = value
-			case messageTypeField:
-				messageType = value
-			// version field is read-only, ignore it
-			}
-		}
+		header = parseCppHeader(headerMatch[1])
 	}
 
 	// Apply header fields to container
-	if targetID != "" || targetSubID != "" {
-		container.SetTarget(targetID, targetSubID)
+	if header.TargetID != "" || header.TargetSubID != "" {
+		container.SetTarget(header.TargetID, header.TargetSubID)
 	}
-	if sourceID != "" || sourceSubID != "" {
-		container.SetSource(sourceID, sourceSubID)
+	if header.SourceID != "" || header.SourceSubID != "" {
+		container.SetSource(header.SourceID, header.SourceSubID)
 	}
-	if messageType != "" {
-		container.SetMessageType(messageType)
+	if header.MessageType != "" {
+		container.SetMessageType(header.MessageType)
 	}
+	container.SetCreatedAt(header.CreatedAt)
 
 	// Parse data section
 	dataRegex := regexp.MustCompile(`@data=\s*\{\{?\s*(.*?)\s*\}\}?;`)
@@ -368,7 +579,10 @@ func DeserializeCppWire(wireData string) (*core.ValueContainer, error) {
 		dataContent := dataMatch[1]
 
 		// Parse values using recursive parser that supports nested containers/arrays
-		parsedValues, _ := parseValuesRecursive(dataContent)
+		parsedValues, _, err := parseValuesRecursive(dataContent, header.WireEscaped)
+		if err != nil {
+			return nil, err
+		}
 		for _, parsedValue := range parsedValues {
 			container.AddValue(parsedValue)
 		}
@@ -377,9 +591,69 @@ func DeserializeCppWire(wireData string) (*core.ValueContainer, error) {
 	return container, nil
 }
 
+// parseCppHeader parses the content of a @header section ([id,value]; pairs).
+// The version field is captured in the returned header but is not applied
+// by DeserializeCppWire, which treats it as read-only. Field values are
+// unescaped only when the escapingField marker is present, so frames from
+// writers that do not escape are read verbatim.
+func parseCppHeader(headerContent string) core.Header {
+	var header core.Header
+
+	// Parse header pairs: [id,value];
+	pairRegex := regexp.MustCompile(`\[(\d+),(.*?)\];`)
+	pairMatches := pairRegex.FindAllStringSubmatch(headerContent, -1)
+
+	for _, match := range pairMatches {
+		if len(match) >= 3 && match[1] == strconv.Itoa(escapingField) {
+			header.WireEscaped = strings.TrimSpace(match[2]) == percentEscaping
+		}
+	}
+
+	for _, match := range pairMatches {
+		if len(match) < 3 {
+			continue
+		}
+
+		id, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		value := strings.TrimSpace(match[2])
+		if header.WireEscaped {
+			value = unescapeCppField(value)
+		}
+
+		switch id {
+		case targetIDField:
+			header.TargetID = value
+		case targetSubIDField:
+			header.TargetSubID = value
+		case sourceIDField:
+			header.SourceID = value
+		case sourceSubIDField:
+			header.SourceSubID = value
+		case messageTypeField:
+			header.MessageType = value
+		case messageVersionField:
+			header.Version = value
+		case createdAtField:
+			// An unparsable timestamp is ignored like an unknown field
+			if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+				header.CreatedAt = time.UnixMilli(ms).UTC()
+			}
+		}
+	}
+
+	return header
+}
+
 // parseValuesRecursive parses wire protocol values with support for nested containers and arrays.
 // It returns the parsed values and the remaining unparsed content.
-func parseValuesRecursive(content string) ([]core.Value, string) {
+// Parsing stops at the first malformed value; an error is returned for a
+// value with an unknown type name (wrapping core.ErrUnknownValueType) and for
+// values that are well-formed but fail validation. Names and string data are
+// unescaped when escaped is set.
+func parseValuesRecursive(content string, escaped bool) ([]core.Value, string, error) {
 	var result []core.Value
 
 	for len(content) > 0 {
@@ -395,7 +669,10 @@ func parseValuesRecursive(content string) ([]core.Value, string) {
 		}
 
 		// Parse single value and get remaining content
-		parsedValue, remaining := parseSingleValue(content)
+		parsedValue, remaining, err := parseSingleValue(content, escaped)
+		if err != nil {
+			return nil, content, err
+		}
 		if parsedValue == nil {
 			break
 		}
@@ -404,21 +681,23 @@ func parseValuesRecursive(content string) ([]core.Value, string) {
 		content = remaining
 	}
 
-	return result, content
+	return result, content, nil
 }
 
 // parseSingleValue parses a single value from wire protocol format.
 // Returns the parsed value and remaining content, or nil if parsing fails.
-func parseSingleValue(content string) (core.Value, string) {
+// An error is returned when the value is well-formed but out of range for
+// its type, e.g. a long_value outside the 32-bit range.
+func parseSingleValue(content string, escaped bool) (core.Value, string, error) {
 	content = strings.TrimSpace(content)
 	if len(content) == 0 || content[0] != '[' {
-		return nil, content
+		return nil, content, nil
 	}
 
 	// Find the closing '];' for this value
 	closingIdx := strings.Index(content, "];")
 	if closingIdx == -1 {
-		return nil, content
+		return nil, content, nil
 	}
 
 	// Extract the value content (without brackets)
@@ -428,16 +707,19 @@ func parseSingleValue(content string) (core.Value, string) {
 	// Parse the value: name,type,data
 	parts := strings.SplitN(valueContent, ",", 3)
 	if len(parts) < 3 {
-		return nil, remaining
+		return nil, remaining, nil
 	}
 
 	name := strings.TrimSpace(parts[0])
 	typeName := strings.TrimSpace(parts[1])
 	dataStr := strings.TrimSpace(parts[2])
+	if escaped {
+		name = unescapeCppField(name)
+	}
 
 	valueType, err := cppNameToValueType(typeName)
 	if err != nil {
-		return nil, remaining
+		return nil, remaining, err
 	}
 
 	var parsedValue core.Value
@@ -450,67 +732,92 @@ func parseSingleValue(content string) (core.Value, string) {
 	case core.ShortValue:
 		val, err := strconv.ParseInt(dataStr, 10, 16)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewInt16Value(name, int16(val))
 
 	case core.UShortValue:
 		val, err := strconv.ParseUint(dataStr, 10, 16)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewUInt16Value(name, uint16(val))
 
 	case core.IntValue:
 		val, err := strconv.ParseInt(dataStr, 10, 32)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewInt32Value(name, int32(val))
 
 	case core.UIntValue:
 		val, err := strconv.ParseUint(dataStr, 10, 32)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewUInt32Value(name, uint32(val))
 
-	case core.LongValue, core.LLongValue:
+	case core.LongValue:
+		val, err := strconv.ParseInt(dataStr, 10, 64)
+		if err != nil {
+			return nil, remaining, nil
+		}
+		longVal, err := values.NewLongValue(name, val)
+		if err != nil {
+			return nil, remaining, err
+		}
+		parsedValue = longVal
+
+	case core.ULongValue:
+		val, err := strconv.ParseUint(dataStr, 10, 64)
+		if err != nil {
+			return nil, remaining, nil
+		}
+		ulongVal, err := values.NewULongValue(name, val)
+		if err != nil {
+			return nil, remaining, err
+		}
+		parsedValue = ulongVal
+
+	case core.LLongValue:
 		val, err := strconv.ParseInt(dataStr, 10, 64)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewInt64Value(name, val)
 
-	case core.ULongValue, core.ULLongValue:
+	case core.ULLongValue:
 		val, err := strconv.ParseUint(dataStr, 10, 64)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewUInt64Value(name, val)
 
 	case core.FloatValue:
 		val, err := strconv.ParseFloat(dataStr, 32)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewFloat32Value(name, float32(val))
 
 	case core.DoubleValue:
 		val, err := strconv.ParseFloat(dataStr, 64)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewFloat64Value(name, val)
 
 	case core.StringValue:
+		if escaped {
+			dataStr = unescapeCppField(dataStr)
+		}
 		parsedValue = values.NewStringValue(name, dataStr)
 
 	case core.BytesValue:
 		// Decode hex string
 		bytes, err := hex.DecodeString(dataStr)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		parsedValue = values.NewBytesValue(name, bytes)
 
@@ -518,12 +825,15 @@ func parseSingleValue(content string) (core.Value, string) {
 		// Parse child count and recursively parse children
 		childCount, err := strconv.Atoi(dataStr)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		containerVal := values.NewContainerValue(name)
 		// Parse children recursively
 		for i := 0; i < childCount && len(remaining) > 0; i++ {
-			child, newRemaining := parseSingleValue(remaining)
+			child, newRemaining, err := parseSingleValue(remaining, escaped)
+			if err != nil {
+				return nil, remaining, err
+			}
 			if child != nil {
 				containerVal.AddChild(child)
 				remaining = newRemaining
@@ -537,12 +847,15 @@ func parseSingleValue(content string) (core.Value, string) {
 		// Parse element count and recursively parse elements
 		elementCount, err := strconv.Atoi(dataStr)
 		if err != nil {
-			return nil, remaining
+			return nil, remaining, nil
 		}
 		arrayVal := values.NewArrayValue(name)
 		// Parse elements recursively
 		for i := 0; i < elementCount && len(remaining) > 0; i++ {
-			element, newRemaining := parseSingleValue(remaining)
+			element, newRemaining, err := parseSingleValue(remaining, escaped)
+			if err != nil {
+				return nil, remaining, err
+			}
 			if element != nil {
 				arrayVal.Append(element)
 				remaining = newRemaining
@@ -552,13 +865,62 @@ func parseSingleValue(content string) (core.Value, string) {
 		}
 		parsedValue = arrayVal
 
+	case core.DateTimeValue:
+		val, err := strconv.ParseInt(dataStr, 10, 64)
+		if err != nil {
+			return nil, remaining, nil
+		}
+		parsedValue = values.NewDateTimeValue(name, time.Unix(0, val).UTC())
+
+	case core.UUIDValue:
+		uuidVal, err := values.NewUUIDValueFromString(name, dataStr)
+		if err != nil {
+			return nil, remaining, nil
+		}
+		parsedValue = uuidVal
+
+	case core.DecimalValue:
+		decimalVal, err := values.NewDecimalValueFromString(name, dataStr)
+		if err != nil {
+			return nil, remaining, nil
+		}
+		parsedValue = decimalVal
+
+	case core.MapValue:
+		// Parse entry count, then a key cell and a value cell per entry
+		entryCount, err := strconv.Atoi(dataStr)
+		if err != nil {
+			return nil, remaining, nil
+		}
+		mapVal := values.NewMapValue(name)
+		for i := 0; i < entryCount && len(remaining) > 0; i++ {
+			key, afterKey, err := parseSingleValue(remaining, escaped)
+			if err != nil {
+				return nil, remaining, err
+			}
+			if key == nil || key.Type() != core.StringValue {
+				break
+			}
+			entry, newRemaining, err := parseSingleValue(afterKey, escaped)
+			if err != nil {
+				return nil, remaining, err
+			}
+			if entry == nil {
+				break
+			}
+			keyStr, _ := key.ToString()
+			mapVal.Set(keyStr, entry)
+			remaining = newRemaining
+		}
+		parsedValue = mapVal
+
 	case core.NullValue:
-		// Skip null values
-		return nil, remaining
+		// Keep null values so nested child counts stay in sync
+		parsedValue = values.NewNullValue(name)
 
 	default:
-		return nil, remaining
+		return nil, remaining, nil
 	}
 
-	return parsedValue, remaining
+	return parsedValue, remaining, nil
 }
@@ -5,4 +5,7 @@ go 1.21
 require (
 	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
 	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	go.uber.org/dig v1.17.1
+	google.golang.org/protobuf v1.36.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestBase64_RoundTrip(t *testing.T) {
+	original := core.NewValueContainer()
+	original.SetSource("auth", "issuer")
+	original.SetTarget("api", "gateway")
+	original.SetMessageType("claims")
+	original.AddValue(values.NewStringValue("sub", "user-42"))
+	original.AddValue(values.NewInt64Value("exp", 1767225600))
+	original.AddValue(values.NewBytesValue("nonce", []byte{0xFB, 0xFF, 0xFE}))
+
+	for _, format := range []core.SerializationFormat{core.FormatBinary, core.FormatMessagePack} {
+		t.Run(format.String(), func(t *testing.T) {
+			encoded, err := original.ToBase64(format)
+			if err != nil {
+				t.Fatalf("ToBase64 failed: %v", err)
+			}
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Errorf("Encoded string is not URL-safe: %s", encoded)
+			}
+
+			restored, err := core.FromBase64(encoded, format)
+			if err != nil {
+				t.Fatalf("FromBase64 failed: %v", err)
+			}
+			if restored.Header() != original.Header() {
+				t.Errorf("Header mismatch: %+v vs %+v", restored.Header(), original.Header())
+			}
+			if changes := core.Changelog(original, restored); len(changes) != 0 {
+				t.Errorf("Round trip changed values: %v", changes)
+			}
+		})
+	}
+
+	t.Run("StandardAlphabet", func(t *testing.T) {
+		data, err := original.SerializeBinary()
+		if err != nil {
+			t.Fatalf("SerializeBinary failed: %v", err)
+		}
+		restored, err := core.FromBase64(base64.StdEncoding.EncodeToString(data), core.FormatBinary)
+		if err != nil {
+			t.Fatalf("FromBase64 failed: %v", err)
+		}
+		if changes := core.Changelog(original, restored); len(changes) != 0 {
+			t.Errorf("Round trip changed values: %v", changes)
+		}
+	})
+
+	t.Run("InvalidBase64", func(t *testing.T) {
+		if _, err := core.FromBase64("not base64!", core.FormatBinary); err == nil {
+			t.Error("Expected error for invalid base64")
+		}
+	})
+}
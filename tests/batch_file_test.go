@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestSaveLoadContainers(t *testing.T) {
+	batch := make([]*core.ValueContainer, 100)
+	for i := range batch {
+		batch[i] = newSampleContainer(values.NewInt32Value("index", int32(i)))
+		batch[i].SetSource("producer", fmt.Sprintf("p%d", i))
+	}
+
+	for _, format := range []core.SerializationFormat{core.FormatBinary, core.FormatMessagePack, core.FormatString} {
+		t.Run(format.String(), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "batch.bin")
+			if err := core.SaveContainers(path, batch, format); err != nil {
+				t.Fatalf("SaveContainers failed: %v", err)
+			}
+
+			loaded, err := core.LoadContainers(path)
+			if err != nil {
+				t.Fatalf("LoadContainers failed: %v", err)
+			}
+			if len(loaded) != len(batch) {
+				t.Fatalf("loaded %d containers, want %d", len(loaded), len(batch))
+			}
+
+			for _, i := range []int{0, 42, 99} {
+				if got, want := loaded[i].Header(), batch[i].Header(); got != want {
+					t.Errorf("container %d header = %+v, want %+v", i, got, want)
+				}
+			}
+			if format == core.FormatBinary {
+				if n, _ := loaded[42].GetValue("index", 0).ToInt32(); n != 42 {
+					t.Errorf("container 42 index = %d, want 42", n)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveLoadContainersEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.bin")
+	if err := core.SaveContainers(path, nil, core.FormatBinary); err != nil {
+		t.Fatalf("SaveContainers failed: %v", err)
+	}
+	loaded, err := core.LoadContainers(path)
+	if err != nil {
+		t.Fatalf("LoadContainers failed: %v", err)
+	}
+	if len(loaded) != 0 {
+		t.Errorf("loaded %d containers, want 0", len(loaded))
+	}
+}
+
+func TestLoadContainersRejectsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "plain.bin")
+	if err := os.WriteFile(plain, []byte("not a batch file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := core.LoadContainers(plain); !errors.Is(err, core.ErrNotBatchFile) {
+		t.Errorf("expected ErrNotBatchFile, got %v", err)
+	}
+
+	path := filepath.Join(dir, "batch.bin")
+	if err := core.SaveContainers(path, []*core.ValueContainer{newSampleContainer(), newSampleContainer()}, core.FormatBinary); err != nil {
+		t.Fatalf("SaveContainers failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	truncated := filepath.Join(dir, "truncated.bin")
+	if err := os.WriteFile(truncated, data[:len(data)-5], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := core.LoadContainers(truncated); !errors.Is(err, core.ErrTruncatedData) {
+		t.Errorf("expected ErrTruncatedData, got %v", err)
+	}
+}
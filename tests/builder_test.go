@@ -1,8 +1,11 @@
 package tests
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/kcenon/go_container_system/container/core"
 	"github.com/kcenon/go_container_system/container/messaging"
 	"github.com/kcenon/go_container_system/container/values"
 )
@@ -201,3 +204,130 @@ func TestContainerBuilderMultipleValues(t *testing.T) {
 		t.Errorf("Expected 4 values, got %d", len(vals))
 	}
 }
+
+func TestContainerBuilderRequireTypeMissing(t *testing.T) {
+	container, err := messaging.NewContainerBuilder().
+		WithSource("client", "1").
+		RequireType().
+		Build()
+
+	if err == nil {
+		t.Fatal("Expected an error for a missing required message type")
+	}
+	if !errors.Is(err, core.ErrMissingField) {
+		t.Errorf("Expected ErrMissingField, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "message type") {
+		t.Errorf("Expected the error to name the message type, got %v", err)
+	}
+	if container != nil {
+		t.Error("Expected no container on error")
+	}
+}
+
+func TestContainerBuilderRequireBothMissing(t *testing.T) {
+	_, err := messaging.NewContainerBuilder().
+		RequireType().
+		RequireSource().
+		Build()
+
+	if err == nil {
+		t.Fatal("Expected an error for missing required fields")
+	}
+	for _, want := range []string{"message type", "source ID"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected the error to name %q, got %v", want, err)
+		}
+	}
+}
+
+func TestContainerBuilderRequiredFieldsSet(t *testing.T) {
+	container, err := messaging.NewContainerBuilder().
+		WithSource("client", "").
+		WithType("request").
+		RequireType().
+		RequireSource().
+		Build()
+
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if container.MessageType() != "request" || container.SourceID() != "client" {
+		t.Errorf("Unexpected header: %+v", container.Header())
+	}
+}
+
+func TestContainerBuilderMustBuild(t *testing.T) {
+	container := messaging.NewContainerBuilder().WithType("ok").MustBuild()
+	if container.MessageType() != "ok" {
+		t.Errorf("Expected message type 'ok', got '%s'", container.MessageType())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustBuild to panic on a missing required field")
+		}
+	}()
+	messaging.NewContainerBuilder().RequireType().MustBuild()
+}
+
+func TestContainerBuilderTypedHelpers(t *testing.T) {
+	container := messaging.NewContainerBuilder().
+		WithType("profile").
+		WithString("name", "Alice").
+		WithInt32("age", 30).
+		WithBool("active", true).
+		WithValues(values.NewStringValue("note", "mixed")).
+		MustBuild()
+
+	if len(container.Values()) != 4 {
+		t.Fatalf("Expected 4 values, got %d", len(container.Values()))
+	}
+	if name, err := container.GetValue("name", 0).ToString(); err != nil || name != "Alice" {
+		t.Errorf("Expected name 'Alice', got %q (%v)", name, err)
+	}
+	age := container.GetValue("age", 0)
+	if n, err := age.ToInt32(); err != nil || n != 30 || age.Type() != core.IntValue {
+		t.Errorf("Expected int age 30, got %s %d (%v)", age.Type().TypeName(), n, err)
+	}
+	if active, err := container.GetValue("active", 0).ToBool(); err != nil || !active {
+		t.Errorf("Expected active true, got %v (%v)", active, err)
+	}
+}
+
+func TestContainerBuilderTypedHelpersWide(t *testing.T) {
+	raw := []byte{1, 2, 3}
+	container := messaging.NewContainerBuilder().
+		WithInt64("big", 1<<40).
+		WithFloat64("ratio", 0.25).
+		WithBytes("raw", raw).
+		MustBuild()
+	raw[0] = 9
+
+	if n, _ := container.GetValue("big", 0).ToInt64(); n != 1<<40 {
+		t.Errorf("Expected big 1<<40, got %d", n)
+	}
+	if f, _ := container.GetValue("ratio", 0).ToFloat64(); f != 0.25 {
+		t.Errorf("Expected ratio 0.25, got %v", f)
+	}
+	if data := container.GetValue("raw", 0).Data(); data[0] != 1 {
+		t.Error("WithBytes should copy its input")
+	}
+}
+
+func TestContainerBuilderWithVersion(t *testing.T) {
+	container := messaging.NewContainerBuilder().
+		WithType("versioned").
+		WithVersion("2.1.0.0").
+		WithThreadSafe(true).
+		MustBuild()
+
+	if container.Version() != "2.1.0.0" {
+		t.Errorf("Expected version '2.1.0.0', got '%s'", container.Version())
+	}
+
+	plain := messaging.NewContainerBuilder().MustBuild()
+	if plain.Version() != core.DefaultVersion {
+		t.Errorf("Expected default version %q, got %q", core.DefaultVersion, plain.Version())
+	}
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestChangelog(t *testing.T) {
+	oldContainer := core.NewValueContainer()
+	oldContainer.AddValue(values.NewStringValue("name", "Alice"))
+	oldContainer.AddValue(values.NewInt32Value("count", 1))
+	oldContainer.AddValue(values.NewInt32Value("age", 42))
+	oldContainer.AddValue(values.NewBoolValue("legacy", true))
+
+	newContainer := core.NewValueContainer()
+	newContainer.AddValue(values.NewStringValue("name", "Alice"))
+	newContainer.AddValue(values.NewInt32Value("count", 2))
+	newContainer.AddValue(values.NewStringValue("age", "42"))
+	newContainer.AddValue(values.NewStringValue("email", "alice@example.com"))
+
+	changes := core.Changelog(oldContainer, newContainer)
+
+	expected := []struct {
+		kind  core.ChangeKind
+		field string
+		text  string
+	}{
+		{core.ChangeModified, "count", `~ count: int 1 -> 2`},
+		{core.ChangeModified, "age", `~ age: int 42 -> string "42"`},
+		{core.ChangeRemoved, "legacy", `- legacy: bool true`},
+		{core.ChangeAdded, "email", `+ email: string "alice@example.com"`},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for i, want := range expected {
+		got := changes[i]
+		if got.Kind != want.kind || got.Field != want.field {
+			t.Errorf("changes[%d]: expected %s %s, got %s %s", i, want.kind, want.field, got.Kind, got.Field)
+		}
+		if got.String() != want.text {
+			t.Errorf("changes[%d]: expected %q, got %q", i, want.text, got.String())
+		}
+	}
+
+	t.Run("TypeChangeFields", func(t *testing.T) {
+		age := changes[1]
+		if age.OldType != core.IntValue || age.NewType != core.StringValue {
+			t.Errorf("Expected int -> string, got %s -> %s", age.OldType.TypeName(), age.NewType.TypeName())
+		}
+		if age.Old != "42" || age.New != `"42"` {
+			t.Errorf("Unexpected representations: %q -> %q", age.Old, age.New)
+		}
+	})
+
+	t.Run("Identical", func(t *testing.T) {
+		if changes := core.Changelog(oldContainer, oldContainer); len(changes) != 0 {
+			t.Errorf("Expected no changes, got %v", changes)
+		}
+	})
+
+	t.Run("NilContainer", func(t *testing.T) {
+		changes := core.Changelog(nil, newContainer)
+		if len(changes) != 4 {
+			t.Fatalf("Expected 4 additions, got %d", len(changes))
+		}
+		for _, change := range changes {
+			if change.Kind != core.ChangeAdded {
+				t.Errorf("Expected Added, got %s for %s", change.Kind, change.Field)
+			}
+		}
+	})
+
+	t.Run("RepeatedNames", func(t *testing.T) {
+		before := core.NewValueContainer()
+		before.AddValue(values.NewStringValue("tag", "a"))
+		before.AddValue(values.NewStringValue("tag", "b"))
+
+		after := core.NewValueContainer()
+		after.AddValue(values.NewStringValue("tag", "a"))
+
+		changes := core.Changelog(before, after)
+		if len(changes) != 1 || changes[0].String() != `- tag[1]: string "b"` {
+			t.Errorf("Unexpected changes for repeated names: %v", changes)
+		}
+	})
+
+	t.Run("NestedContainer", func(t *testing.T) {
+		before := core.NewValueContainer()
+		before.AddValue(values.NewContainerValue("profile", values.NewStringValue("city", "Seoul")))
+
+		after := core.NewValueContainer()
+		after.AddValue(values.NewContainerValue("profile", values.NewStringValue("city", "Busan")))
+
+		changes := core.Changelog(before, after)
+		if len(changes) != 1 || changes[0].Kind != core.ChangeModified || changes[0].Field != "profile" {
+			t.Fatalf("Expected profile to be modified, got %v", changes)
+		}
+	})
+}
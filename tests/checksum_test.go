@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestChecksumRoundTrip(t *testing.T) {
+	original := newSampleContainer()
+
+	data, err := original.SerializeArrayWithChecksum()
+	if err != nil {
+		t.Fatalf("SerializeArrayWithChecksum failed: %v", err)
+	}
+	payload, _ := original.SerializeBinary()
+	if len(data) != len(payload)+4 {
+		t.Errorf("Expected a 4-byte footer: payload %d bytes, got %d bytes", len(payload), len(data))
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.DeserializeArrayWithChecksum(data); err != nil {
+		t.Fatalf("DeserializeArrayWithChecksum failed: %v", err)
+	}
+	if !restored.Equal(original) {
+		t.Errorf("Round trip changed values: %v", original.Diff(restored))
+	}
+}
+
+func TestChecksumDetectsBitFlip(t *testing.T) {
+	data, err := newSampleContainer().SerializeArrayWithChecksum()
+	if err != nil {
+		t.Fatalf("SerializeArrayWithChecksum failed: %v", err)
+	}
+
+	// Flip each bit in turn, across the payload and the footer itself
+	for i := 0; i < len(data)*8; i++ {
+		corrupted := append([]byte(nil), data...)
+		corrupted[i/8] ^= 1 << (i % 8)
+
+		restored := core.NewValueContainer()
+		restored.AddValue(values.NewStringValue("untouched", "yes"))
+		err := restored.DeserializeArrayWithChecksum(corrupted)
+		if !errors.Is(err, core.ErrChecksumMismatch) {
+			t.Fatalf("Bit %d: expected ErrChecksumMismatch, got %v", i, err)
+		}
+		if restored.GetValue("untouched", 0) == nil {
+			t.Fatalf("Bit %d: a failed check should leave the container unchanged", i)
+		}
+	}
+
+	if err := core.NewValueContainer().DeserializeArrayWithChecksum(data[:3]); !errors.Is(err, core.ErrTruncatedData) {
+		t.Errorf("Expected ErrTruncatedData for data shorter than the footer, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func newUniformBatch(n int) []*core.ValueContainer {
+	batch := make([]*core.ValueContainer, 0, n)
+	for i := 0; i < n; i++ {
+		c := core.NewValueContainerFull("sensor", fmt.Sprintf("s%d", i%4), "collector", "main", "reading")
+		c.AddValue(values.NewInt64Value("timestamp_ns", int64(1700000000000000000+i)))
+		c.AddValue(values.NewFloat64Value("temperature_celsius", 20.0+float64(i)/10))
+		c.AddValue(values.NewInt32Value("sequence_number", int32(i)))
+		c.AddValue(values.NewStringValue("status_message", "ok"))
+		c.AddValue(values.NewBoolValue("calibrated", i%2 == 0))
+		batch = append(batch, c)
+	}
+	return batch
+}
+
+func TestColumnar_RoundTrip(t *testing.T) {
+	batch := newUniformBatch(100)
+
+	data, err := core.SerializeColumnar(batch)
+	if err != nil {
+		t.Fatalf("SerializeColumnar failed: %v", err)
+	}
+
+	rows, err := core.DeserializeColumnar(data)
+	if err != nil {
+		t.Fatalf("DeserializeColumnar failed: %v", err)
+	}
+	if len(rows) != 100 {
+		t.Fatalf("Expected 100 rows, got %d", len(rows))
+	}
+
+	for i, row := range rows {
+		original := batch[i]
+		if row.Header() != original.Header() {
+			t.Fatalf("Row %d header mismatch: %+v vs %+v", i, row.Header(), original.Header())
+		}
+		if len(row.Values()) != 5 {
+			t.Fatalf("Row %d: expected 5 values, got %d", i, len(row.Values()))
+		}
+		if seq, _ := row.GetValue("sequence_number", 0).ToInt32(); seq != int32(i) {
+			t.Errorf("Row %d: expected sequence %d, got %d", i, i, seq)
+		}
+		if ts, _ := row.GetValue("timestamp_ns", 0).ToInt64(); ts != int64(1700000000000000000+i) {
+			t.Errorf("Row %d: timestamp mismatch: %d", i, ts)
+		}
+		expectedTemp, _ := original.GetValue("temperature_celsius", 0).ToFloat64()
+		if temp, _ := row.GetValue("temperature_celsius", 0).ToFloat64(); temp != expectedTemp {
+			t.Errorf("Row %d: expected temperature %f, got %f", i, expectedTemp, temp)
+		}
+		if status, _ := row.GetValue("status_message", 0).ToString(); status != "ok" {
+			t.Errorf("Row %d: expected status 'ok', got '%s'", i, status)
+		}
+		if calibrated, _ := row.GetValue("calibrated", 0).ToBool(); calibrated != (i%2 == 0) {
+			t.Errorf("Row %d: calibrated mismatch", i)
+		}
+	}
+}
+
+func TestColumnar_SmallerThanRowWise(t *testing.T) {
+	batch := newUniformBatch(100)
+
+	columnar, err := core.SerializeColumnar(batch)
+	if err != nil {
+		t.Fatalf("SerializeColumnar failed: %v", err)
+	}
+
+	// Row-wise: every container writes its header strings and full value frames
+	rowWise := 0
+	for _, c := range batch {
+		h := c.Header()
+		rowWise += 6*4 + len(h.SourceID) + len(h.SourceSubID) + len(h.TargetID) +
+			len(h.TargetSubID) + len(h.MessageType) + len(h.Version)
+		for _, value := range c.Values() {
+			frame, err := value.ToBytes()
+			if err != nil {
+				t.Fatalf("ToBytes failed: %v", err)
+			}
+			rowWise += len(frame)
+		}
+	}
+
+	t.Logf("columnar=%d bytes, row-wise=%d bytes (%.1f%%)",
+		len(columnar), rowWise, 100*float64(len(columnar))/float64(rowWise))
+
+	if len(columnar) >= rowWise {
+		t.Errorf("Columnar (%d bytes) should be smaller than row-wise (%d bytes)", len(columnar), rowWise)
+	}
+}
+
+func TestColumnar_SparseAndNested(t *testing.T) {
+	first := core.NewValueContainer()
+	first.AddValue(values.NewStringValue("name", "a"))
+	first.AddValue(values.NewContainerValue("meta", values.NewInt32Value("n", 1)))
+
+	second := core.NewValueContainer()
+	second.AddValue(values.NewStringValue("name", "b"))
+	second.AddValue(values.NewArrayValue("tags", values.NewStringValue("", "x")))
+
+	data, err := core.SerializeColumnar([]*core.ValueContainer{first, second})
+	if err != nil {
+		t.Fatalf("SerializeColumnar failed: %v", err)
+	}
+	rows, err := core.DeserializeColumnar(data)
+	if err != nil {
+		t.Fatalf("DeserializeColumnar failed: %v", err)
+	}
+
+	if len(rows[0].Values()) != 2 || len(rows[1].Values()) != 2 {
+		t.Fatalf("Unexpected value counts: %d, %d", len(rows[0].Values()), len(rows[1].Values()))
+	}
+	if n, _ := rows[0].GetValue("meta", 0).GetChild("n", 0).ToInt32(); n != 1 {
+		t.Errorf("Expected nested n=1, got %d", n)
+	}
+	if rows[1].GetValue("meta", 0).Type() != core.NullValue {
+		t.Error("Second row should not have a meta value")
+	}
+	if rows[1].GetValue("tags", 0).Type() != core.ArrayValue {
+		t.Error("Second row should have a tags array")
+	}
+}
+
+func TestColumnar_RejectsTypeMismatch(t *testing.T) {
+	first := core.NewValueContainer()
+	first.AddValue(values.NewInt32Value("id", 1))
+	second := core.NewValueContainer()
+	second.AddValue(values.NewStringValue("id", "2"))
+
+	if _, err := core.SerializeColumnar([]*core.ValueContainer{first, second}); err == nil {
+		t.Error("Expected error for column type mismatch")
+	}
+}
+
+func TestColumnar_ConcurrentWriters(t *testing.T) {
+	batch := newUniformBatch(4)
+	for _, c := range batch {
+		c.EnableThreadSafe()
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			batch[i%len(batch)].AddValue(values.NewInt32Value(fmt.Sprintf("extra_%d", i), int32(i)))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if _, err := core.SerializeColumnar(batch); err != nil {
+			t.Fatalf("SerializeColumnar failed: %v", err)
+		}
+	}
+	wg.Wait()
+}
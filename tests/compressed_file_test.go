@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func newLargeContainer(n int) *core.ValueContainer {
+	container := core.NewValueContainer()
+	container.SetSource("batch", "job-7")
+	container.SetTarget("archive", "store")
+	container.SetMessageType("bulk_export")
+	for i := 0; i < n; i++ {
+		container.AddValue(values.NewStringValue(fmt.Sprintf("field_%04d", i), "repeated payload text"))
+	}
+	return container
+}
+
+func TestCompressedFileIO(t *testing.T) {
+	container := newLargeContainer(1000)
+	dir := t.TempDir()
+
+	testCases := []struct {
+		format core.SerializationFormat
+		save   func(path string) error
+	}{
+		{core.FormatString, container.SaveToFile},
+		{core.FormatJSON, container.SaveToFileJSON},
+		{core.FormatXML, container.SaveToFileXML},
+		{core.FormatMessagePack, container.SaveToFileMessagePack},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format.String(), func(t *testing.T) {
+			plainPath := filepath.Join(dir, tc.format.String()+".plain")
+			if err := tc.save(plainPath); err != nil {
+				t.Fatalf("Uncompressed save failed: %v", err)
+			}
+			compressedPath := filepath.Join(dir, tc.format.String()+".gz")
+			if err := container.SaveToFileCompressed(compressedPath, tc.format); err != nil {
+				t.Fatalf("SaveToFileCompressed failed: %v", err)
+			}
+
+			plainInfo, _ := os.Stat(plainPath)
+			compressedInfo, _ := os.Stat(compressedPath)
+			if compressedInfo.Size() >= plainInfo.Size() {
+				t.Errorf("Compressed file (%d bytes) is not smaller than uncompressed (%d bytes)",
+					compressedInfo.Size(), plainInfo.Size())
+			}
+
+			restored := core.NewValueContainer()
+			if err := restored.LoadFromFileCompressed(compressedPath); err != nil {
+				t.Fatalf("LoadFromFileCompressed failed: %v", err)
+			}
+			if restored.Header() != container.Header() {
+				t.Errorf("Header mismatch: %+v vs %+v", restored.Header(), container.Header())
+			}
+		})
+	}
+
+	t.Run("NotCompressed", func(t *testing.T) {
+		plainPath := filepath.Join(dir, "plain.json")
+		if err := container.SaveToFileJSON(plainPath); err != nil {
+			t.Fatalf("SaveToFileJSON failed: %v", err)
+		}
+		err := core.NewValueContainer().LoadFromFileCompressed(plainPath)
+		if !errors.Is(err, core.ErrNotCompressedContainer) {
+			t.Errorf("Expected ErrNotCompressedContainer, got %v", err)
+		}
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		err := container.SaveToFileCompressed(filepath.Join(dir, "bad.gz"), core.SerializationFormat(99))
+		if err == nil {
+			t.Error("Expected error for unsupported format")
+		}
+	})
+}
+
+func TestCompressedFileStats(t *testing.T) {
+	container := newLargeContainer(500)
+	dir := t.TempDir()
+
+	for _, format := range []core.SerializationFormat{core.FormatJSON, core.FormatBinary} {
+		t.Run(format.String(), func(t *testing.T) {
+			path := filepath.Join(dir, format.String()+".gz")
+			stats, err := container.SaveToFileCompressedWithStats(path, format)
+			if err != nil {
+				t.Fatalf("SaveToFileCompressedWithStats failed: %v", err)
+			}
+
+			serialized, err := container.Marshal(format)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if stats.OriginalBytes != int64(len(serialized)) {
+				t.Errorf("OriginalBytes: expected %d, got %d", len(serialized), stats.OriginalBytes)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("Stat failed: %v", err)
+			}
+			if want := info.Size() - 6; stats.CompressedBytes != want { // 6-byte file header
+				t.Errorf("CompressedBytes: expected %d, got %d", want, stats.CompressedBytes)
+			}
+			if want := float64(stats.CompressedBytes) / float64(stats.OriginalBytes); stats.Ratio != want {
+				t.Errorf("Ratio: expected %f, got %f", want, stats.Ratio)
+			}
+			if stats.Ratio <= 0 || stats.Ratio >= 1 {
+				t.Errorf("Repetitive payload should compress, got ratio %f", stats.Ratio)
+			}
+			if stats.Codec != "gzip" {
+				t.Errorf("Codec: expected gzip, got %s", stats.Codec)
+			}
+		})
+	}
+}
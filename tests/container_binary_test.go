@@ -0,0 +1,322 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func newBinaryTestContainer() *core.ValueContainer {
+	container := core.NewValueContainer()
+	container.SetSource("client", "session")
+	container.SetTarget("server", "handler")
+	container.SetMessageType("binary_test")
+	container.AddValue(values.NewInt32Value("count", 42))
+	container.AddValue(values.NewStringValue("name", "stream"))
+	container.AddValue(values.NewBytesValue("blob", bytes.Repeat([]byte{0xAB}, 300)))
+	container.AddValue(values.NewNullValue("nothing"))
+	container.AddValue(values.NewDateTimeValue("at", time.Unix(1700000000, 0).UTC()))
+	container.AddValue(values.NewContainerValue("profile",
+		values.NewStringValue("city", "Seoul"),
+		values.NewArrayValue("tags", values.NewStringValue("", "a"), values.NewBoolValue("", true)),
+	))
+	return container
+}
+
+func assertBinaryTestContainer(t *testing.T, c *core.ValueContainer) {
+	t.Helper()
+	if c.SourceSubID() != "session" || c.TargetID() != "server" || c.MessageType() != "binary_test" {
+		t.Errorf("Header mismatch: %+v", c.Header())
+	}
+	if len(c.Values()) != 6 {
+		t.Fatalf("Expected 6 values, got %d", len(c.Values()))
+	}
+	count, _ := c.GetValue("count", 0).ToInt32()
+	name, _ := c.GetValue("name", 0).ToString()
+	if count != 42 || name != "stream" {
+		t.Errorf("Primitive mismatch: count=%d name=%q", count, name)
+	}
+	if blob := c.GetValue("blob", 0).Data(); len(blob) != 300 {
+		t.Errorf("Expected 300-byte blob, got %d", len(blob))
+	}
+	if !c.GetValue("nothing", 0).IsNull() {
+		t.Error("Null value not restored")
+	}
+	profile := c.GetValue("profile", 0)
+	if profile.Type() != core.ContainerValue || profile.ChildCount() != 2 {
+		t.Fatalf("Nested container not restored: %s", profile.Type().TypeName())
+	}
+	if tags := profile.GetChild("tags", 0); tags == nil || tags.Type() != core.ArrayValue {
+		t.Error("Nested array not restored")
+	}
+}
+
+func TestReadContainerFrom(t *testing.T) {
+	original := newBinaryTestContainer()
+	data, err := original.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+
+	t.Run("OneByteReader", func(t *testing.T) {
+		restored, err := core.ReadContainerFrom(iotest.OneByteReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("ReadContainerFrom failed: %v", err)
+		}
+		assertBinaryTestContainer(t, restored)
+
+		again, _ := restored.SerializeBinary()
+		if !bytes.Equal(again, data) {
+			t.Error("Re-serialized bytes differ from original")
+		}
+	})
+
+	t.Run("ConsecutiveContainers", func(t *testing.T) {
+		stream := iotest.OneByteReader(bytes.NewReader(append(append([]byte{}, data...), data...)))
+		for i := 0; i < 2; i++ {
+			restored, err := core.ReadContainerFrom(stream)
+			if err != nil {
+				t.Fatalf("Container %d: %v", i, err)
+			}
+			assertBinaryTestContainer(t, restored)
+		}
+		if _, err := core.ReadContainerFrom(stream); err != io.EOF {
+			t.Errorf("Expected io.EOF at end of stream, got %v", err)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		for _, size := range []int{1, 10, len(data) / 2, len(data) - 1} {
+			_, err := core.ReadContainerFrom(iotest.OneByteReader(bytes.NewReader(data[:size])))
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("Truncated at %d: expected io.ErrUnexpectedEOF, got %v", size, err)
+			}
+		}
+	})
+
+	t.Run("ReaderError", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		r := io.MultiReader(bytes.NewReader(data[:20]), iotest.ErrReader(errBoom))
+		if _, err := core.ReadContainerFrom(r); !errors.Is(err, errBoom) {
+			t.Errorf("Expected reader error, got %v", err)
+		}
+	})
+
+	t.Run("DeserializeBinary", func(t *testing.T) {
+		restored := core.NewValueContainer()
+		if err := restored.DeserializeBinary(data); err != nil {
+			t.Fatalf("DeserializeBinary failed: %v", err)
+		}
+		assertBinaryTestContainer(t, restored)
+	})
+}
+
+func TestReadContainerFromPartialResult(t *testing.T) {
+	container := core.NewValueContainer()
+	container.SetMessageType("partial")
+	headerOnly, _ := container.SerializeBinary()
+
+	first := values.NewInt32Value("first", 1)
+	second := values.NewStringValue("second", "two")
+	third := values.NewBoolValue("third", true)
+	container.AddValue(first)
+	container.AddValue(second)
+	container.AddValue(third)
+	data, err := container.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+
+	// Stream the header and the first two value frames, then fail
+	cut := len(headerOnly) + first.SerializedSize() + second.SerializedSize()
+	errStalled := errors.New("connection stalled")
+
+	t.Run("ReaderErrorAfterTwoValues", func(t *testing.T) {
+		r := io.MultiReader(bytes.NewReader(data[:cut]), iotest.ErrReader(errStalled))
+		restored, err := core.ReadContainerFrom(r)
+		if restored != nil {
+			t.Error("Expected nil container on error")
+		}
+
+		var partial *core.PartialReadError
+		if !errors.As(err, &partial) {
+			t.Fatalf("Expected *PartialReadError, got %v", err)
+		}
+		if !errors.Is(err, errStalled) {
+			t.Errorf("Expected underlying reader error, got %v", err)
+		}
+		if partial.ValuesRead != 2 || len(partial.Container.Values()) != 2 {
+			t.Errorf("Expected 2 values read, got %d (%d in container)",
+				partial.ValuesRead, len(partial.Container.Values()))
+		}
+		if partial.Container.MessageType() != "partial" {
+			t.Errorf("Partial container lost header: %s", partial.Container.MessageType())
+		}
+		if s, _ := partial.Container.GetValue("second", 0).ToString(); s != "two" {
+			t.Errorf("Second value mismatch: %q", s)
+		}
+	})
+
+	t.Run("TruncatedAfterTwoValues", func(t *testing.T) {
+		_, err := core.ReadContainerFrom(bytes.NewReader(data[:cut]))
+		var partial *core.PartialReadError
+		if !errors.As(err, &partial) || partial.ValuesRead != 2 {
+			t.Fatalf("Expected partial result with 2 values, got %v", err)
+		}
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+		}
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := &stallingReader{data: bytes.NewReader(data[:cut]), ctx: ctx, onStall: cancel}
+
+		_, err := core.ReadContainerFromContext(ctx, r)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+		var partial *core.PartialReadError
+		if !errors.As(err, &partial) || partial.ValuesRead != 2 {
+			t.Errorf("Expected partial result with 2 values, got %v", err)
+		}
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		restored, err := core.ReadContainerFromContext(context.Background(), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("ReadContainerFromContext failed: %v", err)
+		}
+		if len(restored.Values()) != 3 {
+			t.Errorf("Expected 3 values, got %d", len(restored.Values()))
+		}
+	})
+}
+
+// stallingReader serves data and then blocks, like a stalled peer, until its
+// context is done. onStall is called when the data runs out.
+type stallingReader struct {
+	data    *bytes.Reader
+	ctx     context.Context
+	onStall func()
+}
+
+func (s *stallingReader) Read(p []byte) (int, error) {
+	if s.data.Len() > 0 {
+		return s.data.Read(p)
+	}
+	s.onStall()
+	<-s.ctx.Done()
+	return 0, errors.New("read interrupted")
+}
+
+// unpooledBinary builds the binary container format from each value's own
+// ToBytes frame, without the pooled scratch buffers used by SerializeBinary
+func unpooledBinary(t testing.TB, c *core.ValueContainer) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.WriteByte(core.BinaryVersion)
+	for _, field := range []string{c.SourceID(), c.SourceSubID(), c.TargetID(), c.TargetSubID(), c.MessageType(), c.Version()} {
+		buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(field))))
+		buf.WriteString(field)
+	}
+	buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(c.Values()))))
+	for _, v := range c.Values() {
+		frame, err := core.FrameBytes(v)
+		if err != nil {
+			t.Fatalf("FrameBytes failed for %s: %v", v.Name(), err)
+		}
+		buf.Write(frame)
+	}
+	return buf.Bytes()
+}
+
+func TestSerializeBinaryPooledMatchesUnpooled(t *testing.T) {
+	container := newBinaryTestContainer()
+	want := unpooledBinary(t, container)
+
+	got, err := container.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("Pooled output differs from unpooled:\n got %x\nwant %x", got, want)
+	}
+
+	var streamed bytes.Buffer
+	if _, err := container.WriteBinaryTo(&streamed); err != nil {
+		t.Fatalf("WriteBinaryTo failed: %v", err)
+	}
+	if !bytes.Equal(streamed.Bytes(), want) {
+		t.Fatalf("WriteBinaryTo output differs from unpooled")
+	}
+
+	// Later serializations reuse the pooled buffer; earlier results must not change
+	other := core.NewValueContainerWithType("other")
+	other.AddValue(values.NewStringValue("filler", string(bytes.Repeat([]byte{'x'}, 2000))))
+	for i := 0; i < 10; i++ {
+		if _, err := other.SerializeBinary(); err != nil {
+			t.Fatalf("SerializeBinary failed: %v", err)
+		}
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("Result was overwritten by a later serialization")
+	}
+}
+
+func TestContainerSizeMatchesBinaryLength(t *testing.T) {
+	for _, container := range []*core.ValueContainer{
+		core.NewValueContainer(),
+		newBinaryTestContainer(),
+		createLargeBenchContainer(),
+	} {
+		data, err := container.SerializeBinary()
+		if err != nil {
+			t.Fatalf("SerializeBinary failed: %v", err)
+		}
+		if got := container.Size(); got != len(data) {
+			t.Errorf("Size() = %d, want binary length %d", got, len(data))
+		}
+	}
+}
+
+func TestSerializeBinaryPooledConcurrent(t *testing.T) {
+	containers := make([]*core.ValueContainer, 8)
+	expected := make([][]byte, len(containers))
+	for i := range containers {
+		containers[i] = newBinaryTestContainer()
+		containers[i].AddValue(values.NewInt32Value("index", int32(i)))
+		expected[i] = unpooledBinary(t, containers[i])
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, len(containers))
+	for i := range containers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				got, err := containers[i].SerializeBinary()
+				if err != nil || !bytes.Equal(got, expected[i]) {
+					errs <- fmt.Sprintf("container %d iteration %d: mismatch (err %v)", i, j, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for msg := range errs {
+		t.Error(msg)
+	}
+}
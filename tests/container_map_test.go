@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestValueContainerToMapNested(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	container := core.NewValueContainerWithType("profile")
+	container.AddValue(values.NewStringValue("name", "Ada"))
+	container.AddValue(values.NewInt16Value("age", 36))
+	container.AddValue(values.NewUInt32Value("visits", 7))
+	container.AddValue(values.NewFloat32Value("score", 0.5))
+	container.AddValue(values.NewBoolValue("active", true))
+	container.AddValue(values.NewNullValue("nickname"))
+	container.AddValue(values.NewBytesValue("avatar", []byte{0xca, 0xfe}))
+	container.AddValue(values.NewDateTimeValue("created", created))
+	container.AddValue(values.NewContainerValue("address",
+		values.NewStringValue("city", "London"),
+		values.NewContainerValue("geo", values.NewFloat64Value("lat", 51.5)),
+	))
+	container.AddValue(values.NewArrayValue("tags",
+		values.NewStringValue("", "math"),
+		values.NewInt32Value("", 1815),
+		values.NewArrayValue("", values.NewBoolValue("", false)),
+	))
+
+	got := container.ToMap()
+	want := map[string]interface{}{
+		"name":     "Ada",
+		"age":      int64(36),
+		"visits":   uint64(7),
+		"score":    0.5,
+		"active":   true,
+		"nickname": nil,
+		"avatar":   []byte{0xca, 0xfe},
+		"created":  created,
+		"address": map[string]interface{}{
+			"city": "London",
+			"geo":  map[string]interface{}{"lat": 51.5},
+		},
+		"tags": []interface{}{"math", int64(1815), []interface{}{false}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap mismatch:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestContainerFromMapNested(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	input := map[string]interface{}{
+		"name":    "Ada",
+		"age":     36,
+		"visits":  uint(7),
+		"ratio":   0.25,
+		"active":  true,
+		"missing": nil,
+		"avatar":  []byte{0xca, 0xfe},
+		"created": created,
+		"address": map[string]interface{}{
+			"city": "London",
+			"geo":  map[string]interface{}{"lat": 51.5},
+		},
+		"tags": []interface{}{"math", 1815, []interface{}{false}},
+	}
+
+	container, err := core.ContainerFromMap(input, "profile")
+	if err != nil {
+		t.Fatalf("ContainerFromMap failed: %v", err)
+	}
+	if container.MessageType() != "profile" {
+		t.Errorf("Expected message type 'profile', got '%s'", container.MessageType())
+	}
+
+	// Keys are added in sorted order
+	var names []string
+	container.ForEach(func(v core.Value) bool {
+		names = append(names, v.Name())
+		return true
+	})
+	if strings.Join(names, ",") != "active,address,age,avatar,created,missing,name,ratio,tags,visits" {
+		t.Errorf("Unexpected value order %v", names)
+	}
+
+	wantTypes := map[string]core.ValueType{
+		"name":    core.StringValue,
+		"age":     core.LLongValue,
+		"visits":  core.ULLongValue,
+		"ratio":   core.DoubleValue,
+		"active":  core.BoolValue,
+		"missing": core.NullValue,
+		"avatar":  core.BytesValue,
+		"created": core.DateTimeValue,
+		"address": core.ContainerValue,
+		"tags":    core.ArrayValue,
+	}
+	for name, want := range wantTypes {
+		if got := container.GetValue(name, 0).Type(); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want.TypeName(), got.TypeName())
+		}
+	}
+	if _, ok := container.GetValue("age", 0).(*values.Int64Value); !ok {
+		t.Errorf("Expected age to be an Int64Value, got %T", container.GetValue("age", 0))
+	}
+
+	lat, err := container.GetValueByPath("address.geo.lat")
+	if err != nil {
+		t.Fatalf("GetValueByPath failed: %v", err)
+	}
+	if f, _ := lat.ToFloat64(); f != 51.5 {
+		t.Errorf("Expected lat 51.5, got %v", f)
+	}
+
+	// Converting back yields the same structure, with ints widened to int64
+	back := container.ToMap()
+	if back["age"] != int64(36) || back["visits"] != uint64(7) {
+		t.Errorf("Unexpected numbers after round trip: %v %v", back["age"], back["visits"])
+	}
+	if !reflect.DeepEqual(back["address"], input["address"]) {
+		t.Errorf("Address mismatch: %#v", back["address"])
+	}
+	if !reflect.DeepEqual(back["tags"], []interface{}{"math", int64(1815), []interface{}{false}}) {
+		t.Errorf("Tags mismatch: %#v", back["tags"])
+	}
+	if !back["created"].(time.Time).Equal(created) {
+		t.Errorf("Created mismatch: %v", back["created"])
+	}
+}
+
+func TestContainerFromMapUnsupportedType(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"top level": {"ch": make(chan int)},
+		"nested":    {"cfg": map[string]interface{}{"fn": func() {}}},
+		"in array":  {"list": []interface{}{1, struct{}{}}},
+	}
+	for name, input := range cases {
+		container, err := core.ContainerFromMap(input, "bad")
+		if !errors.Is(err, core.ErrTypeConversion) {
+			t.Errorf("%s: expected ErrTypeConversion, got %v", name, err)
+		}
+		if container != nil {
+			t.Errorf("%s: expected nil container on error", name)
+		}
+	}
+}
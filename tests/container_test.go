@@ -1,8 +1,10 @@
 package tests
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -205,6 +207,131 @@ func TestValueContainerCopy(t *testing.T) {
 	}
 }
 
+func TestValueContainerCopy_DeepCopiesValues(t *testing.T) {
+	original := core.NewValueContainerWithType("test_message")
+	original.AddValue(values.NewArrayValue("tags",
+		values.NewStringValue("", "a"),
+		values.NewContainerValue("", values.NewInt32Value("n", 1)),
+	))
+
+	copied := original.Copy(true)
+	copiedArray := copied.GetValue("tags", 0).(*values.ArrayValue)
+	copiedArray.Elements()[0] = values.NewStringValue("", "changed")
+	copiedArray.Elements()[1].(*values.ContainerValue).AddChild(values.NewInt32Value("m", 2))
+	copiedArray.Append(values.NewStringValue("", "extra"))
+
+	originalArray := original.GetValue("tags", 0).(*values.ArrayValue)
+	if originalArray.Count() != 2 {
+		t.Errorf("Original array should keep 2 elements, got %d", originalArray.Count())
+	}
+	if s, _ := originalArray.Elements()[0].ToString(); s != "a" {
+		t.Errorf("Original element changed to %q", s)
+	}
+	if n := len(originalArray.Elements()[1].(*values.ContainerValue).Children()); n != 1 {
+		t.Errorf("Original nested container should keep 1 child, got %d", n)
+	}
+
+	if core.CloneValue(nil) != nil {
+		t.Error("CloneValue(nil) should return nil")
+	}
+}
+
+func TestValueContainerTransformValues(t *testing.T) {
+	container := core.NewValueContainerWithType("transform_test")
+	container.EnableThreadSafe()
+	container.AddValue(values.NewInt32Value("a", 1))
+	container.AddValue(values.NewNullValue("gone"))
+	container.AddValue(values.NewStringValue("s", "keep"))
+	container.AddValue(values.NewInt32Value("b", -21))
+	container.AddValue(values.NewNullValue("gone_too"))
+
+	container.TransformValues(func(v core.Value) (core.Value, bool) {
+		switch v.Type() {
+		case core.NullValue:
+			return nil, false
+		case core.IntValue:
+			n, _ := v.ToInt32()
+			return values.NewInt32Value(v.Name(), n*2), true
+		default:
+			return v, true
+		}
+	})
+
+	expected := core.NewValueContainerWithType("transform_test")
+	expected.AddValue(values.NewInt32Value("a", 2))
+	expected.AddValue(values.NewStringValue("s", "keep"))
+	expected.AddValue(values.NewInt32Value("b", -42))
+
+	if changes := core.Changelog(expected, container); len(changes) != 0 {
+		t.Errorf("Unexpected transform result: %v", changes)
+	}
+	for i, value := range container.Values() {
+		if want := expected.Values()[i].Name(); value.Name() != want {
+			t.Errorf("Values()[%d]: expected %s, got %s", i, want, value.Name())
+		}
+	}
+}
+
+func TestValueContainerInsertValue(t *testing.T) {
+	container := core.NewValueContainerWithType("insert_test")
+	container.AddValue(values.NewInt32Value("b", 2))
+
+	if err := container.InsertValue(0, values.NewInt32Value("a", 1)); err != nil {
+		t.Fatalf("Insert at 0 failed: %v", err)
+	}
+	if err := container.InsertValue(2, values.NewInt32Value("d", 4)); err != nil {
+		t.Fatalf("Insert at end failed: %v", err)
+	}
+	if err := container.InsertValue(2, values.NewInt32Value("c", 3)); err != nil {
+		t.Fatalf("Insert in middle failed: %v", err)
+	}
+
+	for _, index := range []int{-1, 5} {
+		if err := container.InsertValue(index, values.NewInt32Value("x", 0)); err == nil {
+			t.Errorf("Expected error for insert index %d", index)
+		}
+	}
+
+	var names []string
+	for _, value := range container.Values() {
+		names = append(names, value.Name())
+	}
+	if strings.Join(names, ",") != "a,b,c,d" {
+		t.Errorf("Expected order a,b,c,d, got %v", names)
+	}
+}
+
+func TestValueContainerReplaceValue(t *testing.T) {
+	container := core.NewValueContainerWithType("replace_test")
+	container.EnableThreadSafe()
+	container.AddValue(values.NewStringValue("tag", "first"))
+	container.AddValue(values.NewInt32Value("count", 1))
+	container.AddValue(values.NewStringValue("tag", "second"))
+
+	if !container.ReplaceValue("tag", 1, values.NewStringValue("tag", "replaced")) {
+		t.Fatal("ReplaceValue should find the second tag")
+	}
+	if s, _ := container.GetValue("tag", 1).ToString(); s != "replaced" {
+		t.Errorf("Expected replaced value, got %q", s)
+	}
+	if container.Values()[2].Name() != "tag" || len(container.Values()) != 3 {
+		t.Error("ReplaceValue should keep the value's position")
+	}
+	if s, _ := container.GetValue("tag", 0).ToString(); s != "first" {
+		t.Errorf("First tag should be unchanged, got %q", s)
+	}
+
+	if container.ReplaceValue("tag", 2, values.NewStringValue("tag", "x")) {
+		t.Error("ReplaceValue should return false for an out-of-range index")
+	}
+	if container.ReplaceValue("missing", 0, values.NewStringValue("missing", "x")) {
+		t.Error("ReplaceValue should return false for a missing name")
+	}
+	if len(container.Values()) != 3 {
+		t.Errorf("Failed replacements should not change the container, got %d values", len(container.Values()))
+	}
+}
+
 func TestJSONSerialization(t *testing.T) {
 	container := core.NewValueContainerWithType("test_message")
 	container.AddValue(values.NewStringValue("name", "Alice"))
@@ -463,6 +590,56 @@ func TestThreadSafeOperations(t *testing.T) {
 	})
 }
 
+func TestThreadSafeGetValuesAndClear(t *testing.T) {
+	container := core.NewValueContainerWithType("get_values_test")
+	container.EnableThreadSafe()
+
+	var wg sync.WaitGroup
+	numGoroutines := 50
+	numOpsPerGoroutine := 20
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(2)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < numOpsPerGoroutine; j++ {
+				container.AddValue(values.NewInt32Value("item", int32(id*numOpsPerGoroutine+j)))
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOpsPerGoroutine; j++ {
+				for _, value := range container.GetValues("item") {
+					if value.Name() != "item" {
+						t.Errorf("GetValues returned %s", value.Name())
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	expectedCount := numGoroutines * numOpsPerGoroutine
+	if count := len(container.GetValues("item")); count != expectedCount {
+		t.Errorf("Expected %d values, got %d", expectedCount, count)
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		container.ClearValues()
+	}()
+	go func() {
+		defer wg.Done()
+		_ = container.GetValues("item")
+	}()
+	wg.Wait()
+
+	if count := len(container.GetValues("item")); count != 0 {
+		t.Errorf("Expected 0 values after ClearValues, got %d", count)
+	}
+}
+
 func TestThreadSafeDisable(t *testing.T) {
 	container := core.NewValueContainerWithType("disable_test")
 
@@ -483,3 +660,537 @@ func TestThreadSafeDisable(t *testing.T) {
 		t.Error("Thread-safe mode should be disabled")
 	}
 }
+
+func TestArrayValueThreadSafeOperations(t *testing.T) {
+	array := values.NewArrayValue("concurrent_array")
+	if array.IsThreadSafe() {
+		t.Error("Thread-safe mode should be disabled by default")
+	}
+	array.EnableThreadSafe()
+	if !array.IsThreadSafe() {
+		t.Error("Thread-safe mode should be enabled")
+	}
+
+	var wg sync.WaitGroup
+	numGoroutines := 100
+	numOpsPerGoroutine := 10
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(2)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < numOpsPerGoroutine; j++ {
+				array.Append(values.NewInt32Value("", int32(id*numOpsPerGoroutine+j)))
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOpsPerGoroutine; j++ {
+				if count := array.Count(); count > 0 {
+					if _, err := array.At(count - 1); err != nil {
+						t.Errorf("At(%d) failed: %v", count-1, err)
+					}
+				}
+				_ = array.Elements()
+				_, _ = array.ToBytes()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	expectedCount := numGoroutines * numOpsPerGoroutine
+	if array.Count() != expectedCount {
+		t.Errorf("Expected %d elements, got %d", expectedCount, array.Count())
+	}
+
+	array.Clear()
+	if !array.IsEmpty() {
+		t.Errorf("Expected empty array after Clear, got %d elements", array.Count())
+	}
+}
+
+func TestValueContainerGroupByName(t *testing.T) {
+	container := core.NewValueContainer()
+	container.EnableThreadSafe()
+	container.AddValue(values.NewStringValue("tag", "a"))
+	container.AddValue(values.NewInt32Value("id", 1))
+	container.AddValue(values.NewStringValue("tag", "b"))
+	container.AddValue(values.NewBoolValue("flag", true))
+	container.AddValue(values.NewStringValue("tag", "c"))
+
+	groups := container.GroupByName()
+
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+
+	tags := groups["tag"]
+	if len(tags) != 3 {
+		t.Fatalf("Expected 3 'tag' values, got %d", len(tags))
+	}
+	for i, expected := range []string{"a", "b", "c"} {
+		str, _ := tags[i].ToString()
+		if str != expected {
+			t.Errorf("tag[%d]: expected '%s', got '%s'", i, expected, str)
+		}
+	}
+
+	if len(groups["id"]) != 1 {
+		t.Errorf("Expected 1 'id' value, got %d", len(groups["id"]))
+	}
+	if len(groups["flag"]) != 1 {
+		t.Errorf("Expected 1 'flag' value, got %d", len(groups["flag"]))
+	}
+	if _, exists := groups["missing"]; exists {
+		t.Error("Unexpected group for missing name")
+	}
+}
+
+func TestValueContainerSerializeText(t *testing.T) {
+	container := core.NewValueContainerFull("src", "s1", "dst", "d1", "text_test")
+	container.AddValue(values.NewStringValue("greeting", "hello"))
+	container.AddValue(values.NewInt32Value("count", 3))
+	container.AddValue(values.NewBoolValue("ok", true))
+
+	var buf bytes.Buffer
+	if err := container.SerializeText(&buf); err != nil {
+		t.Fatalf("SerializeText failed: %v", err)
+	}
+
+	expected, err := container.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("SerializeText output differs from Serialize:\n got: %q\nwant: %q", buf.String(), expected)
+	}
+}
+
+func TestTextFormatEscapesDelimiters(t *testing.T) {
+	container := core.NewValueContainerFull("src|a", "line1\nline2", "dst", `back\slash`, "type|with|pipes")
+	container.AddValue(values.NewStringValue("key|name", "v"))
+	container.AddValue(values.NewNullValue("multi\nline"))
+
+	serialized, err := container.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	// Only the header terminator may appear as a raw newline
+	if strings.Count(serialized, "\n") != 1 {
+		t.Errorf("Expected exactly one raw newline, got %d in %q", strings.Count(serialized, "\n"), serialized)
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.Deserialize(serialized); err != nil {
+		t.Fatalf("Deserialize failed: %v", err)
+	}
+
+	if restored.SourceID() != "src|a" {
+		t.Errorf("SourceID mismatch: got %q", restored.SourceID())
+	}
+	if restored.SourceSubID() != "line1\nline2" {
+		t.Errorf("SourceSubID mismatch: got %q", restored.SourceSubID())
+	}
+	if restored.TargetSubID() != `back\slash` {
+		t.Errorf("TargetSubID mismatch: got %q", restored.TargetSubID())
+	}
+	if restored.MessageType() != "type|with|pipes" {
+		t.Errorf("MessageType mismatch: got %q", restored.MessageType())
+	}
+
+	// Value lines split back into name|type|size triples
+	valueFields := core.SplitTextFields(strings.SplitN(serialized, "\n", 2)[1])
+	if len(valueFields) != 6 {
+		t.Fatalf("Expected 6 value fields, got %d: %q", len(valueFields), valueFields)
+	}
+	if valueFields[0] != "key|name" {
+		t.Errorf("First value name mismatch: got %q", valueFields[0])
+	}
+	if valueFields[3] != "multi\nline" {
+		t.Errorf("Second value name mismatch: got %q", valueFields[3])
+	}
+}
+
+func TestEscapeTextFieldRoundTrip(t *testing.T) {
+	inputs := []string{"", "plain", "a|b", "x\ny", `c:\path`, "\\|\n\r|", "trailing\\"}
+	for _, input := range inputs {
+		fields := core.SplitTextFields(core.EscapeTextField(input))
+		if len(fields) != 1 || fields[0] != input {
+			t.Errorf("Round trip of %q produced %q", input, fields)
+		}
+	}
+}
+
+func TestValueContainerNormalizeVersion(t *testing.T) {
+	// A deserialized header without a version survives a header-only copy
+	source := core.NewValueContainer()
+	source.SetHeader(core.Header{SourceID: "wire", MessageType: "event"})
+	container := source.Copy(false)
+	if container.Version() != "" {
+		t.Fatalf("Expected empty version after copy, got %q", container.Version())
+	}
+
+	if !container.NormalizeVersion() {
+		t.Error("Expected NormalizeVersion to report a repair")
+	}
+	if container.Version() != core.DefaultVersion {
+		t.Errorf("Expected version %q, got %q", core.DefaultVersion, container.Version())
+	}
+	if container.NormalizeVersion() {
+		t.Error("Second NormalizeVersion should be a no-op")
+	}
+
+	versioned := core.NewValueContainer()
+	versioned.SetHeader(core.Header{Version: "2.1.0.0"})
+	if versioned.NormalizeVersion() || versioned.Version() != "2.1.0.0" {
+		t.Errorf("Set version should be left alone, got %q", versioned.Version())
+	}
+
+	if core.NewValueContainer().Version() != core.DefaultVersion {
+		t.Error("Constructors should stamp DefaultVersion")
+	}
+}
+
+func TestValueContainerTryGetValue(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		container := core.NewValueContainer()
+		container.AddValue(values.NewStringValue("tag", "first"))
+		container.AddValue(values.NewInt32Value("count", 3))
+		container.AddValue(values.NewStringValue("tag", "second"))
+		container.AddValue(core.NewBaseValue("empty", core.NullValue, nil))
+		if indexed {
+			container.EnableIndex()
+		}
+
+		for index, want := range []string{"first", "second"} {
+			v, ok := container.TryGetValue("tag", index)
+			if !ok {
+				t.Fatalf("indexed=%v: expected tag[%d] to exist", indexed, index)
+			}
+			if s, _ := v.ToString(); s != want {
+				t.Errorf("indexed=%v: expected tag[%d] = %q, got %q", indexed, index, want, s)
+			}
+		}
+
+		for _, missing := range []struct {
+			name  string
+			index int
+		}{{"tag", 2}, {"tag", -1}, {"absent", 0}, {"count", 1}} {
+			if v, ok := container.TryGetValue(missing.name, missing.index); ok || v != nil {
+				t.Errorf("indexed=%v: expected %s[%d] to be missing, got %v", indexed, missing.name, missing.index, v)
+			}
+		}
+
+		// A stored NullValue is present, unlike GetValue's placeholder
+		if v, ok := container.TryGetValue("empty", 0); !ok || v.Type() != core.NullValue {
+			t.Errorf("indexed=%v: expected stored null value to be found", indexed)
+		}
+	}
+}
+
+func TestValueContainerHasAndCount(t *testing.T) {
+	for _, mode := range []struct {
+		name  string
+		setup func(c *core.ValueContainer)
+	}{
+		{"plain", func(c *core.ValueContainer) {}},
+		{"thread-safe", func(c *core.ValueContainer) { c.EnableThreadSafe() }},
+		{"indexed", func(c *core.ValueContainer) { c.EnableIndex() }},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			container := core.NewValueContainer()
+			mode.setup(container)
+			container.AddValue(values.NewStringValue("name", "Alice"))
+			container.AddValue(values.NewNullValue("nickname"))
+			container.AddValue(values.NewStringValue("tag", "a"))
+			container.AddValue(values.NewStringValue("tag", "b"))
+			container.AddValue(values.NewStringValue("tag", "c"))
+
+			// Present
+			if !container.Has("name") || container.Count("name") != 1 {
+				t.Errorf("Expected one name, got Has=%v Count=%d", container.Has("name"), container.Count("name"))
+			}
+			// A null-typed value still exists
+			if !container.Has("nickname") {
+				t.Error("Has should find a NullValue field")
+			}
+			// Absent
+			if container.Has("missing") || container.Count("missing") != 0 {
+				t.Error("Expected no missing field")
+			}
+			// Duplicates
+			if container.Count("tag") != 3 {
+				t.Errorf("Expected 3 tags, got %d", container.Count("tag"))
+			}
+
+			container.RemoveValue("tag")
+			if container.Has("tag") {
+				t.Error("Removed field should be absent")
+			}
+		})
+	}
+}
+
+func TestValueContainerSetVersion(t *testing.T) {
+	container := core.NewValueContainer()
+	container.EnableThreadSafe()
+	container.SetVersion("3.0.0.0")
+	if container.Version() != "3.0.0.0" {
+		t.Errorf("Expected version '3.0.0.0', got '%s'", container.Version())
+	}
+	if container.Header().Version != "3.0.0.0" {
+		t.Error("Header should reflect SetVersion")
+	}
+}
+
+func TestValueContainerForEach(t *testing.T) {
+	container := core.NewValueContainer()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		container.AddValue(values.NewStringValue(name, name))
+	}
+
+	var visited []string
+	container.ForEach(func(v core.Value) bool {
+		visited = append(visited, v.Name())
+		return true
+	})
+	if strings.Join(visited, ",") != "a,b,c,d" {
+		t.Errorf("Expected values in order a,b,c,d, got %v", visited)
+	}
+
+	// Returning false stops the iteration
+	visited = nil
+	container.ForEach(func(v core.Value) bool {
+		visited = append(visited, v.Name())
+		return v.Name() != "b"
+	})
+	if strings.Join(visited, ",") != "a,b" {
+		t.Errorf("Expected iteration to stop after b, got %v", visited)
+	}
+}
+
+func TestValueContainerFind(t *testing.T) {
+	container := core.NewValueContainer()
+	container.EnableThreadSafe()
+	container.AddValue(values.NewStringValue("db.host", "localhost"))
+	container.AddValue(values.NewInt32Value("port", 8080))
+	container.AddValue(values.NewInt32Value("db.port", 5432))
+
+	hasPrefix := func(v core.Value) bool { return strings.HasPrefix(v.Name(), "db.") }
+	found := container.Find(hasPrefix)
+	if len(found) != 2 || found[0].Name() != "db.host" || found[1].Name() != "db.port" {
+		t.Fatalf("Expected db.host and db.port in order, got %v", found)
+	}
+	first, ok := container.FindFirst(hasPrefix)
+	if !ok || first.Name() != "db.host" {
+		t.Errorf("Expected db.host first, got %v, %v", first, ok)
+	}
+
+	noMatch := func(v core.Value) bool { return strings.HasPrefix(v.Name(), "cache.") }
+	if found := container.Find(noMatch); found == nil || len(found) != 0 {
+		t.Errorf("Expected an empty, non-nil result, got %#v", found)
+	}
+	if value, ok := container.FindFirst(noMatch); ok || value != nil {
+		t.Errorf("Expected no match, got %v, %v", value, ok)
+	}
+}
+
+func TestValueContainerRenameValue(t *testing.T) {
+	container := core.NewValueContainer()
+	container.EnableIndex()
+	container.AddValue(values.NewStringValue("tag", "a"))
+	container.AddValue(values.NewInt32Value("count", 1))
+	container.AddValue(values.NewStringValue("tag", "b"))
+
+	if renamed := container.RenameValue("tag", "label"); renamed != 2 {
+		t.Fatalf("Expected 2 values renamed, got %d", renamed)
+	}
+	if container.Has("tag") {
+		t.Error("Expected no value named tag after renaming")
+	}
+	labels := container.GetValues("label")
+	if len(labels) != 2 {
+		t.Fatalf("Expected 2 labels, got %d", len(labels))
+	}
+	if first, _ := labels[0].ToString(); first != "a" {
+		t.Errorf("Expected renamed values to keep their order, got %q first", first)
+	}
+	if renamed := container.RenameValue("missing", "other"); renamed != 0 {
+		t.Errorf("Expected nothing renamed, got %d", renamed)
+	}
+}
+
+func TestValueContainerTransformNames(t *testing.T) {
+	container := core.NewValueContainer()
+	container.AddValue(values.NewStringValue("userName", "kim"))
+	container.AddValue(values.NewInt32Value("userAge", 30))
+
+	container.TransformNames(func(name string) string { return name })
+	if !container.Has("userName") || !container.Has("userAge") {
+		t.Fatal("Identity transform should leave names unchanged")
+	}
+
+	container.TransformNames(func(name string) string {
+		return "user_" + strings.ToLower(strings.TrimPrefix(name, "user"))
+	})
+	if age, _ := container.GetValue("user_age", 0).ToInt32(); age != 30 {
+		t.Errorf("Expected user_age 30, got %d", age)
+	}
+	if !container.Has("user_name") || container.Has("userName") {
+		t.Error("Expected userName renamed to user_name")
+	}
+}
+
+func TestValueContainerForEachConcurrentWriters(t *testing.T) {
+	container := core.NewValueContainer()
+	container.EnableThreadSafe()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				container.AddValue(values.NewInt32Value("n", int32(i)))
+			}
+		}()
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				count := 0
+				container.ForEach(func(v core.Value) bool {
+					if v.Name() != "n" {
+						t.Errorf("Unexpected value %q", v.Name())
+					}
+					count++
+					return true
+				})
+				if count > 400 {
+					t.Errorf("Iterated %d values, at most 400 were added", count)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	count := 0
+	container.ForEach(func(core.Value) bool {
+		count++
+		return true
+	})
+	if count != 400 {
+		t.Errorf("Expected 400 values, got %d", count)
+	}
+}
+
+func TestValueContainerStatistics(t *testing.T) {
+	t.Run("ReadWriteCounts", func(t *testing.T) {
+		container := core.NewValueContainer()
+
+		if container.GetReadCount() != 0 {
+			t.Errorf("Expected read count 0, got %d", container.GetReadCount())
+		}
+		if container.GetWriteCount() != 0 {
+			t.Errorf("Expected write count 0, got %d", container.GetWriteCount())
+		}
+
+		container.AddValue(values.NewInt32Value("a", 1))
+		if container.GetWriteCount() != 1 {
+			t.Errorf("Expected write count 1, got %d", container.GetWriteCount())
+		}
+
+		container.AddValue(values.NewInt32Value("b", 2))
+		if container.GetWriteCount() != 2 {
+			t.Errorf("Expected write count 2, got %d", container.GetWriteCount())
+		}
+
+		container.GetValue("a", 0)
+		if container.GetReadCount() != 1 {
+			t.Errorf("Expected read count 1, got %d", container.GetReadCount())
+		}
+
+		container.GetValue("b", 0)
+		if container.GetReadCount() != 2 {
+			t.Errorf("Expected read count 2, got %d", container.GetReadCount())
+		}
+
+		// Misses are not counted, as with ValueStore.Get
+		container.GetValue("missing", 0)
+		if container.GetReadCount() != 2 {
+			t.Errorf("Expected read count 2 after a miss, got %d", container.GetReadCount())
+		}
+	})
+
+	t.Run("SerializationCount", func(t *testing.T) {
+		container := core.NewValueContainer()
+		container.AddValue(values.NewInt32Value("x", 10))
+
+		if container.GetSerializationCount() != 0 {
+			t.Errorf("Expected serialization count 0, got %d", container.GetSerializationCount())
+		}
+
+		container.SerializeBinary()
+		if container.GetSerializationCount() != 1 {
+			t.Errorf("Expected serialization count 1, got %d", container.GetSerializationCount())
+		}
+
+		container.ToJSONCompact()
+		if container.GetSerializationCount() != 2 {
+			t.Errorf("Expected serialization count 2, got %d", container.GetSerializationCount())
+		}
+
+		for _, format := range []core.SerializationFormat{core.FormatString, core.FormatMessagePack} {
+			container.Marshal(format)
+		}
+		if container.GetSerializationCount() != 4 {
+			t.Errorf("Expected serialization count 4, got %d", container.GetSerializationCount())
+		}
+	})
+
+	t.Run("ResetStatistics", func(t *testing.T) {
+		container := core.NewValueContainer()
+		container.AddValue(values.NewInt32Value("test", 1))
+		container.GetValue("test", 0)
+		container.SerializeBinary()
+
+		container.ResetStatistics()
+
+		if container.GetWriteCount() != 0 {
+			t.Errorf("Expected write count 0 after reset, got %d", container.GetWriteCount())
+		}
+		if container.GetReadCount() != 0 {
+			t.Errorf("Expected read count 0 after reset, got %d", container.GetReadCount())
+		}
+		if container.GetSerializationCount() != 0 {
+			t.Errorf("Expected serialization count 0 after reset, got %d", container.GetSerializationCount())
+		}
+	})
+
+	t.Run("ConcurrentWithoutThreadSafe", func(t *testing.T) {
+		container := core.NewValueContainer()
+		container.AddValue(values.NewInt32Value("x", 1))
+		container.ResetStatistics()
+
+		// Readers only, so no lock is needed; the counters must still add up
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					container.GetValue("x", 0)
+				}
+			}()
+		}
+		wg.Wait()
+		if container.GetReadCount() != 800 {
+			t.Errorf("Expected read count 800, got %d", container.GetReadCount())
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/containerhttp"
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestContainerHTTP_JSONRequest(t *testing.T) {
+	body, err := newSampleContainer().ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	container, err := containerhttp.FromRequest(req)
+	if err != nil {
+		t.Fatalf("FromRequest failed: %v", err)
+	}
+	if container.SourceID() != "client" || container.TargetSubID() != "2" ||
+		container.MessageType() != "sample" {
+		t.Errorf("Unexpected header: %+v", container.Header())
+	}
+}
+
+func TestContainerHTTP_MessagePackRequest(t *testing.T) {
+	var body bytes.Buffer
+	if err := newSampleContainer().WriteMessagePackTo(&body); err != nil {
+		t.Fatalf("WriteMessagePackTo failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", &body)
+	req.Header.Set("Content-Type", "application/x-msgpack")
+
+	container, err := containerhttp.FromRequest(req)
+	if err != nil {
+		t.Fatalf("FromRequest failed: %v", err)
+	}
+	if container.TargetID() != "server" || container.SourceSubID() != "1" {
+		t.Errorf("Unexpected header: %+v", container.Header())
+	}
+}
+
+func TestContainerHTTP_MultipartRequest(t *testing.T) {
+	payload, err := newSampleContainer().ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("comment", "skipped: no container media type"); err != nil {
+		t.Fatalf("WriteField failed: %v", err)
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="container"; filename="order.json"`)
+	header.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatalf("CreatePart failed: %v", err)
+	}
+	part.Write([]byte(payload))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	container, err := containerhttp.FromRequest(req)
+	if err != nil {
+		t.Fatalf("FromRequest failed: %v", err)
+	}
+	if container.MessageType() != "sample" || container.GetValue("id", 0).Type() != core.IntValue {
+		t.Errorf("Unexpected container: %+v", container.Header())
+	}
+
+	body.Reset()
+	writer = multipart.NewWriter(&body)
+	writer.WriteField("comment", "no container")
+	writer.Close()
+	req = httptest.NewRequest(http.MethodPost, "/orders", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if _, err := containerhttp.FromRequest(req); !errors.Is(err, containerhttp.ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType without a container part, got %v", err)
+	}
+}
+
+func TestContainerHTTP_WriteResponse(t *testing.T) {
+	for _, format := range []containerhttp.Format{
+		containerhttp.FormatJSON, containerhttp.FormatXML, containerhttp.FormatMessagePack,
+	} {
+		t.Run(format.String(), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if err := containerhttp.WriteResponse(rec, newSampleContainer(), format); err != nil {
+				t.Fatalf("WriteResponse failed: %v", err)
+			}
+			if got := rec.Header().Get("Content-Type"); got != format.ContentType() {
+				t.Errorf("Expected Content-Type %s, got %s", format.ContentType(), got)
+			}
+
+			// The response body can be read back as a request
+			req := httptest.NewRequest(http.MethodPost, "/", rec.Body)
+			req.Header.Set("Content-Type", rec.Header().Get("Content-Type"))
+			container, err := containerhttp.FromRequest(req)
+			if err != nil {
+				t.Fatalf("FromRequest failed: %v", err)
+			}
+			if container.MessageType() != "sample" {
+				t.Errorf("Message type not preserved: %s", container.MessageType())
+			}
+		})
+	}
+}
+
+func TestContainerHTTP_UnsupportedFormats(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x"))
+	req.Header.Set("Content-Type", "text/plain")
+	if _, err := containerhttp.FromRequest(req); !errors.Is(err, containerhttp.ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x"))
+	req.Header.Set("Content-Type", "application/cbor")
+	if _, err := containerhttp.FromRequest(req); !errors.Is(err, containerhttp.ErrNoCodec) {
+		t.Errorf("Expected ErrNoCodec for CBOR without a registered codec, got %v", err)
+	}
+}
+
+func TestContainerHTTP_RegisterCodec(t *testing.T) {
+	containerhttp.RegisterCodec(containerhttp.FormatCBOR, containerhttp.Codec{
+		Decode: func(data []byte) (*core.ValueContainer, error) {
+			container := core.NewValueContainer()
+			container.SetMessageType(string(data))
+			return container, nil
+		},
+		Encode: func(w io.Writer, c *core.ValueContainer) error {
+			_, err := io.WriteString(w, c.MessageType())
+			return err
+		},
+	})
+	defer containerhttp.RegisterCodec(containerhttp.FormatCBOR, containerhttp.Codec{})
+
+	rec := httptest.NewRecorder()
+	if err := containerhttp.WriteResponse(rec, newSampleContainer(), containerhttp.FormatCBOR); err != nil {
+		t.Fatalf("WriteResponse failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", rec.Body)
+	req.Header.Set("Content-Type", "application/cbor")
+	container, err := containerhttp.FromRequest(req)
+	if err != nil {
+		t.Fatalf("FromRequest failed: %v", err)
+	}
+	if container.MessageType() != "sample" {
+		t.Errorf("Registered codec not used: %s", container.MessageType())
+	}
+}
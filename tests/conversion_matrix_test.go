@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+// conversion is one ToXxx method of core.Value
+type conversion struct {
+	name string
+	call func(core.Value) (interface{}, error)
+}
+
+var conversions = []conversion{
+	{"ToBool", func(v core.Value) (interface{}, error) { r, err := v.ToBool(); return r, err }},
+	{"ToInt16", func(v core.Value) (interface{}, error) { r, err := v.ToInt16(); return r, err }},
+	{"ToUInt16", func(v core.Value) (interface{}, error) { r, err := v.ToUInt16(); return r, err }},
+	{"ToInt32", func(v core.Value) (interface{}, error) { r, err := v.ToInt32(); return r, err }},
+	{"ToUInt32", func(v core.Value) (interface{}, error) { r, err := v.ToUInt32(); return r, err }},
+	{"ToInt64", func(v core.Value) (interface{}, error) { r, err := v.ToInt64(); return r, err }},
+	{"ToUInt64", func(v core.Value) (interface{}, error) { r, err := v.ToUInt64(); return r, err }},
+	{"ToFloat32", func(v core.Value) (interface{}, error) { r, err := v.ToFloat32(); return r, err }},
+	{"ToFloat64", func(v core.Value) (interface{}, error) { r, err := v.ToFloat64(); return r, err }},
+	{"ToString", func(v core.Value) (interface{}, error) { r, err := v.ToString(); return r, err }},
+}
+
+// conversionRow is one value type of the conversion matrix. Supported maps
+// each supported conversion to its expected result for the sample value;
+// every other conversion must fail with core.ErrTypeConversion.
+type conversionRow struct {
+	value     core.Value
+	supported map[string]interface{}
+}
+
+func mustLong(v int64) core.Value {
+	value, err := values.NewLongValue("v", v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func mustULong(v uint64) core.Value {
+	value, err := values.NewULongValue("v", v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// conversionMatrix is the specification of the ToXxx conversions
+// (see "Conversion Matrix" in docs/API_REFERENCE.md)
+func conversionMatrix() []conversionRow {
+	return []conversionRow{
+		{values.NewNullValue("v"), map[string]interface{}{
+			"ToString": "null",
+		}},
+		{values.NewBoolValue("v", true), map[string]interface{}{
+			"ToBool": true, "ToInt32": int32(1), "ToInt64": int64(1), "ToString": "true",
+		}},
+		{values.NewInt16Value("v", -7), map[string]interface{}{
+			"ToInt16": int16(-7), "ToInt32": int32(-7), "ToInt64": int64(-7),
+		}},
+		{values.NewUInt16Value("v", 7), map[string]interface{}{
+			"ToUInt16": uint16(7), "ToUInt32": uint32(7), "ToUInt64": uint64(7),
+		}},
+		{values.NewInt32Value("v", -7), map[string]interface{}{
+			"ToInt32": int32(-7), "ToInt64": int64(-7),
+		}},
+		{values.NewUInt32Value("v", 7), map[string]interface{}{
+			"ToUInt32": uint32(7), "ToUInt64": uint64(7),
+		}},
+		{mustLong(-7), map[string]interface{}{
+			"ToInt32": int32(-7), "ToInt64": int64(-7),
+		}},
+		{mustULong(7), map[string]interface{}{
+			"ToUInt32": uint32(7), "ToUInt64": uint64(7),
+		}},
+		{values.NewInt64Value("v", -7), map[string]interface{}{
+			"ToInt64": int64(-7),
+		}},
+		{values.NewUInt64Value("v", 7), map[string]interface{}{
+			"ToUInt64": uint64(7),
+		}},
+		{values.NewFloat32Value("v", 7.5), map[string]interface{}{
+			"ToFloat32": float32(7.5), "ToFloat64": float64(7.5),
+		}},
+		{values.NewFloat64Value("v", 7.5), map[string]interface{}{
+			"ToFloat64": float64(7.5),
+		}},
+		{values.NewStringValue("v", "7"), map[string]interface{}{
+			"ToString": "7",
+		}},
+		{values.NewBytesValue("v", []byte{0xDE, 0xAD}), map[string]interface{}{
+			"ToString": "3q0=",
+		}},
+		{values.NewContainerValue("v", values.NewInt32Value("n", 1)), map[string]interface{}{}},
+		{values.NewArrayValue("v", values.NewInt32Value("", 1)), map[string]interface{}{}},
+		{mapWithEntry(), map[string]interface{}{}},
+		{values.NewDateTimeValue("v", time.Unix(7, 0).UTC()), map[string]interface{}{
+			"ToInt64": int64(7000000000), "ToString": "1970-01-01T00:00:07Z",
+		}},
+		{values.NewUUIDValue("v", [16]byte{15: 1}), map[string]interface{}{
+			"ToString": "00000000-0000-0000-0000-000000000001",
+		}},
+		{values.NewDecimalValue("v", big.NewInt(-75), 1), map[string]interface{}{
+			"ToString": "-7.5",
+		}},
+	}
+}
+
+func mapWithEntry() *values.MapValue {
+	m := values.NewMapValue("v")
+	m.Set("n", values.NewInt32Value("", 1))
+	return m
+}
+
+func TestConversionMatrix(t *testing.T) {
+	matrix := conversionMatrix()
+
+	covered := make(map[core.ValueType]bool)
+	for _, row := range matrix {
+		covered[row.value.Type()] = true
+	}
+	for vtype := core.NullValue; vtype.IsDefined(); vtype++ {
+		if !covered[vtype] {
+			t.Errorf("Conversion matrix has no row for %s", vtype.TypeName())
+		}
+	}
+
+	for _, row := range matrix {
+		for _, conv := range conversions {
+			t.Run(row.value.Type().TypeName()+"/"+conv.name, func(t *testing.T) {
+				got, err := conv.call(row.value)
+				want, supported := row.supported[conv.name]
+				if !supported {
+					if !errors.Is(err, core.ErrTypeConversion) {
+						t.Errorf("Expected ErrTypeConversion, got %v (%v)", got, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Expected %v, got error %v", want, err)
+				}
+				if got != want {
+					t.Errorf("Expected %v (%T), got %v (%T)", want, want, got, got)
+				}
+			})
+		}
+	}
+}
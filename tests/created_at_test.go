@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/wireprotocol"
+)
+
+// createdAtInput is a non-UTC timestamp with sub-millisecond precision
+var createdAtInput = time.Date(2024, 3, 9, 18, 30, 15, 123456789, time.FixedZone("KST", 9*60*60))
+
+func TestSetCreatedAtNormalizesToUTCMillis(t *testing.T) {
+	container := newSampleContainer()
+	container.SetCreatedAt(createdAtInput)
+
+	got := container.CreatedAt()
+	if got.Location() != time.UTC {
+		t.Errorf("Expected UTC, got %v", got.Location())
+	}
+	if !got.Equal(createdAtInput.Truncate(time.Millisecond)) {
+		t.Errorf("Expected %v, got %v", createdAtInput.Truncate(time.Millisecond), got)
+	}
+	if container.Header().CreatedAt != got {
+		t.Errorf("Header().CreatedAt = %v, want %v", container.Header().CreatedAt, got)
+	}
+	if copied := container.Copy(false); copied.CreatedAt() != got {
+		t.Errorf("Copy lost CreatedAt: %v", copied.CreatedAt())
+	}
+
+	container.SetCreatedAt(time.Time{})
+	if !container.CreatedAt().IsZero() {
+		t.Errorf("Expected zero time after clearing, got %v", container.CreatedAt())
+	}
+}
+
+func TestCreatedAtWireRoundTrip(t *testing.T) {
+	original := newSampleContainer()
+	original.SetCreatedAt(createdAtInput)
+	wire, err := wireprotocol.SerializeCppWire(original)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	want := "[7,1709976615123];"
+	if !strings.Contains(wire, want) {
+		t.Errorf("Expected %s in wire output, got %s", want, wire)
+	}
+
+	restored, err := wireprotocol.DeserializeCppWire(wire)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+	if restored.CreatedAt() != original.CreatedAt() {
+		t.Errorf("Expected CreatedAt %v, got %v", original.CreatedAt(), restored.CreatedAt())
+	}
+
+	// Rewriting the routing fields keeps the timestamp
+	header, payload, err := wireprotocol.SplitHeaderPayload([]byte(wire), wireprotocol.FormatCppWire)
+	if err != nil {
+		t.Fatalf("SplitHeaderPayload failed: %v", err)
+	}
+	header.TargetID = "archive"
+	rejoined, err := wireprotocol.DeserializeCppWire(string(wireprotocol.JoinHeaderPayload(header, payload, wireprotocol.FormatCppWire)))
+	if err != nil {
+		t.Fatalf("DeserializeCppWire of rejoined data failed: %v", err)
+	}
+	if rejoined.CreatedAt() != original.CreatedAt() {
+		t.Errorf("Expected CreatedAt %v after rejoin, got %v", original.CreatedAt(), rejoined.CreatedAt())
+	}
+}
+
+func TestCreatedAtOmittedWhenUnset(t *testing.T) {
+	container := newSampleContainer()
+
+	wire, err := wireprotocol.SerializeCppWire(container)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	if strings.Contains(wire, "[7,") {
+		t.Errorf("Expected no created_at field, got %s", wire)
+	}
+	restored, err := wireprotocol.DeserializeCppWire(wire)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+	if !restored.CreatedAt().IsZero() {
+		t.Errorf("Expected zero CreatedAt, got %v", restored.CreatedAt())
+	}
+
+	jsonData, err := container.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if strings.Contains(jsonData, "created_at") {
+		t.Errorf("Expected no created_at key in JSON, got %s", jsonData)
+	}
+
+	mp, err := container.Marshal(core.FormatMessagePack)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(mp), "created_at") {
+		t.Error("Expected no created_at key in MessagePack")
+	}
+}
+
+func TestCreatedAtJSONAndMessagePackRoundTrip(t *testing.T) {
+	original := newSampleContainer()
+	original.SetCreatedAt(createdAtInput)
+
+	jsonData, err := original.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if !strings.Contains(jsonData, `"created_at": 1709976615123`) {
+		t.Errorf("Expected epoch milliseconds in JSON, got %s", jsonData)
+	}
+	fromJSON := core.NewValueContainer()
+	if err := fromJSON.FromJSON(jsonData); err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if fromJSON.Header() != original.Header() {
+		t.Errorf("JSON: expected header %+v, got %+v", original.Header(), fromJSON.Header())
+	}
+
+	mp, err := original.Marshal(core.FormatMessagePack)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	fromMP := core.NewValueContainer()
+	if err := fromMP.Unmarshal(mp, core.FormatMessagePack); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if fromMP.Header() != original.Header() {
+		t.Errorf("MessagePack: expected header %+v, got %+v", original.Header(), fromMP.Header())
+	}
+	peeked, err := core.PeekHeaderMessagePack(mp)
+	if err != nil {
+		t.Fatalf("PeekHeaderMessagePack failed: %v", err)
+	}
+	if peeked != original.Header() {
+		t.Errorf("PeekHeaderMessagePack: expected header %+v, got %+v", original.Header(), peeked)
+	}
+}
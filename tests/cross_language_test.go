@@ -8,6 +8,7 @@ All rights reserved.
 package tests
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/kcenon/go_container_system/container/core"
@@ -212,6 +213,82 @@ func TestCrossLanguage_NestedContainer(t *testing.T) {
 	t.Log("✓ Nested container roundtrip successful")
 }
 
+// TestCrossLanguage_NestedContainerRoundTrip verifies the C++ nested container
+// encoding: a header cell carrying the child count followed by the children inline
+func TestCrossLanguage_NestedContainerRoundTrip(t *testing.T) {
+	nested := values.NewContainerValue("meta",
+		values.NewBoolValue("active", true),
+		values.NewNullValue("note"),
+	)
+	outer := values.NewContainerValue("profile",
+		values.NewStringValue("name", "alice"),
+		values.NewInt32Value("age", 30),
+		nested,
+	)
+
+	container := core.NewValueContainer()
+	container.SetMessageType("nested_test")
+	container.AddValue(outer)
+	container.AddValue(values.NewStringValue("request_id", "req-1"))
+
+	wireData, err := wireprotocol.SerializeCppWire(container)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+
+	expectedData := "@data={{[profile,container_value,3];[name,string_value,alice];[age,int_value,30];" +
+		"[meta,container_value,2];[active,bool_value,true];[note,null_value,];" +
+		"[request_id,string_value,req-1];}};"
+	if !strings.HasSuffix(wireData, expectedData) {
+		t.Fatalf("Unexpected wire data:\n%s\nexpected suffix:\n%s", wireData, expectedData)
+	}
+
+	restored, err := wireprotocol.DeserializeCppWire(wireData)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+
+	if got := len(restored.Values()); got != 2 {
+		t.Fatalf("Expected 2 top-level values, got %d", got)
+	}
+	requestID, _ := restored.GetValue("request_id", 0).ToString()
+	if requestID != "req-1" {
+		t.Errorf("Value after nested container lost: got %q", requestID)
+	}
+
+	profile := restored.GetValue("profile", 0)
+	if profile.Type() != core.ContainerValue || profile.ChildCount() != 3 {
+		t.Fatalf("Expected profile container with 3 children, got type %s with %d",
+			profile.Type().TypeName(), profile.ChildCount())
+	}
+	name, _ := profile.GetChild("name", 0).ToString()
+	age, _ := profile.GetChild("age", 0).ToInt32()
+	if name != "alice" || age != 30 {
+		t.Errorf("Primitive children mismatch: name=%q age=%d", name, age)
+	}
+
+	meta := profile.GetChild("meta", 0)
+	if meta == nil || meta.Type() != core.ContainerValue || meta.ChildCount() != 2 {
+		t.Fatal("Nested container meta not restored with 2 children")
+	}
+	active, _ := meta.GetChild("active", 0).ToBool()
+	if !active {
+		t.Error("Nested bool child mismatch")
+	}
+	if note := meta.GetChild("note", 0); note == nil || !note.IsNull() {
+		t.Error("Nested null child not restored")
+	}
+
+	// Serializing the restored container reproduces the same wire data
+	again, err := wireprotocol.SerializeCppWire(restored)
+	if err != nil {
+		t.Fatalf("SerializeCppWire (restored) failed: %v", err)
+	}
+	if again != wireData {
+		t.Errorf("Round trip mismatch:\n%s\n%s", wireData, again)
+	}
+}
+
 // TestCrossLanguage_ArrayValue tests array serialization/deserialization
 func TestCrossLanguage_ArrayValue(t *testing.T) {
 	// Create array with mixed types
@@ -258,6 +335,80 @@ func TestCrossLanguage_ArrayValue(t *testing.T) {
 	t.Log("✓ Array roundtrip successful")
 }
 
+// TestCrossLanguage_ArrayWireRoundTrip round-trips hardcoded C++ wire strings
+// carrying an empty array and a heterogeneous array with a nested array
+func TestCrossLanguage_ArrayWireRoundTrip(t *testing.T) {
+	wireData := "@header={{[1,cpp_server];[2,handler];[3,cpp_client];[4,session];[5,array_test];[6,1.0.0.0];}};" +
+		"@data={{[empty,array_value,0];" +
+		"[mixed,array_value,5];[,int_value,7];[,string_value,seven];[,bool_value,true];[,double_value,7.500000];" +
+		"[,array_value,2];[,bytes_value,0a0b];[,null_value,];" +
+		"[after,int_value,1];}};"
+
+	restored, err := wireprotocol.DeserializeCppWire(wireData)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+
+	if got := len(restored.Values()); got != 3 {
+		t.Fatalf("Expected 3 top-level values, got %d", got)
+	}
+
+	t.Run("EmptyArray", func(t *testing.T) {
+		empty, ok := restored.GetValue("empty", 0).(*values.ArrayValue)
+		if !ok {
+			t.Fatalf("empty: expected *ArrayValue, got %T", restored.GetValue("empty", 0))
+		}
+		if !empty.IsEmpty() {
+			t.Errorf("Expected empty array, got %d elements", empty.Count())
+		}
+	})
+
+	t.Run("HeterogeneousArray", func(t *testing.T) {
+		mixed, ok := restored.GetValue("mixed", 0).(*values.ArrayValue)
+		if !ok {
+			t.Fatalf("mixed: expected *ArrayValue, got %T", restored.GetValue("mixed", 0))
+		}
+		expectedTypes := []core.ValueType{
+			core.IntValue, core.StringValue, core.BoolValue, core.DoubleValue, core.ArrayValue,
+		}
+		if mixed.Count() != len(expectedTypes) {
+			t.Fatalf("Expected %d elements, got %d", len(expectedTypes), mixed.Count())
+		}
+		for i, want := range expectedTypes {
+			element, _ := mixed.At(i)
+			if element.Type() != want {
+				t.Errorf("Element %d: expected %s, got %s", i, want.TypeName(), element.Type().TypeName())
+			}
+		}
+
+		str, _ := mixed.Elements()[1].ToString()
+		if str != "seven" {
+			t.Errorf("String element mismatch: %q", str)
+		}
+		inner := mixed.Elements()[4].(*values.ArrayValue)
+		if inner.Count() != 2 || !inner.Elements()[1].IsNull() {
+			t.Errorf("Nested array not restored correctly: %d elements", inner.Count())
+		}
+	})
+
+	t.Run("ValueAfterArrays", func(t *testing.T) {
+		after, _ := restored.GetValue("after", 0).ToInt32()
+		if after != 1 {
+			t.Errorf("Value after arrays lost: got %d", after)
+		}
+	})
+
+	t.Run("Reserialize", func(t *testing.T) {
+		again, err := wireprotocol.SerializeCppWire(restored)
+		if err != nil {
+			t.Fatalf("SerializeCppWire failed: %v", err)
+		}
+		if again != wireData {
+			t.Errorf("Round trip mismatch:\nexpected: %s\ngot:      %s", wireData, again)
+		}
+	})
+}
+
 // TestCrossLanguage_TypeIDCompatibility verifies type IDs match C++/Python/.NET
 func TestCrossLanguage_TypeIDCompatibility(t *testing.T) {
 	// C++ value_types.h defines:
@@ -352,3 +503,42 @@ func TestCrossLanguage_BytesAndStringDistinct(t *testing.T) {
 
 	t.Log("✓ Bytes and String are correctly distinguished")
 }
+
+// TestCrossLanguage_LongOutOfRange ensures long_value/ulong_value data written
+// by a language with 64-bit longs is validated against the 32-bit range
+func TestCrossLanguage_LongOutOfRange(t *testing.T) {
+	testCases := map[string]string{
+		"LongTooLarge":    "[big,long_value,2147483648];",
+		"LongTooSmall":    "[big,long_value,-2147483649];",
+		"ULongTooLarge":   "[big,ulong_value,4294967296];",
+		"NestedLong":      "[outer,container_value,1];[big,long_value,5000000000];",
+		"ArrayULongValue": "[list,array_value,1];[,ulong_value,4294967296];",
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wire := "@header={{[5,test];}};@data={{" + data + "}};"
+			_, err := wireprotocol.DeserializeCppWire(wire)
+			if err == nil {
+				t.Fatal("Expected range error")
+			}
+			if !strings.Contains(err.Error(), "32-bit range") {
+				t.Errorf("Expected 32-bit range error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("InRange", func(t *testing.T) {
+		wire := "@header={{[5,test];}};@data={{[min,long_value,-2147483648];[max,ulong_value,4294967295];}};"
+		container, err := wireprotocol.DeserializeCppWire(wire)
+		if err != nil {
+			t.Fatalf("DeserializeCppWire failed: %v", err)
+		}
+		if v, ok := container.GetValue("min", 0).(*values.LongValue); !ok || v.Value() != -2147483648 {
+			t.Errorf("Expected LongValue -2147483648, got %v", container.GetValue("min", 0))
+		}
+		if v, ok := container.GetValue("max", 0).(*values.ULongValue); !ok || v.Value() != 4294967295 {
+			t.Errorf("Expected ULongValue 4294967295, got %v", container.GetValue("max", 0))
+		}
+	})
+}
@@ -3,9 +3,12 @@ package tests
 import (
 	"testing"
 
+	"go.uber.org/dig"
+
 	"github.com/kcenon/go_container_system/container/core"
 	"github.com/kcenon/go_container_system/container/di"
 	"github.com/kcenon/go_container_system/container/messaging"
+	"github.com/kcenon/go_container_system/container/values"
 )
 
 func TestNewContainerFactory(t *testing.T) {
@@ -121,6 +124,39 @@ func TestContainerFactoryNewBuilder(t *testing.T) {
 	}
 }
 
+func TestContainerFactoryNewFromPrototype(t *testing.T) {
+	factory := di.NewContainerFactory()
+
+	proto := factory.NewContainerFull("service", "1", "client", "", "response")
+	proto.AddValue(values.NewStringValue("status", "ok"))
+
+	headerOnly := factory.NewFromPrototype(proto, false)
+	if headerOnly.SourceID() != "service" || headerOnly.MessageType() != "response" {
+		t.Errorf("Expected prototype header, got %+v", headerOnly.Header())
+	}
+	if len(headerOnly.GetValues("status")) != 0 {
+		t.Error("Values should not be copied without withValues")
+	}
+
+	derived := factory.NewFromPrototype(proto, true)
+	if derived.Header() != proto.Header() {
+		t.Errorf("Expected header %+v, got %+v", proto.Header(), derived.Header())
+	}
+	if got, _ := derived.GetValue("status", 0).ToString(); got != "ok" {
+		t.Errorf("Expected copied value 'ok', got '%s'", got)
+	}
+
+	// Per-request changes must not leak back into the prototype
+	derived.AddValue(values.NewInt32Value("request_id", 42))
+	derived.SetMessageType("error")
+	if len(proto.GetValues("request_id")) != 0 {
+		t.Error("Prototype should not see values added to the derived container")
+	}
+	if proto.MessageType() != "response" {
+		t.Errorf("Prototype message type changed to '%s'", proto.MessageType())
+	}
+}
+
 func TestContainerFactoryInterface(t *testing.T) {
 	var factory di.ContainerFactory = di.NewContainerFactory()
 
@@ -134,6 +170,39 @@ func TestContainerFactoryInterface(t *testing.T) {
 	}
 }
 
+func TestRegisterDig(t *testing.T) {
+	container := dig.New()
+	if err := di.RegisterDig(container); err != nil {
+		t.Fatalf("RegisterDig failed: %v", err)
+	}
+
+	invoked := false
+	err := container.Invoke(func(factory di.ContainerFactory, builder *messaging.ContainerBuilder) {
+		invoked = true
+		if factory == nil || builder == nil {
+			t.Fatal("Dig should resolve non-nil dependencies")
+		}
+		c := factory.NewContainerWithType("request")
+		if c.MessageType() != "request" {
+			t.Errorf("Expected message type 'request', got '%s'", c.MessageType())
+		}
+		if _, err := builder.WithType("response").Build(); err != nil {
+			t.Errorf("Build failed: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if !invoked {
+		t.Error("Function depending on ContainerFactory was not invoked")
+	}
+
+	// Registering twice provides the same types again, which Dig rejects
+	if err := di.RegisterDig(container); err == nil {
+		t.Error("Expected an error when registering the providers twice")
+	}
+}
+
 func TestContainerFactoryMocking(t *testing.T) {
 	var factory di.ContainerFactory = &mockContainerFactory{}
 
@@ -168,3 +237,9 @@ func (m *mockContainerFactory) NewContainerFull(sourceID, sourceSubID, targetID,
 func (m *mockContainerFactory) NewBuilder() *messaging.ContainerBuilder {
 	return messaging.NewContainerBuilder()
 }
+
+func (m *mockContainerFactory) NewFromPrototype(proto *core.ValueContainer, withValues bool) *core.ValueContainer {
+	container := proto.Copy(withValues)
+	container.SetMessageType("mocked_" + proto.MessageType())
+	return container
+}
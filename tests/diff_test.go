@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+// diffSummary reduces diffs to "kind path" strings for comparison
+func diffSummary(diffs []core.ValueDiff) []string {
+	summary := make([]string, len(diffs))
+	for i, d := range diffs {
+		summary[i] = d.Kind.String() + " " + d.Path
+	}
+	return summary
+}
+
+func assertDiffs(t *testing.T, diffs []core.ValueDiff, expected ...string) {
+	t.Helper()
+	got := diffSummary(diffs)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected diffs %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Diff %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestContainerDiff_Identical(t *testing.T) {
+	a, b := newEqualTestContainer(), newEqualTestContainer()
+	if diffs := a.Diff(b); len(diffs) != 0 {
+		t.Errorf("Expected no diffs, got %v", diffSummary(diffs))
+	}
+}
+
+func TestContainerDiff_AddedField(t *testing.T) {
+	a, b := newEqualTestContainer(), newEqualTestContainer()
+	note := values.NewStringValue("note", "gift")
+	b.AddValue(note)
+
+	diffs := a.Diff(b)
+	assertDiffs(t, diffs, "Added note")
+	if diffs[0].Old != nil || diffs[0].New != note {
+		t.Errorf("Expected only New to be set, got %+v", diffs[0])
+	}
+}
+
+func TestContainerDiff_RemovedField(t *testing.T) {
+	a, b := newEqualTestContainer(), newEqualTestContainer()
+	b.RemoveValue("qty")
+
+	diffs := a.Diff(b)
+	assertDiffs(t, diffs, "Removed qty")
+	if n, _ := diffs[0].Old.ToInt32(); n != 3 || diffs[0].New != nil {
+		t.Errorf("Expected only Old to be set, got %+v", diffs[0])
+	}
+}
+
+func TestContainerDiff_ChangedScalar(t *testing.T) {
+	a, b := newEqualTestContainer(), newEqualTestContainer()
+	b.ReplaceValue("qty", 0, values.NewInt32Value("qty", 5))
+
+	diffs := a.Diff(b)
+	assertDiffs(t, diffs, "Modified qty")
+	if diffs[0].String() != "~ qty: 3 -> 5" {
+		t.Errorf("Unexpected String(): %s", diffs[0].String())
+	}
+}
+
+func TestContainerDiff_NestedChanges(t *testing.T) {
+	a := newEqualTestContainer()
+	b := newEqualTestContainer()
+	b.ReplaceValue("customer", 0, values.NewContainerValue("customer",
+		values.NewStringValue("name", "Lee"),
+		values.NewArrayValue("tags",
+			values.NewStringValue("", "vip"),
+			values.NewInt32Value("", 8),
+			values.NewBoolValue("", true),
+		),
+		values.NewStringValue("email", "lee@example.com"),
+	))
+
+	assertDiffs(t, a.Diff(b),
+		"Modified customer.name",
+		"Modified customer.tags[1]",
+		"Added customer.tags[2]",
+		"Added customer.email",
+	)
+
+	// The reverse direction reports the mirror-image changes
+	assertDiffs(t, b.Diff(a),
+		"Modified customer.name",
+		"Modified customer.tags[1]",
+		"Removed customer.tags[2]",
+		"Removed customer.email",
+	)
+}
+
+func TestContainerDiff_TypeChangeAndRepeatedNames(t *testing.T) {
+	a := core.NewValueContainerWithType("t",
+		values.NewInt32Value("item", 1),
+		values.NewInt32Value("item", 2),
+		values.NewContainerValue("meta", values.NewInt32Value("v", 1)),
+	)
+	b := core.NewValueContainerWithType("t",
+		values.NewInt32Value("item", 1),
+		values.NewInt32Value("item", 3),
+		values.NewStringValue("meta", "flat"),
+	)
+
+	assertDiffs(t, a.Diff(b), "Modified item[1]", "Modified meta")
+	if diffs := a.Diff(nil); len(diffs) != 3 {
+		t.Errorf("Expected every value removed against nil, got %v", diffSummary(diffs))
+	}
+}
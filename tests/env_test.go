@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestToEnv_NestedContainer(t *testing.T) {
+	container := core.NewValueContainer()
+	container.AddValue(values.NewStringValue("name", "worker"))
+	container.AddValue(values.NewInt32Value("threads", 8))
+	container.AddValue(values.NewBoolValue("debug", false))
+	container.AddValue(values.NewFloat64Value("ratio", 0.5))
+	container.AddValue(values.NewBytesValue("key", []byte{0xDE, 0xAD}))
+	container.AddValue(values.NewNullValue("unset"))
+	container.AddValue(values.NewDateTimeValue("started", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	container.AddValue(values.NewContainerValue("db",
+		values.NewStringValue("host", "localhost"),
+		values.NewUInt16Value("port", 5432),
+		values.NewContainerValue("pool", values.NewInt32Value("max-size", 10)),
+	))
+	container.AddValue(values.NewArrayValue("peers",
+		values.NewStringValue("", "a.example"),
+		values.NewStringValue("", "b.example"),
+	))
+
+	expected := map[string]string{
+		"APP_NAME":             "worker",
+		"APP_THREADS":          "8",
+		"APP_DEBUG":            "false",
+		"APP_RATIO":            "0.5",
+		"APP_KEY":              "0xdead",
+		"APP_UNSET":            "",
+		"APP_STARTED":          "2024-01-02T03:04:05Z",
+		"APP_DB_HOST":          "localhost",
+		"APP_DB_PORT":          "5432",
+		"APP_DB_POOL_MAX_SIZE": "10",
+		"APP_PEERS_0":          "a.example",
+		"APP_PEERS_1":          "b.example",
+	}
+
+	env := container.ToEnv("app")
+	if !reflect.DeepEqual(env, expected) {
+		t.Errorf("ToEnv mismatch:\n got %v\nwant %v", env, expected)
+	}
+
+	if env := container.ToEnv(""); env["DB_HOST"] != "localhost" || env["NAME"] != "worker" {
+		t.Errorf("ToEnv without prefix: got %v", env)
+	}
+}
+
+func TestToEnv_SanitizesPrefix(t *testing.T) {
+	container := core.NewValueContainer()
+	container.AddValue(values.NewStringValue("db.host", "localhost"))
+	container.AddValue(values.NewContainerValue("pool", values.NewInt32Value("max", 10)))
+
+	expected := map[string]string{
+		"MY_APP__DB_HOST":  "localhost",
+		"MY_APP__POOL_MAX": "10",
+	}
+	if env := container.ToEnv("my-app."); !reflect.DeepEqual(env, expected) {
+		t.Errorf("ToEnv mismatch:\n got %v\nwant %v", env, expected)
+	}
+}
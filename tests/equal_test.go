@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func newEqualTestContainer() *core.ValueContainer {
+	container := core.NewValueContainerFull("client", "1", "server", "2", "order")
+	container.AddValue(values.NewStringValue("id", "A-100"))
+	container.AddValue(values.NewInt32Value("qty", 3))
+	container.AddValue(values.NewContainerValue("customer",
+		values.NewStringValue("name", "Kim"),
+		values.NewArrayValue("tags", values.NewStringValue("", "vip"), values.NewInt32Value("", 7)),
+	))
+	return container
+}
+
+func TestContainerEqual_Equal(t *testing.T) {
+	a, b := newEqualTestContainer(), newEqualTestContainer()
+	b.EnableThreadSafe()
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Error("Identically built containers should be equal")
+	}
+	if !a.Equal(a) {
+		t.Error("A container should equal itself")
+	}
+	if !a.Equal(a.Copy(true)) {
+		t.Error("A deep copy should be equal")
+	}
+	if a.Equal(nil) {
+		t.Error("A container should not equal nil")
+	}
+}
+
+func TestContainerEqual_DifferentHeader(t *testing.T) {
+	a, b := newEqualTestContainer(), newEqualTestContainer()
+	b.SetMessageType("refund")
+	if a.Equal(b) {
+		t.Error("Containers with different message types should differ")
+	}
+
+	b = newEqualTestContainer()
+	b.SwapHeader()
+	if a.Equal(b) {
+		t.Error("Containers with swapped source and target should differ")
+	}
+}
+
+func TestContainerEqual_DifferentOrder(t *testing.T) {
+	a := core.NewValueContainerWithType("pair", values.NewInt32Value("x", 1), values.NewInt32Value("y", 2))
+	b := core.NewValueContainerWithType("pair", values.NewInt32Value("y", 2), values.NewInt32Value("x", 1))
+	if a.Equal(b) {
+		t.Error("Value order should matter")
+	}
+}
+
+func TestContainerEqual_NestedDifference(t *testing.T) {
+	a, b := newEqualTestContainer(), newEqualTestContainer()
+	customer := b.GetValue("customer", 0)
+	tags, ok := customer.GetChild("tags", 0).(*values.ArrayValue)
+	if !ok {
+		t.Fatal("Expected tags array")
+	}
+	tags.Append(values.NewStringValue("", "new"))
+	if a.Equal(b) {
+		t.Error("An extra array element should make containers differ")
+	}
+
+	b = newEqualTestContainer()
+	b.ReplaceValue("customer", 0, values.NewContainerValue("customer",
+		values.NewStringValue("name", "Lee"),
+		values.NewArrayValue("tags", values.NewStringValue("", "vip"), values.NewInt32Value("", 7)),
+	))
+	if a.Equal(b) {
+		t.Error("A different nested string should make containers differ")
+	}
+}
+
+func TestValuesEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  core.Value
+		equal bool
+	}{
+		{"same int", values.NewInt32Value("n", 1), values.NewInt32Value("n", 1), true},
+		{"different payload", values.NewInt32Value("n", 1), values.NewInt32Value("n", 2), false},
+		{"different name", values.NewInt32Value("n", 1), values.NewInt32Value("m", 1), false},
+		{"different type", values.NewInt32Value("n", 1), values.NewUInt32Value("n", 1), false},
+		{"empty bytes", values.NewBytesValue("b", nil), values.NewBytesValue("b", []byte{}), true},
+		{"nulls", values.NewNullValue("z"), values.NewNullValue("z"), true},
+		{"nil and value", nil, values.NewNullValue("z"), false},
+		{"both nil", nil, nil, true},
+		{
+			"nested arrays",
+			values.NewArrayValue("a", values.NewArrayValue("", values.NewBoolValue("", true))),
+			values.NewArrayValue("a", values.NewArrayValue("", values.NewBoolValue("", true))),
+			true,
+		},
+		{
+			"nested arrays differ",
+			values.NewArrayValue("a", values.NewArrayValue("", values.NewBoolValue("", true))),
+			values.NewArrayValue("a", values.NewArrayValue("", values.NewBoolValue("", false))),
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := core.ValuesEqual(tt.a, tt.b); got != tt.equal {
+				t.Errorf("ValuesEqual = %v, expected %v", got, tt.equal)
+			}
+		})
+	}
+}
+
+// newEventContainer builds an event whose business fields are id and amount
+// and whose ingestion metadata is the header and received_at
+func newEventContainer(source string, receivedAt int64, amount int32) *core.ValueContainer {
+	container := core.NewValueContainerFull(source, "", "billing", "", "payment")
+	container.AddValue(values.NewStringValue("id", "evt-1"))
+	container.AddValue(values.NewInt32Value("amount", amount))
+	container.AddValue(values.NewInt64Value("received_at", receivedAt))
+	return container
+}
+
+func TestContainerEqualsOn_IgnoresOtherFields(t *testing.T) {
+	a := newEventContainer("ingest-a", 1000, 250)
+	b := newEventContainer("ingest-b", 2000, 250)
+
+	if a.Equal(b) {
+		t.Fatal("Containers should differ in full")
+	}
+	if !a.EqualsOn(b, "id", "amount") {
+		t.Error("Containers should be equal on id and amount")
+	}
+	if a.EqualsOn(b, "id", "received_at") {
+		t.Error("Containers should differ on received_at")
+	}
+}
+
+func TestContainerEqualsOn_DetectsDifferenceInChosenField(t *testing.T) {
+	a := newEventContainer("ingest", 1000, 250)
+	b := newEventContainer("ingest", 1000, 300)
+
+	if a.EqualsOn(b, "id", "amount") {
+		t.Error("Containers should differ on amount")
+	}
+	if !a.EqualsOn(b, "id", "received_at") {
+		t.Error("Containers should be equal on id and received_at")
+	}
+}
+
+func TestContainerEqualsOn_MissingAndRepeatedFields(t *testing.T) {
+	a := newEventContainer("ingest", 1000, 250)
+	b := newEventContainer("ingest", 1000, 250)
+
+	if !a.EqualsOn(b) || !a.EqualsOn(b, "absent") {
+		t.Error("No fields, or a field absent from both, should compare equal")
+	}
+
+	b.AddValue(values.NewInt32Value("amount", 10))
+	if a.EqualsOn(b, "amount") {
+		t.Error("An extra occurrence of a chosen field should make containers differ")
+	}
+
+	b = newEventContainer("ingest", 1000, 250)
+	b.RemoveValue("id")
+	if a.EqualsOn(b, "id") {
+		t.Error("A field present on one side only should make containers differ")
+	}
+	if a.EqualsOn(nil, "id") {
+		t.Error("A container should not equal nil")
+	}
+}
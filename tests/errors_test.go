@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestSentinelErrors_TypeConversion(t *testing.T) {
+	if _, err := values.NewBoolValue("flag", true).ToFloat64(); !errors.Is(err, core.ErrTypeConversion) {
+		t.Errorf("bool to float64: expected ErrTypeConversion, got %v", err)
+	}
+	if _, err := values.NewNullValue("nothing").ToInt32(); !errors.Is(err, core.ErrTypeConversion) {
+		t.Errorf("null to int32: expected ErrTypeConversion, got %v", err)
+	}
+	if errors.Is(core.ErrTypeConversion, core.ErrIndexOutOfRange) {
+		t.Error("Sentinels must be distinct")
+	}
+}
+
+func TestSentinelErrors_NotContainer(t *testing.T) {
+	value := values.NewInt32Value("n", 1)
+	if err := value.AddChild(values.NewInt32Value("child", 2)); !errors.Is(err, core.ErrNotContainer) {
+		t.Errorf("AddChild: expected ErrNotContainer, got %v", err)
+	}
+	if err := value.RemoveChild("child"); !errors.Is(err, core.ErrNotContainer) {
+		t.Errorf("RemoveChild: expected ErrNotContainer, got %v", err)
+	}
+}
+
+func TestSentinelErrors_IndexOutOfRange(t *testing.T) {
+	array := values.NewArrayValue("list", values.NewInt32Value("", 1))
+	if _, err := array.At(1); !errors.Is(err, core.ErrIndexOutOfRange) {
+		t.Errorf("At(1): expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := array.At(-1); !errors.Is(err, core.ErrIndexOutOfRange) {
+		t.Errorf("At(-1): expected ErrIndexOutOfRange, got %v", err)
+	}
+}
+
+func TestSentinelErrors_TruncatedArray(t *testing.T) {
+	array := values.NewArrayValue("list",
+		values.NewStringValue("", "hello"),
+		values.NewContainerValue("nested", values.NewInt64Value("n", 7)),
+	)
+	data, err := array.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes failed: %v", err)
+	}
+
+	// Every proper prefix is truncated, including cuts inside nested frames
+	for cut := 0; cut < len(data); cut++ {
+		if _, err := values.DeserializeArrayValue(data[:cut]); !errors.Is(err, core.ErrTruncatedData) {
+			t.Fatalf("Cut at %d: expected ErrTruncatedData, got %v", cut, err)
+		}
+	}
+}
+
+func TestSentinelErrors_ValueStore(t *testing.T) {
+	store := core.NewValueStore()
+	store.Add("name", values.NewStringValue("name", "store"))
+	data, err := store.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+
+	for _, cut := range []int{1, 3, 7, len(data) - 1} {
+		if _, err := core.LoadValueStore(data[:cut]); !errors.Is(err, core.ErrTruncatedData) {
+			t.Errorf("Cut at %d: expected ErrTruncatedData, got %v", cut, err)
+		}
+	}
+
+	bad := append([]byte{0xEE}, data[1:]...)
+	if _, err := core.LoadValueStore(bad); !errors.Is(err, core.ErrUnsupportedVersion) {
+		t.Errorf("Expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestSentinelErrors_UnknownType(t *testing.T) {
+	if _, err := core.NewValueFromData("x", core.ValueType(200), nil); !errors.Is(err, core.ErrUnknownType) {
+		t.Errorf("Expected ErrUnknownType, got %v", err)
+	}
+}
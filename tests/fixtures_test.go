@@ -0,0 +1,24 @@
+package tests
+
+import (
+	"bytes"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+// newSampleContainer returns the container shared by the integration tests:
+// header client/1 -> server/2 of type "sample", one value of each common
+// kind (the 4 KiB blob keeps it larger than small write limits), then extra
+func newSampleContainer(extra ...core.Value) *core.ValueContainer {
+	units := []core.Value{
+		values.NewInt32Value("id", 1),
+		values.NewStringValue("name", "braces {in} text"),
+		values.NewInt64Value("amount", 125000),
+		values.NewFloat64Value("ratio", 0.25),
+		values.NewBytesValue("blob", bytes.Repeat([]byte{0xab}, 4096)),
+		values.NewArrayValue("list", values.NewInt32Value("", 1), values.NewInt32Value("", 2)),
+		values.NewContainerValue("lines", values.NewStringValue("sku", "A-1")),
+	}
+	return core.NewValueContainerFull("client", "1", "server", "2", "sample", append(units, extra...)...)
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+	"github.com/kcenon/go_container_system/container/wireprotocol"
+)
+
+func TestSplitHeaderPayload_RewriteTargetCppWire(t *testing.T) {
+	container := core.NewValueContainerFull("client", "c1", "broker", "b1", "order")
+	container.AddValue(values.NewStringValue("item", "book"))
+	container.AddValue(values.NewInt32Value("qty", 3))
+	container.AddValue(values.NewContainerValue("meta", values.NewBoolValue("gift", true)))
+
+	wire, err := wireprotocol.SerializeCppWire(container)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+
+	header, payload, err := wireprotocol.SplitHeaderPayload([]byte(wire), wireprotocol.FormatCppWire)
+	if err != nil {
+		t.Fatalf("SplitHeaderPayload failed: %v", err)
+	}
+	if header.TargetID != "broker" || header.SourceID != "client" || header.MessageType != "order" {
+		t.Errorf("Unexpected header: %+v", header)
+	}
+	if header.Version != "1.0.0.0" {
+		t.Errorf("Expected version 1.0.0.0, got %q", header.Version)
+	}
+
+	// Rewrite routing and re-join without touching the payload
+	header.TargetID = "warehouse"
+	header.TargetSubID = "w7"
+	rejoined := wireprotocol.JoinHeaderPayload(header, payload, wireprotocol.FormatCppWire)
+
+	restored, err := wireprotocol.DeserializeCppWire(string(rejoined))
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+	if restored.TargetID() != "warehouse" || restored.TargetSubID() != "w7" {
+		t.Errorf("Target not rewritten: %s/%s", restored.TargetID(), restored.TargetSubID())
+	}
+	if restored.SourceID() != "client" {
+		t.Errorf("Source changed: %s", restored.SourceID())
+	}
+
+	if item, _ := restored.GetValue("item", 0).ToString(); item != "book" {
+		t.Errorf("Expected item 'book', got '%s'", item)
+	}
+	if qty, _ := restored.GetValue("qty", 0).ToInt32(); qty != 3 {
+		t.Errorf("Expected qty 3, got %d", qty)
+	}
+	meta := restored.GetValue("meta", 0)
+	if meta.ChildCount() != 1 {
+		t.Fatalf("Expected nested meta with 1 child, got %d", meta.ChildCount())
+	}
+	if gift, _ := meta.GetChild("gift", 0).ToBool(); !gift {
+		t.Error("Expected nested gift=true")
+	}
+}
+
+func TestSplitHeaderPayload_Text(t *testing.T) {
+	container := core.NewValueContainerFull("src", "s|1", "dst", "d1", "note")
+	container.AddValue(values.NewStringValue("body", "x"))
+
+	text, err := container.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	header, payload, err := wireprotocol.SplitHeaderPayload([]byte(text), wireprotocol.FormatText)
+	if err != nil {
+		t.Fatalf("SplitHeaderPayload failed: %v", err)
+	}
+	if header.SourceSubID != "s|1" {
+		t.Errorf("Expected unescaped source sub ID 's|1', got %q", header.SourceSubID)
+	}
+
+	// Joining the unchanged header reproduces the original bytes
+	if joined := wireprotocol.JoinHeaderPayload(header, payload, wireprotocol.FormatText); string(joined) != text {
+		t.Errorf("Re-joined text differs:\n got: %q\nwant: %q", joined, text)
+	}
+}
+
+func TestSplitHeaderPayload_MissingHeader(t *testing.T) {
+	_, _, err := wireprotocol.SplitHeaderPayload([]byte("@data={{}};"), wireprotocol.FormatCppWire)
+	if !errors.Is(err, wireprotocol.ErrHeaderNotFound) {
+		t.Errorf("Expected ErrHeaderNotFound, got %v", err)
+	}
+}
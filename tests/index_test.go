@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+// assertLookupsMatch checks that indexed lookups agree with a linear scan
+func assertLookupsMatch(t *testing.T, indexed *core.ValueContainer, names ...string) {
+	t.Helper()
+	linear := core.NewValueContainer()
+	for _, v := range indexed.Values() {
+		linear.AddValue(v)
+	}
+	for _, name := range names {
+		want := linear.GetValues(name)
+		got := indexed.GetValues(name)
+		if len(got) != len(want) {
+			t.Fatalf("GetValues(%q): got %d values, want %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("GetValues(%q)[%d] differs from the linear scan", name, i)
+			}
+			if indexed.GetValue(name, i) != want[i] {
+				t.Errorf("GetValue(%q, %d) differs from the linear scan", name, i)
+			}
+		}
+		if len(indexed.GetValue(name, len(want)).Data()) != 0 || indexed.GetValue(name, -1).Name() != "" {
+			t.Errorf("Out-of-range GetValue(%q) should return the null placeholder", name)
+		}
+	}
+}
+
+func TestIndex_DuplicateNames(t *testing.T) {
+	c := core.NewValueContainer()
+	c.AddValue(values.NewStringValue("tag", "a"))
+	c.AddValue(values.NewInt32Value("id", 1))
+	c.EnableIndex()
+	c.AddValue(values.NewStringValue("tag", "b"))
+	c.AddValue(values.NewStringValue("tag", "c"))
+
+	if !c.IsIndexed() {
+		t.Fatal("Expected the index to be enabled")
+	}
+	if s, _ := c.GetValue("tag", 2).ToString(); s != "c" {
+		t.Errorf("Expected third tag c, got %q", s)
+	}
+	assertLookupsMatch(t, c, "tag", "id", "missing")
+}
+
+func TestIndex_StaysCorrectAcrossMutations(t *testing.T) {
+	c := core.NewValueContainer()
+	c.EnableThreadSafe()
+	c.EnableIndex()
+	names := []string{"a", "b", "c"}
+	for i := 0; i < 9; i++ {
+		c.AddValue(values.NewInt32Value(names[i%3], int32(i)))
+	}
+
+	if err := c.InsertValue(0, values.NewInt32Value("b", 100)); err != nil {
+		t.Fatalf("InsertValue failed: %v", err)
+	}
+	assertLookupsMatch(t, c, names...)
+
+	c.ReplaceValue("a", 1, values.NewInt32Value("c", 200))
+	assertLookupsMatch(t, c, names...)
+
+	c.RemoveValue("b")
+	assertLookupsMatch(t, c, names...)
+
+	c.TransformValues(func(v core.Value) (core.Value, bool) {
+		n, _ := v.ToInt32()
+		return values.NewInt32Value(fmt.Sprintf("n%d", n%2), n), n != 0
+	})
+	assertLookupsMatch(t, c, "n0", "n1", "a", "c")
+
+	other := core.NewValueContainer()
+	other.AddValue(values.NewInt32Value("n0", 300))
+	other.AddValue(values.NewInt32Value("z", 400))
+	if err := c.Merge(other, core.AppendAll); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	assertLookupsMatch(t, c, "n0", "n1", "z")
+
+	data, _ := c.SerializeBinary()
+	if err := c.DeserializeBinary(data); err != nil {
+		t.Fatalf("DeserializeBinary failed: %v", err)
+	}
+	assertLookupsMatch(t, c, "n0", "n1", "z")
+
+	c.ClearValues()
+	assertLookupsMatch(t, c, "n0", "z")
+	if !c.IsIndexed() {
+		t.Error("Mutations should not disable the index")
+	}
+
+	c.DisableIndex()
+	if c.IsIndexed() {
+		t.Error("Expected the index to be disabled")
+	}
+}
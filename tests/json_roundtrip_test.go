@@ -0,0 +1,364 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func jsonRoundTrip(t *testing.T, original *core.ValueContainer) *core.ValueContainer {
+	t.Helper()
+	jsonStr, err := original.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	restored := core.NewValueContainer()
+	if err := restored.FromJSON(jsonStr); err != nil {
+		t.Fatalf("FromJSON failed: %v\n%s", err, jsonStr)
+	}
+	return restored
+}
+
+func TestFromJSON_PreservesInt32(t *testing.T) {
+	original := core.NewValueContainerFull("client", "1", "server", "2", "user")
+	original.AddValue(values.NewInt32Value("age", 42))
+
+	restored := jsonRoundTrip(t, original)
+
+	age, err := restored.GetValue("age", 0).ToInt32()
+	if err != nil {
+		t.Fatalf("ToInt32 failed: %v", err)
+	}
+	if age != 42 {
+		t.Errorf("Expected age 42, got %d", age)
+	}
+	if restored.GetValue("age", 0).Type() != core.IntValue {
+		t.Errorf("Expected type int, got %s", restored.GetValue("age", 0).Type().TypeName())
+	}
+	if restored.Header() != original.Header() {
+		t.Errorf("Header mismatch: got %+v, want %+v", restored.Header(), original.Header())
+	}
+}
+
+func TestFromJSON_AllTypes(t *testing.T) {
+	long, _ := values.NewLongValue("long", -2147483648)
+	ulong, _ := values.NewULongValue("ulong", 4294967295)
+	id, _ := values.NewUUIDValueFromString("id", "123e4567-e89b-12d3-a456-426614174000")
+
+	original := core.NewValueContainerFull("client", "1", "server", "2", "all")
+	original.AddValue(values.NewNullValue("nothing"))
+	original.AddValue(values.NewBoolValue("flag", true))
+	original.AddValue(values.NewInt16Value("short", -12))
+	original.AddValue(values.NewUInt16Value("ushort", 65535))
+	original.AddValue(values.NewUInt32Value("uint", 4000000000))
+	original.AddValue(long)
+	original.AddValue(ulong)
+	original.AddValue(values.NewInt64Value("llong", math.MinInt64))
+	original.AddValue(values.NewUInt64Value("ullong", math.MaxUint64))
+	original.AddValue(values.NewFloat32Value("float", 1.5))
+	original.AddValue(values.NewFloat64Value("double", -math.MaxFloat64))
+	original.AddValue(values.NewStringValue("text", "héllo \"world\""))
+	original.AddValue(values.NewBytesValue("blob", []byte{0x00, 0xff, 0x10}))
+	original.AddValue(values.NewDateTimeValue("when", time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)))
+	original.AddValue(id)
+	original.AddValue(values.NewDecimalValue("price", big.NewInt(-12345), 3))
+	original.AddValue(values.NewContainerValue("customer",
+		values.NewStringValue("name", "Kim"),
+		values.NewArrayValue("tags", values.NewStringValue("", "vip"), values.NewInt32Value("", 7)),
+	))
+
+	restored := jsonRoundTrip(t, original)
+
+	if !restored.Equal(original) {
+		for _, diff := range original.Diff(restored) {
+			t.Errorf("Round-trip difference: %s", diff)
+		}
+		t.Fatal("Restored container should equal the original")
+	}
+}
+
+func TestFromJSON_NonUTF8BytesAreBase64(t *testing.T) {
+	payload := []byte{0x00, 0xFF, 0xFE}
+	original := core.NewValueContainer()
+	original.AddValue(values.NewBytesValue("blob", payload))
+
+	jsonStr, err := original.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if !json.Valid([]byte(jsonStr)) {
+		t.Fatalf("ToJSON produced invalid JSON:\n%s", jsonStr)
+	}
+	if !strings.Contains(jsonStr, `"AP/+"`) || !strings.Contains(jsonStr, `"encoding": "base64"`) {
+		t.Errorf("Expected base64 data with an encoding marker:\n%s", jsonStr)
+	}
+
+	restored := jsonRoundTrip(t, original)
+	blob, ok := restored.GetValue("blob", 0).(*values.BytesValue)
+	if !ok {
+		t.Fatalf("Expected *values.BytesValue, got %T", restored.GetValue("blob", 0))
+	}
+	if !bytes.Equal(blob.Value(), payload) {
+		t.Errorf("Expected %x, got %x", payload, blob.Value())
+	}
+}
+
+func TestFromJSON_ReplacesExistingValues(t *testing.T) {
+	original := core.NewValueContainer()
+	original.AddValue(values.NewStringValue("name", "Lee"))
+
+	restored := core.NewValueContainer()
+	restored.AddValue(values.NewStringValue("stale", "x"))
+	jsonStr, _ := original.ToJSON()
+	if err := restored.FromJSON(jsonStr); err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if len(restored.GetValues("stale")) != 0 {
+		t.Error("FromJSON should replace existing values")
+	}
+	if len(restored.GetValues("name")) != 1 {
+		t.Error("Expected the decoded value")
+	}
+}
+
+func TestFromJSON_UnmarshalFormatJSON(t *testing.T) {
+	original := core.NewValueContainer()
+	original.AddValue(values.NewInt32Value("age", 42))
+
+	data, err := original.Marshal(core.FormatJSON)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	restored := core.NewValueContainer()
+	if err := restored.Unmarshal(data, core.FormatJSON); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if age, err := restored.GetValue("age", 0).ToInt32(); err != nil || age != 42 {
+		t.Errorf("Expected age 42, got %d (%v)", age, err)
+	}
+}
+
+func TestFromJSON_Errors(t *testing.T) {
+	c := core.NewValueContainer()
+	if err := c.FromJSON("{not json"); err == nil {
+		t.Error("Expected an error for malformed JSON")
+	}
+	err := c.FromJSON(`{"values":[{"name":"x","type":"quaternion","data":1}]}`)
+	if !errors.Is(err, core.ErrUnknownValueType) {
+		t.Errorf("Expected ErrUnknownValueType, got %v", err)
+	}
+	if err := c.FromJSON(`{"values":[{"name":"x","type":"short","data":70000}]}`); err == nil {
+		t.Error("Expected an error for an out-of-range short")
+	}
+}
+
+func TestToJSONCompact_NoNewlinesAndRoundTrips(t *testing.T) {
+	original := newEqualTestContainer()
+	original.AddValue(values.NewBytesValue("blob", []byte{1, 2, 3}))
+
+	compact, err := original.ToJSONCompact()
+	if err != nil {
+		t.Fatalf("ToJSONCompact failed: %v", err)
+	}
+	if strings.ContainsAny(compact, "\n\t") {
+		t.Errorf("Compact JSON should have no newlines or tabs:\n%s", compact)
+	}
+	pretty, _ := original.ToJSON()
+	if len(compact) >= len(pretty) {
+		t.Errorf("Compact JSON (%d bytes) should be smaller than pretty JSON (%d bytes)", len(compact), len(pretty))
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.FromJSON(compact); err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if !restored.Equal(original) {
+		t.Error("Compact JSON should round-trip")
+	}
+}
+
+func TestToJSONWithOptions_Indent(t *testing.T) {
+	original := newEqualTestContainer()
+
+	tabbed, err := original.ToJSONWithOptions("\t")
+	if err != nil {
+		t.Fatalf("ToJSONWithOptions failed: %v", err)
+	}
+	if !strings.Contains(tabbed, "\n\t\"message_type\"") {
+		t.Errorf("Expected tab-indented JSON:\n%s", tabbed)
+	}
+	pretty, _ := original.ToJSON()
+	twoSpaces, _ := original.ToJSONWithOptions("  ")
+	if pretty != twoSpaces {
+		t.Error("ToJSON should match ToJSONWithOptions with two spaces")
+	}
+	compact, _ := original.ToJSONCompact()
+	if empty, _ := original.ToJSONWithOptions(""); empty != compact {
+		t.Error("An empty indent should produce the compact form")
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.FromJSON(tabbed); err != nil || !restored.Equal(original) {
+		t.Errorf("Tab-indented JSON should round-trip (%v)", err)
+	}
+}
+
+func TestToJSON_Deterministic(t *testing.T) {
+	build := func() *core.ValueContainer {
+		c := core.NewValueContainerFull("client", "1", "server", "main", "golden")
+		c.SetCreatedAt(time.UnixMilli(1700000000123))
+		m := values.NewMapValue("settings")
+		for _, key := range []string{"zeta", "alpha", "mid", "beta"} {
+			m.Set(key, values.NewStringValue(key, key+"-value"))
+		}
+		c.AddValue(m)
+		c.AddValue(values.NewContainerValue("nested",
+			values.NewInt64Value("big", math.MaxInt64),
+			values.NewBytesValue("raw", []byte{0xDE, 0xAD}),
+			values.NewDateTimeValue("when", time.Unix(1700000000, 42).UTC()),
+		))
+		c.AddValue(values.NewArrayValue("list",
+			values.NewFloat64Value("", 1.0/3),
+			values.NewBoolValue("", true),
+			values.NewNullValue(""),
+		))
+		return c
+	}
+
+	original := build()
+	first, err := original.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		again, err := original.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON failed: %v", err)
+		}
+		if again != first {
+			t.Fatalf("ToJSON output changed between calls:\n%s\n---\n%s", first, again)
+		}
+	}
+
+	// An independently built, equal container encodes to the same bytes
+	if other, _ := build().ToJSON(); other != first {
+		t.Errorf("Equal containers encoded differently:\n%s\n---\n%s", first, other)
+	}
+	compactA, _ := original.ToJSONCompact()
+	compactB, _ := build().ToJSONCompact()
+	if compactA != compactB {
+		t.Error("Compact JSON of equal containers should be identical")
+	}
+
+	// Header keys appear in a fixed order
+	keys := []string{`"created_at"`, `"message_type"`, `"source_id"`, `"source_sub_id"`,
+		`"target_id"`, `"target_sub_id"`, `"values"`, `"version"`}
+	last := -1
+	for _, key := range keys {
+		idx := strings.Index(compactA, key)
+		if idx <= last {
+			t.Fatalf("Key %s out of order in %s", key, compactA)
+		}
+		last = idx
+	}
+}
+
+func TestToJSONWith_NonFinitePolicy(t *testing.T) {
+	newContainer := func(v float64) *core.ValueContainer {
+		c := core.NewValueContainer()
+		c.AddValue(values.NewFloat64Value("ratio", v))
+		c.AddValue(values.NewArrayValue("samples", values.NewFloat64Value("", v), values.NewInt64Value("", math.MaxInt64)))
+		c.AddValue(values.NewStringValue("label", "NaN"))
+		return c
+	}
+
+	for _, tc := range []struct {
+		name  string
+		value float64
+		text  string
+	}{
+		{"nan", math.NaN(), `"NaN"`},
+		{"inf", math.Inf(1), `"+Inf"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newContainer(tc.value)
+
+			// NaNAsError is the default, for ToJSON and ToJSONCompact too
+			if _, err := c.ToJSONWith(core.JSONOptions{}); !errors.Is(err, core.ErrNonFiniteFloat) {
+				t.Errorf("Default options: expected ErrNonFiniteFloat, got %v", err)
+			}
+			if _, err := c.ToJSON(); !errors.Is(err, core.ErrNonFiniteFloat) {
+				t.Errorf("ToJSON: expected ErrNonFiniteFloat, got %v", err)
+			}
+			if _, err := c.ToJSONCompact(); !errors.Is(err, core.ErrNonFiniteFloat) {
+				t.Errorf("ToJSONCompact: expected ErrNonFiniteFloat, got %v", err)
+			}
+
+			// NaNAsString writes strings that FromJSON reads back
+			asString, err := c.ToJSONWith(core.JSONOptions{NonFinite: core.NaNAsString})
+			if err != nil {
+				t.Fatalf("NaNAsString failed: %v", err)
+			}
+			if n := strings.Count(asString, `"data":`+tc.text+`,"name":"ratio"`); n != 1 {
+				t.Errorf("Expected a string-encoded ratio in %s", asString)
+			}
+			restored := core.NewValueContainer()
+			if err := restored.FromJSON(asString); err != nil || !restored.Equal(c) {
+				t.Errorf("NaNAsString should round-trip (%v)", err)
+			}
+
+			// NaNAsNull writes null for the floats only
+			asNull, err := c.ToJSONWith(core.JSONOptions{NonFinite: core.NaNAsNull})
+			if err != nil {
+				t.Fatalf("NaNAsNull failed: %v", err)
+			}
+			if !json.Valid([]byte(asNull)) {
+				t.Fatalf("NaNAsNull produced invalid JSON: %s", asNull)
+			}
+			if n := strings.Count(asNull, `"data":null`); n != 2 {
+				t.Errorf("Expected 2 null values, found %d in %s", n, asNull)
+			}
+			if strings.Contains(asNull, `"data":`+tc.text+`,"name":"",`) {
+				t.Errorf("NaNAsNull kept a string-encoded float: %s", asNull)
+			}
+			if !strings.Contains(asNull, `{"name":"samples","type":"array","elements":[`) {
+				t.Errorf("NaNAsNull should keep the key order of array values: %s", asNull)
+			}
+			if !strings.Contains(asNull, `"data":"NaN","name":"label"`) {
+				t.Errorf("NaNAsNull should not touch string values: %s", asNull)
+			}
+			if !strings.Contains(asNull, "9223372036854775807") {
+				t.Errorf("NaNAsNull lost integer precision: %s", asNull)
+			}
+
+			// NaNAsError refuses to serialize
+			_, err = c.ToJSONWith(core.JSONOptions{NonFinite: core.NaNAsError})
+			if !errors.Is(err, core.ErrNonFiniteFloat) {
+				t.Errorf("Expected ErrNonFiniteFloat, got %v", err)
+			}
+		})
+	}
+
+	finite := newContainer(1.5)
+	for _, policy := range []core.NonFinitePolicy{core.NaNAsError, core.NaNAsString, core.NaNAsNull} {
+		got, err := finite.ToJSONWith(core.JSONOptions{Indent: "  ", NonFinite: policy})
+		if err != nil {
+			t.Errorf("%s failed on finite values: %v", policy, err)
+		}
+		if want, _ := finite.ToJSONWithOptions("  "); got != want {
+			t.Errorf("%s changed output for finite values", policy)
+		}
+	}
+
+	if _, err := finite.ToJSONWith(core.JSONOptions{NonFinite: core.NonFinitePolicy(9)}); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+// TestContainersJSONLRoundTrip writes three containers as JSON Lines and
+// reads them back
+func TestContainersJSONLRoundTrip(t *testing.T) {
+	var originals []*core.ValueContainer
+	for i := 0; i < 3; i++ {
+		c := core.NewValueContainerFull(fmt.Sprintf("svc%d", i), "main", "sink", "", "log_entry")
+		c.AddValue(values.NewInt32Value("seq", int32(i)))
+		c.AddValue(values.NewStringValue("msg", fmt.Sprintf("line one\nline %d", i)))
+		c.AddValue(values.NewBytesValue("blob", []byte{byte(i), 0xFF}))
+		originals = append(originals, c)
+	}
+
+	var buf bytes.Buffer
+	if err := core.WriteContainersJSONL(&buf, originals); err != nil {
+		t.Fatalf("WriteContainersJSONL failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("wrote %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+
+	restored, err := core.ReadContainersJSONL(&buf)
+	if err != nil {
+		t.Fatalf("ReadContainersJSONL failed: %v", err)
+	}
+	if len(restored) != len(originals) {
+		t.Fatalf("read %d containers, want %d", len(restored), len(originals))
+	}
+	for i := range originals {
+		if !originals[i].Equal(restored[i]) {
+			t.Errorf("container %d differs after round trip", i)
+		}
+	}
+}
+
+// TestReadContainersJSONLBlankLines checks that blank lines and a missing
+// final newline are tolerated
+func TestReadContainersJSONLBlankLines(t *testing.T) {
+	first := core.NewValueContainer()
+	first.AddValue(values.NewInt32Value("n", 1))
+	second := core.NewValueContainer()
+	second.AddValue(values.NewInt32Value("n", 2))
+
+	a, err := first.ToJSONCompact()
+	if err != nil {
+		t.Fatalf("ToJSONCompact failed: %v", err)
+	}
+	b, err := second.ToJSONCompact()
+	if err != nil {
+		t.Fatalf("ToJSONCompact failed: %v", err)
+	}
+
+	input := "\n" + a + "\n\n   \r\n" + b
+	restored, err := core.ReadContainersJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadContainersJSONL failed: %v", err)
+	}
+	if len(restored) != 2 {
+		t.Fatalf("read %d containers, want 2", len(restored))
+	}
+	if n, _ := restored[1].GetValue("n", 0).ToInt32(); n != 2 {
+		t.Errorf("second container n = %d, want 2", n)
+	}
+}
+
+// TestReadContainersJSONLReportsLine checks that a malformed line is
+// reported by number
+func TestReadContainersJSONLReportsLine(t *testing.T) {
+	c := core.NewValueContainer()
+	good, err := c.ToJSONCompact()
+	if err != nil {
+		t.Fatalf("ToJSONCompact failed: %v", err)
+	}
+
+	_, err = core.ReadContainersJSONL(strings.NewReader(good + "\n{not json\n"))
+	if err == nil {
+		t.Fatal("expected an error for a malformed line")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error %q does not name line 2", err)
+	}
+}
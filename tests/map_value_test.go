@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func mapValueContainer() *core.ValueContainer {
+	limits := values.NewMapValue("limits")
+	limits.Set("cpu", values.NewFloat64Value("", 1.5))
+	limits.Set("memory", values.NewInt64Value("", 512))
+	labels := values.NewMapValue("")
+	labels.Set("team", values.NewStringValue("", "core"))
+	limits.Set("labels", labels)
+
+	container := core.NewValueContainerFull("scheduler", "1", "node", "7", "job")
+	container.AddValue(values.NewStringValue("id", "job-42"))
+	container.AddValue(limits)
+	return container
+}
+
+func TestMapValueContainerRoundTrip(t *testing.T) {
+	original := mapValueContainer()
+
+	for _, format := range []core.SerializationFormat{core.FormatBinary, core.FormatJSON, core.FormatXML, core.FormatMessagePack} {
+		t.Run(format.String(), func(t *testing.T) {
+			data, err := original.Marshal(format)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			restored := core.NewValueContainer()
+			if err := restored.Unmarshal(data, format); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if diffs := original.Diff(restored); len(diffs) > 0 {
+				t.Errorf("Round trip changed values: %v", diffs)
+			}
+			if _, ok := restored.GetValue("limits", 0).(*values.MapValue); !ok {
+				t.Errorf("Expected *values.MapValue, got %T", restored.GetValue("limits", 0))
+			}
+		})
+	}
+
+	t.Run("proto", func(t *testing.T) {
+		data, err := original.ToProto()
+		if err != nil {
+			t.Fatalf("ToProto failed: %v", err)
+		}
+		restored := core.NewValueContainer()
+		if err := restored.FromProto(data); err != nil {
+			t.Fatalf("FromProto failed: %v", err)
+		}
+		if !original.Equal(restored) {
+			t.Errorf("Round trip changed values: %v", original.Diff(restored))
+		}
+	})
+}
+
+func TestMapValueToMapAndDiff(t *testing.T) {
+	original := mapValueContainer()
+
+	limits, ok := original.ToMap()["limits"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected limits as a nested map, got %T", original.ToMap()["limits"])
+	}
+	want := map[string]interface{}{
+		"cpu":    1.5,
+		"memory": int64(512),
+		"labels": map[string]interface{}{"team": "core"},
+	}
+	if !reflect.DeepEqual(limits, want) {
+		t.Errorf("Expected %v, got %v", want, limits)
+	}
+
+	// Overwriting a key is reported as a modification of that key
+	changed := original.Copy(true)
+	changedLimits := changed.GetValue("limits", 0).(*values.MapValue)
+	changedLimits.Set("memory", values.NewInt64Value("", 1024))
+	changedLimits.Set("gpu", values.NewInt32Value("", 1))
+
+	diffs := original.Diff(changed)
+	if len(diffs) != 2 {
+		t.Fatalf("Expected 2 diffs, got %v", diffs)
+	}
+	if diffs[0].Path != "limits.memory" || diffs[0].Kind != core.ChangeModified {
+		t.Errorf("Expected limits.memory modified, got %v", diffs[0])
+	}
+	if diffs[1].Path != "limits.gpu" || diffs[1].Kind != core.ChangeAdded {
+		t.Errorf("Expected limits.gpu added, got %v", diffs[1])
+	}
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+// mergedSummary lists name=value for each value in order
+func mergedSummary(c *core.ValueContainer) []string {
+	summary := make([]string, 0)
+	for _, v := range c.Values() {
+		if n, err := v.ToInt32(); err == nil {
+			summary = append(summary, fmt.Sprintf("%s=%d", v.Name(), n))
+			continue
+		}
+		s, _ := v.ToString()
+		summary = append(summary, v.Name()+"="+s)
+	}
+	return summary
+}
+
+func assertMerged(t *testing.T, c *core.ValueContainer, expected ...string) {
+	t.Helper()
+	got := mergedSummary(c)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Value %d: expected %s, got %s", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestMerge_Policies(t *testing.T) {
+	tests := []struct {
+		policy   core.MergePolicy
+		expected []string
+		target   string
+	}{
+		{core.OverwriteExisting, []string{"timeout=5", "retries=3", "trace=abc"}, "worker-7"},
+		{core.KeepExisting, []string{"timeout=30", "retries=3", "trace=abc"}, "worker"},
+		{core.AppendAll, []string{"timeout=30", "retries=3", "timeout=5", "trace=abc"}, "worker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			// Defaults with a timeout and retry count; a per-request override
+			// that collides on timeout and only sets the target
+			merged := core.NewValueContainerFull("gateway", "", "worker", "", "job",
+				values.NewInt32Value("timeout", 30), values.NewInt32Value("retries", 3))
+			overrides := core.NewValueContainerWithType("",
+				values.NewInt32Value("timeout", 5), values.NewStringValue("trace", "abc"))
+			overrides.SetHeader(core.Header{TargetID: "worker-7"})
+
+			if err := merged.Merge(overrides, tt.policy); err != nil {
+				t.Fatalf("Merge failed: %v", err)
+			}
+			assertMerged(t, merged, tt.expected...)
+			if merged.TargetID() != tt.target {
+				t.Errorf("Expected target %q, got %q", tt.target, merged.TargetID())
+			}
+			if merged.SourceID() != "gateway" || merged.MessageType() != "job" {
+				t.Errorf("Empty header fields should not overwrite, got %+v", merged.Header())
+			}
+		})
+	}
+}
+
+func TestMerge_RepeatedNamesAndIsolation(t *testing.T) {
+	base := core.NewValueContainerWithType("tags",
+		values.NewStringValue("tag", "a"),
+		values.NewStringValue("tag", "b"),
+	)
+	overlay := core.NewValueContainerWithType("",
+		values.NewStringValue("tag", "x"),
+		values.NewStringValue("tag", "y"),
+		values.NewStringValue("tag", "z"),
+	)
+	if err := base.Merge(overlay, core.OverwriteExisting); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	assertMerged(t, base, "tag=x", "tag=y", "tag=z")
+	if base.MessageType() != "tags" {
+		t.Errorf("Empty message type should not overwrite, got %q", base.MessageType())
+	}
+
+	// Merged values are copies
+	overlay.ReplaceValue("tag", 0, values.NewStringValue("tag", "changed"))
+	assertMerged(t, base, "tag=x", "tag=y", "tag=z")
+}
+
+func TestMerge_SelfAndInvalidPolicy(t *testing.T) {
+	merged := core.NewValueContainerFull("gateway", "", "worker", "", "job",
+		values.NewInt32Value("timeout", 30), values.NewInt32Value("retries", 3))
+	merged.EnableThreadSafe()
+	if err := merged.Merge(merged, core.AppendAll); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	assertMerged(t, merged, "timeout=30", "retries=3", "timeout=30", "retries=3")
+
+	if err := merged.Merge(core.NewValueContainer(), core.MergePolicy(99)); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+	if err := merged.Merge(nil, core.OverwriteExisting); err != nil {
+		t.Errorf("Merging nil should be a no-op, got %v", err)
+	}
+}
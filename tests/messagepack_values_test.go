@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// newMessagePackTypesContainer holds one value of every type, with
+// boundary values for the numeric types
+func newMessagePackTypesContainer(t *testing.T) *core.ValueContainer {
+	t.Helper()
+	long, err := values.NewLongValue("long", math.MinInt32)
+	if err != nil {
+		t.Fatalf("NewLongValue failed: %v", err)
+	}
+	ulong, err := values.NewULongValue("ulong", math.MaxUint32)
+	if err != nil {
+		t.Fatalf("NewULongValue failed: %v", err)
+	}
+	id, err := values.NewUUIDValueFromString("uuid", "123e4567-e89b-12d3-a456-426614174000")
+	if err != nil {
+		t.Fatalf("NewUUIDValueFromString failed: %v", err)
+	}
+
+	container := core.NewValueContainerFull("mp_source", "1", "mp_target", "2", "msgpack_types")
+	container.AddValue(values.NewNullValue("null"))
+	container.AddValue(values.NewBoolValue("bool", true))
+	container.AddValue(values.NewInt16Value("short", math.MinInt16))
+	container.AddValue(values.NewUInt16Value("ushort", math.MaxUint16))
+	container.AddValue(values.NewInt32Value("int", -123456789))
+	container.AddValue(values.NewUInt32Value("uint", 3000000000))
+	container.AddValue(long)
+	container.AddValue(ulong)
+	container.AddValue(values.NewInt64Value("llong", math.MinInt64))
+	container.AddValue(values.NewUInt64Value("ullong", math.MaxUint64))
+	container.AddValue(values.NewFloat32Value("float", -1.25))
+	container.AddValue(values.NewFloat64Value("double", 0.1))
+	container.AddValue(values.NewFloat64Value("nan", math.NaN()))
+	container.AddValue(values.NewStringValue("string", "héllo"))
+	container.AddValue(values.NewBytesValue("bytes", []byte{0x00, 0xff, 0x10}))
+	container.AddValue(values.NewDateTimeValue("datetime", time.Unix(1700000000, 123456789)))
+	container.AddValue(id)
+	container.AddValue(values.NewDecimalValue("decimal", big.NewInt(-1234567), 3))
+	container.AddValue(values.NewContainerValue("nested",
+		values.NewInt16Value("id", 7),
+		values.NewStringValue("label", "inner"),
+	))
+	container.AddValue(values.NewArrayValue("list",
+		values.NewUInt16Value("", 1),
+		values.NewFloat32Value("", 2.5),
+	))
+	return container
+}
+
+func TestMessagePackRoundTripAllTypes(t *testing.T) {
+	original := newMessagePackTypesContainer(t)
+	data, err := original.Marshal(core.FormatMessagePack)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.Unmarshal(data, core.FormatMessagePack); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if restored.Header() != original.Header() {
+		t.Errorf("Header mismatch: %+v vs %+v", restored.Header(), original.Header())
+	}
+	if diffs := original.Diff(restored); len(diffs) != 0 {
+		t.Errorf("Round trip changed values: %v", diffs)
+	}
+	for _, v := range original.Values() {
+		got, ok := restored.TryGetValue(v.Name(), 0)
+		if !ok || got.Type() != v.Type() {
+			t.Errorf("%s: expected type %s, got %v", v.Name(), v.Type().TypeName(), got)
+		}
+	}
+}
+
+func TestMessagePackNativeScalars(t *testing.T) {
+	data, err := newMessagePackTypesContainer(t).Marshal(core.FormatMessagePack)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	// A generic MessagePack reader sees natural types instead of raw bytes
+	var decoded struct {
+		Values []struct {
+			Name string      `msgpack:"name"`
+			Type string      `msgpack:"type"`
+			Data interface{} `msgpack:"data"`
+		} `msgpack:"values"`
+	}
+	if err := msgpack.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	byName := make(map[string]interface{})
+	for _, v := range decoded.Values {
+		byName[v.Name] = widenNumber(v.Data)
+	}
+
+	want := map[string]interface{}{
+		"null":    nil,
+		"bool":    true,
+		"short":   int64(math.MinInt16),
+		"ushort":  uint64(math.MaxUint16),
+		"int":     int64(-123456789),
+		"uint":    uint64(3000000000),
+		"long":    int64(math.MinInt32),
+		"ulong":   uint64(math.MaxUint32),
+		"llong":   int64(math.MinInt64),
+		"ullong":  uint64(math.MaxUint64),
+		"float":   float64(-1.25),
+		"double":  0.1,
+		"string":  "héllo",
+		"decimal": "-1234.567",
+	}
+	for name, w := range want {
+		if got := byName[name]; got != w {
+			t.Errorf("%s: expected %v (%T), got %v (%T)", name, w, w, got, got)
+		}
+	}
+	if got, ok := byName["bytes"].([]byte); !ok || !bytes.Equal(got, []byte{0x00, 0xff, 0x10}) {
+		t.Errorf("bytes: expected bin data, got %v (%T)", byName["bytes"], byName["bytes"])
+	}
+	if got, ok := byName["datetime"].(time.Time); !ok || !got.Equal(time.Unix(1700000000, 123456789)) {
+		t.Errorf("datetime: expected timestamp, got %v (%T)", byName["datetime"], byName["datetime"])
+	}
+	if got, ok := byName["nested"].([]interface{}); !ok || len(got) != 2 {
+		t.Errorf("nested: expected array of 2 value maps, got %v (%T)", byName["nested"], byName["nested"])
+	}
+}
+
+// widenNumber converts the sized integer and float types that MessagePack
+// decoding yields to int64, uint64 and float64
+func widenNumber(v interface{}) interface{} {
+	switch n := v.(type) {
+	case int8:
+		return int64(n)
+	case int16:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case uint8:
+		return uint64(n)
+	case uint16:
+		return uint64(n)
+	case uint32:
+		return uint64(n)
+	case float32:
+		return float64(n)
+	}
+	return v
+}
+
+func TestMessagePackReadsLegacyValueLayout(t *testing.T) {
+	original := core.NewValueContainerFull("legacy", "1", "reader", "2", "legacy_values")
+	original.AddValue(values.NewInt16Value("short", -2))
+	original.AddValue(values.NewUInt64Value("ullong", math.MaxUint64))
+	original.AddValue(values.NewFloat64Value("double", 2.75))
+	original.AddValue(values.NewBoolValue("bool", false))
+	original.AddValue(values.NewStringValue("string", "old"))
+	original.AddValue(values.NewBytesValue("bytes", []byte{1, 2, 3}))
+
+	// The legacy layout stored every value's raw Data() as bin
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	legacy := map[string]interface{}{
+		"source_id":     "legacy",
+		"source_sub_id": "1",
+		"target_id":     "reader",
+		"target_sub_id": "2",
+		"message_type":  "legacy_values",
+		"version":       core.DefaultVersion,
+	}
+	var items []interface{}
+	for _, v := range original.Values() {
+		items = append(items, map[string]interface{}{
+			"name": v.Name(),
+			"type": v.Type().String(),
+			"data": v.Data(),
+		})
+	}
+	legacy["values"] = items
+	if err := enc.Encode(legacy); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.Unmarshal(buf.Bytes(), core.FormatMessagePack); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if restored.Header() != original.Header() {
+		t.Errorf("Header mismatch: %+v vs %+v", restored.Header(), original.Header())
+	}
+	if diffs := original.Diff(restored); len(diffs) != 0 {
+		t.Errorf("Legacy values not restored: %v", diffs)
+	}
+}
+
+func TestMessagePackRejectsMismatchedData(t *testing.T) {
+	var buf bytes.Buffer
+	err := msgpack.NewEncoder(&buf).Encode(map[string]interface{}{
+		"message_type": "bad",
+		"values": []interface{}{
+			map[string]interface{}{"name": "n", "type": core.ShortValue.String(), "data": 70000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if err := core.NewValueContainer().Unmarshal(buf.Bytes(), core.FormatMessagePack); err == nil {
+		t.Error("Expected error for an int16 value out of range")
+	}
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestMutationLog_Replay(t *testing.T) {
+	var buf bytes.Buffer
+	log := core.NewMutationLog(core.NewValueContainer(), &buf)
+
+	steps := []func() error{
+		func() error {
+			return log.SetHeader(core.Header{SourceID: "cache", TargetID: "disk", MessageType: "state", Version: core.DefaultVersion})
+		},
+		func() error { return log.AddValue(values.NewStringValue("user", "Kim")) },
+		func() error { return log.AddValue(values.NewInt32Value("hits", 1)) },
+		func() error { return log.AddValue(values.NewStringValue("tag", "a")) },
+		func() error { return log.AddValue(values.NewStringValue("tag", "b")) },
+		func() error {
+			_, err := log.ReplaceValue("hits", 0, values.NewInt32Value("hits", 2))
+			return err
+		},
+		func() error {
+			_, err := log.ReplaceValue("tag", 1, values.NewStringValue("tag", "c"))
+			return err
+		},
+		func() error { return log.RemoveValue("user") },
+		func() error {
+			return log.AddValue(values.NewContainerValue("meta",
+				values.NewBoolValue("dirty", true),
+				values.NewArrayValue("ids", values.NewInt32Value("", 7), values.NewInt32Value("", 8)),
+			))
+		},
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("Mutation %d failed: %v", i, err)
+		}
+	}
+
+	t.Run("RebuildsContainer", func(t *testing.T) {
+		replayed, err := core.Replay(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("Replay failed: %v", err)
+		}
+		if !replayed.Equal(log.Container()) {
+			for _, diff := range log.Container().Diff(replayed) {
+				t.Errorf("Replay difference: %s", diff)
+			}
+			t.Fatal("Replayed container should equal the logged container")
+		}
+		if hits, _ := replayed.GetValue("hits", 0).ToInt32(); hits != 2 {
+			t.Errorf("Expected hits 2, got %d", hits)
+		}
+		if len(replayed.GetValues("user")) != 0 {
+			t.Error("Removed value should not be replayed")
+		}
+	})
+
+	t.Run("TornRecord", func(t *testing.T) {
+		full := buf.Len()
+		if err := log.AddValue(values.NewStringValue("last", "torn")); err != nil {
+			t.Fatalf("AddValue failed: %v", err)
+		}
+
+		// Simulate a crash in the middle of writing the last record
+		torn := buf.Bytes()[:full+3]
+		replayed, err := core.Replay(bytes.NewReader(torn))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("Expected io.ErrUnexpectedEOF, got %v", err)
+		}
+		if len(replayed.GetValues("last")) != 0 {
+			t.Error("Torn record should not be applied")
+		}
+		if len(replayed.GetValues("hits")) != 1 {
+			t.Error("Records before the torn one should be applied")
+		}
+	})
+}
+
+func TestMutationLog_ReplaceMissingValue(t *testing.T) {
+	var buf bytes.Buffer
+	log := core.NewMutationLog(core.NewValueContainer(), &buf)
+	replaced, err := log.ReplaceValue("missing", 0, values.NewInt32Value("missing", 1))
+	if err != nil || replaced {
+		t.Fatalf("Expected no replacement and no error, got %v, %v", replaced, err)
+	}
+
+	replayed, err := core.Replay(&buf)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if len(replayed.Values()) != 0 {
+		t.Error("Replaying a missed replacement should be a no-op")
+	}
+}
+
+func TestMutationLog_ReplayUnknownOp(t *testing.T) {
+	if _, err := core.Replay(bytes.NewReader([]byte{0xEE})); err == nil {
+		t.Error("Expected an error for an unknown mutation op")
+	}
+}
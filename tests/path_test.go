@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func newPathTestContainer() *core.ValueContainer {
+	container := core.NewValueContainer()
+	container.AddValue(values.NewContainerValue("profile",
+		values.NewStringValue("name", "Alice"),
+		values.NewContainerValue("address",
+			values.NewStringValue("city", "Seoul"),
+			values.NewStringValue("zip", "04524"),
+		),
+	))
+	container.AddValue(values.NewArrayValue("items",
+		values.NewContainerValue("", values.NewStringValue("name", "first")),
+		values.NewContainerValue("", values.NewStringValue("name", "second")),
+		values.NewContainerValue("", values.NewStringValue("name", "third")),
+	))
+	container.AddValue(values.NewArrayValue("matrix",
+		values.NewArrayValue("", values.NewInt32Value("", 1), values.NewInt32Value("", 2)),
+		values.NewArrayValue("", values.NewInt32Value("", 3), values.NewInt32Value("", 4)),
+	))
+	return container
+}
+
+func TestGetValueByPath_DeepFetch(t *testing.T) {
+	container := newPathTestContainer()
+
+	city, err := container.GetValueByPath("profile.address.city")
+	if err != nil {
+		t.Fatalf("GetValueByPath failed: %v", err)
+	}
+	if str, _ := city.ToString(); str != "Seoul" {
+		t.Errorf("Expected 'Seoul', got '%s'", str)
+	}
+
+	name, err := container.GetValueByPath("items[2].name")
+	if err != nil {
+		t.Fatalf("GetValueByPath failed: %v", err)
+	}
+	if str, _ := name.ToString(); str != "third" {
+		t.Errorf("Expected 'third', got '%s'", str)
+	}
+
+	cell, err := container.GetValueByPath("matrix[1][0]")
+	if err != nil {
+		t.Fatalf("GetValueByPath failed: %v", err)
+	}
+	if val, _ := cell.ToInt32(); val != 3 {
+		t.Errorf("Expected 3, got %d", val)
+	}
+}
+
+func TestGetValueByPath_MissingMiddleSegment(t *testing.T) {
+	container := newPathTestContainer()
+
+	_, err := container.GetValueByPath("profile.location.city")
+	if !errors.Is(err, core.ErrPathNotFound) {
+		t.Fatalf("Expected ErrPathNotFound, got %v", err)
+	}
+
+	var pathErr *core.PathError
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("Expected *core.PathError, got %T", err)
+	}
+	if pathErr.Segment != "location" {
+		t.Errorf("Expected failing segment 'location', got '%s'", pathErr.Segment)
+	}
+}
+
+func TestGetValueByPath_IndexOutOfRange(t *testing.T) {
+	container := newPathTestContainer()
+
+	_, err := container.GetValueByPath("items[5].name")
+	if !errors.Is(err, core.ErrPathIndexOutOfRange) {
+		t.Fatalf("Expected ErrPathIndexOutOfRange, got %v", err)
+	}
+
+	_, err = container.GetValueByPath("profile[0]")
+	if !errors.Is(err, core.ErrPathNotIndexable) {
+		t.Errorf("Expected ErrPathNotIndexable, got %v", err)
+	}
+}
+
+func TestGetValueByPath_InvalidSyntax(t *testing.T) {
+	container := newPathTestContainer()
+
+	for _, path := range []string{"", "profile..city", "items[x]", "items[1", "[0]"} {
+		if _, err := container.GetValueByPath(path); !errors.Is(err, core.ErrInvalidPath) {
+			t.Errorf("Path %q: expected ErrInvalidPath, got %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestPeekHeaderMatchesFullParse(t *testing.T) {
+	original := newSampleContainer()
+	original.SetVersion("2.0.0.0")
+	data, err := original.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+
+	full := core.NewValueContainer()
+	if err := full.DeserializeBinary(data); err != nil {
+		t.Fatalf("DeserializeBinary failed: %v", err)
+	}
+
+	header, err := core.PeekHeader(data)
+	if err != nil {
+		t.Fatalf("PeekHeader failed: %v", err)
+	}
+	if header != full.Header() {
+		t.Errorf("Expected header %+v, got %+v", full.Header(), header)
+	}
+}
+
+func TestPeekHeaderIgnoresValueSection(t *testing.T) {
+	original := newSampleContainer()
+	original.SetVersion("2.0.0.0")
+	data, err := original.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+
+	// Cut the data inside the value section: a full parse fails, the header
+	// is still readable
+	truncated := data[:len(data)-3]
+	if err := core.NewValueContainer().DeserializeBinary(truncated); err == nil {
+		t.Fatal("Expected DeserializeBinary to fail on truncated data")
+	}
+	header, err := core.PeekHeader(truncated)
+	if err != nil {
+		t.Fatalf("PeekHeader failed: %v", err)
+	}
+	if header != original.Header() {
+		t.Errorf("Expected header %+v, got %+v", original.Header(), header)
+	}
+}
+
+func TestPeekHeaderErrors(t *testing.T) {
+	data, err := newSampleContainer().SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+
+	if _, err := core.PeekHeader(nil); err == nil {
+		t.Error("Expected error for empty data")
+	}
+
+	wrongVersion := append([]byte(nil), data...)
+	wrongVersion[0] = core.BinaryVersion + 1
+	if _, err := core.PeekHeader(wrongVersion); err == nil || !strings.Contains(err.Error(), "unsupported binary version") {
+		t.Errorf("Expected unsupported version error, got %v", err)
+	}
+
+	// Cut inside the header fields
+	if _, err := core.PeekHeader(data[:10]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF for truncated header, got %v", err)
+	}
+}
+
+func TestPeekHeaderMessagePackMatchesFullParse(t *testing.T) {
+	original := newSampleContainer()
+	original.SetVersion("2.0.0.0")
+	data, err := original.Marshal(core.FormatMessagePack)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	full := core.NewValueContainer()
+	if err := full.Unmarshal(data, core.FormatMessagePack); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	header, err := core.PeekHeaderMessagePack(data)
+	if err != nil {
+		t.Fatalf("PeekHeaderMessagePack failed: %v", err)
+	}
+	if header != full.Header() {
+		t.Errorf("Expected header %+v, got %+v", full.Header(), header)
+	}
+	if header != original.Header() {
+		t.Errorf("Expected header %+v, got %+v", original.Header(), header)
+	}
+}
+
+func TestPeekHeaderMessagePackErrors(t *testing.T) {
+	if _, err := core.PeekHeaderMessagePack(nil); err == nil {
+		t.Error("Expected error for empty data")
+	}
+	if _, err := core.PeekHeaderMessagePack([]byte{0x93, 0x01, 0x02, 0x03}); err == nil {
+		t.Error("Expected error for a MessagePack array")
+	}
+
+	data, err := newSampleContainer().Marshal(core.FormatMessagePack)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if _, err := core.PeekHeaderMessagePack(data[:5]); err == nil {
+		t.Error("Expected error for truncated header")
+	}
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func renameTransform(from, to string) core.Transform {
+	return func(c *core.ValueContainer) (*core.ValueContainer, error) {
+		result := c.Copy(false)
+		for _, value := range c.Values() {
+			if value.Name() == from {
+				payload, err := core.RawPayload(value)
+				if err != nil {
+					return nil, err
+				}
+				renamed, err := core.NewValueFromData(to, value.Type(), payload)
+				if err != nil {
+					return nil, err
+				}
+				value = renamed
+			}
+			result.AddValue(value)
+		}
+		return result, nil
+	}
+}
+
+func redactTransform(names ...string) core.Transform {
+	return func(c *core.ValueContainer) (*core.ValueContainer, error) {
+		for _, name := range names {
+			if len(c.GetValues(name)) > 0 {
+				c.RemoveValue(name)
+				c.AddValue(values.NewStringValue(name, "[REDACTED]"))
+			}
+		}
+		return c, nil
+	}
+}
+
+func defaultsTransform(defaults ...core.Value) core.Transform {
+	return func(c *core.ValueContainer) (*core.ValueContainer, error) {
+		for _, value := range defaults {
+			if len(c.GetValues(value.Name())) == 0 {
+				c.AddValue(value.Clone())
+			}
+		}
+		return c, nil
+	}
+}
+
+func TestPipeline_RenameRedactDefaults(t *testing.T) {
+	input := core.NewValueContainer()
+	input.SetSource("gateway", "in")
+	input.SetMessageType("signup")
+	input.AddValue(values.NewStringValue("user", "alice"))
+	input.AddValue(values.NewStringValue("pwd", "hunter2"))
+
+	pipeline := core.NewPipeline(renameTransform("pwd", "password")).
+		Then(redactTransform("password")).
+		Then(defaultsTransform(
+			values.NewStringValue("locale", "en-US"),
+			values.NewStringValue("user", "anonymous"),
+		))
+	if pipeline.Len() != 3 {
+		t.Fatalf("Expected 3 transforms, got %d", pipeline.Len())
+	}
+
+	output, err := pipeline.Apply(input)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	expected := core.NewValueContainer()
+	expected.SetSource("gateway", "in")
+	expected.SetMessageType("signup")
+	expected.AddValue(values.NewStringValue("user", "alice"))
+	expected.AddValue(values.NewStringValue("password", "[REDACTED]"))
+	expected.AddValue(values.NewStringValue("locale", "en-US"))
+
+	if output.Header() != expected.Header() {
+		t.Errorf("Header mismatch: %+v vs %+v", output.Header(), expected.Header())
+	}
+	if changes := core.Changelog(expected, output); len(changes) != 0 {
+		t.Errorf("Unexpected pipeline result: %v", changes)
+	}
+	if len(output.GetValues("pwd")) != 0 {
+		t.Error("Original field name should be gone after rename")
+	}
+}
+
+func TestPipeline_ShortCircuit(t *testing.T) {
+	errBoom := errors.New("boom")
+	var ran []int
+	step := func(i int, err error) core.Transform {
+		return func(c *core.ValueContainer) (*core.ValueContainer, error) {
+			ran = append(ran, i)
+			return c, err
+		}
+	}
+
+	_, err := core.NewPipeline(step(0, nil), step(1, errBoom), step(2, nil)).Apply(core.NewValueContainer())
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Expected wrapped errBoom, got %v", err)
+	}
+	if len(ran) != 2 {
+		t.Errorf("Expected 2 transforms to run, got %v", ran)
+	}
+}
+
+func TestPipeline_Nested(t *testing.T) {
+	inner := core.NewPipeline(renameTransform("a", "b"))
+	outer := core.NewPipeline(inner.Apply, renameTransform("b", "c"))
+
+	input := core.NewValueContainer()
+	input.AddValue(values.NewInt32Value("a", 1))
+
+	output, err := outer.Apply(input)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(output.GetValues("c")) != 1 || len(output.Values()) != 1 {
+		t.Errorf("Expected single value c, got %v", output.Values())
+	}
+}
@@ -0,0 +1,254 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	containerpb "github.com/kcenon/go_container_system/container/proto"
+	"github.com/kcenon/go_container_system/container/values"
+	"google.golang.org/protobuf/proto"
+)
+
+// loadProtoFixture reads a protobuf encoding of container/proto/container.proto
+// generated with protoc --encode from the .txtpb source of the same name in
+// testdata (see the regeneration command there)
+func loadProtoFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name+".binpb"))
+	if err != nil {
+		t.Fatalf("Reading fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func TestProto_DecodeFixture(t *testing.T) {
+	protoFixture := loadProtoFixture(t, "proto_fixture")
+	container := core.NewValueContainer()
+	if err := container.FromProto(protoFixture); err != nil {
+		t.Fatalf("FromProto failed: %v", err)
+	}
+
+	if container.SourceID() != "a" || container.MessageType() != "t" || container.Header().Version != "1" {
+		t.Errorf("Unexpected header: %+v", container.Header())
+	}
+	if len(container.Values()) != 3 {
+		t.Fatalf("Expected 3 values, got %d", len(container.Values()))
+	}
+
+	id := container.GetValue("id", 0)
+	if n, _ := id.ToInt32(); id.Type() != core.IntValue || n != 150 {
+		t.Errorf("id: expected int 150, got %s %d", id.Type().TypeName(), n)
+	}
+	s := container.GetValue("s", 0)
+	if str, _ := s.ToString(); s.Type() != core.StringValue || str != "hi" {
+		t.Errorf("s: expected string \"hi\", got %s %q", s.Type().TypeName(), str)
+	}
+	n := container.GetValue("n", 0)
+	if v, _ := n.ToInt16(); n.Type() != core.ShortValue || v != -2 {
+		t.Errorf("n: expected short -2, got %s %d", n.Type().TypeName(), v)
+	}
+
+	encoded, err := container.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+	if !bytes.Equal(encoded, protoFixture) {
+		t.Errorf("Re-encoded bytes differ from fixture:\n got %x\nwant %x", encoded, protoFixture)
+	}
+}
+
+func TestProto_DecodeAllTypesFixture(t *testing.T) {
+	fixture := loadProtoFixture(t, "proto_all_types")
+	container := core.NewValueContainer()
+	if err := container.FromProto(fixture); err != nil {
+		t.Fatalf("FromProto failed: %v", err)
+	}
+
+	expected := core.NewValueContainerFull("client", "1", "server", "main", "all_types")
+	longValue, _ := values.NewLongValue("long", -7)
+	ulongValue, _ := values.NewULongValue("ulong", 7)
+	settings := values.NewMapValue("map")
+	settings.Set("a", values.NewInt32Value("a", 1))
+	settings.Set("b", values.NewStringValue("b", "x"))
+	for _, value := range []core.Value{
+		values.NewNullValue("null"),
+		values.NewBoolValue("bool", true),
+		values.NewInt16Value("short", math.MinInt16),
+		values.NewUInt16Value("ushort", math.MaxUint16),
+		values.NewInt32Value("int", math.MinInt32),
+		values.NewUInt32Value("uint", math.MaxUint32),
+		longValue,
+		ulongValue,
+		values.NewInt64Value("llong", math.MinInt64),
+		values.NewUInt64Value("ullong", math.MaxUint64),
+		values.NewFloat32Value("float", 1.5),
+		values.NewFloat64Value("double", -0.25),
+		values.NewStringValue("string", "héllo"),
+		values.NewBytesValue("bytes", []byte{0x00, 0xFF, 0x10}),
+		values.NewContainerValue("container",
+			values.NewInt32Value("inner", 1),
+			values.NewArrayValue("list", values.NewStringValue("", "a"), values.NewBoolValue("", false)),
+		),
+		values.NewDateTimeValue("datetime", time.Unix(0, 1700000000000000001).UTC()),
+		values.NewUUIDValue("uuid", [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}),
+		values.NewDecimalValue("decimal", big.NewInt(-12345), 2),
+		settings,
+	} {
+		expected.AddValue(value)
+	}
+
+	if !container.Equal(expected) {
+		for _, diff := range expected.Diff(container) {
+			t.Errorf("Fixture difference: %s", diff)
+		}
+		t.Fatal("Decoded fixture does not match the expected container")
+	}
+
+	encoded, err := container.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+	if !bytes.Equal(encoded, fixture) {
+		t.Errorf("Re-encoded bytes differ from fixture:\n got %x\nwant %x", encoded, fixture)
+	}
+}
+
+func TestProto_RoundTrip(t *testing.T) {
+	longValue, _ := values.NewLongValue("long", math.MinInt32)
+	ulongValue, _ := values.NewULongValue("ulong", math.MaxUint32)
+
+	original := core.NewValueContainer()
+	original.SetSource("client", "session")
+	original.SetTarget("server", "handler")
+	original.SetMessageType("proto_test")
+	original.AddValue(values.NewNullValue("null"))
+	original.AddValue(values.NewBoolValue("false", false))
+	original.AddValue(values.NewBoolValue("true", true))
+	original.AddValue(values.NewInt16Value("short", math.MinInt16))
+	original.AddValue(values.NewUInt16Value("ushort", math.MaxUint16))
+	original.AddValue(values.NewInt32Value("int", -1))
+	original.AddValue(values.NewUInt32Value("uint", math.MaxUint32))
+	original.AddValue(longValue)
+	original.AddValue(ulongValue)
+	original.AddValue(values.NewInt64Value("llong", math.MinInt64))
+	original.AddValue(values.NewUInt64Value("ullong", math.MaxUint64))
+	original.AddValue(values.NewFloat32Value("float", -1.5))
+	original.AddValue(values.NewFloat64Value("double", math.Pi))
+	original.AddValue(values.NewStringValue("string", "héllo"))
+	original.AddValue(values.NewBytesValue("bytes", []byte{0, 1, 2, 0xFF}))
+	original.AddValue(values.NewDateTimeValue("datetime", time.Unix(-1, 5).UTC()))
+	original.AddValue(values.NewUUIDValue("uuid", [16]byte{0: 0x12, 15: 0xFF}))
+	original.AddValue(values.NewDecimalValue("decimal", big.NewInt(-12345), 2))
+	original.AddValue(values.NewContainerValue("nested",
+		values.NewStringValue("city", "Seoul"),
+		values.NewArrayValue("tags", values.NewInt32Value("", 1), values.NewStringValue("", "two")),
+		values.NewContainerValue("empty"),
+	))
+	original.AddValue(values.NewArrayValue("empty_array"))
+
+	data, err := original.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.FromProto(data); err != nil {
+		t.Fatalf("FromProto failed: %v", err)
+	}
+
+	if restored.Header() != original.Header() {
+		t.Errorf("Header mismatch: %+v vs %+v", restored.Header(), original.Header())
+	}
+	if changes := core.Changelog(original, restored); len(changes) != 0 {
+		t.Errorf("Round trip changed values: %v", changes)
+	}
+	for i, value := range restored.Values() {
+		if want := original.Values()[i].Name(); value.Name() != want {
+			t.Errorf("Values()[%d]: expected %s, got %s", i, want, value.Name())
+		}
+	}
+}
+
+func TestProto_GeneratedTypes(t *testing.T) {
+	original := core.NewValueContainerFull("svc", "1", "peer", "2", "order")
+	original.AddValue(values.NewInt32Value("qty", 3))
+	original.AddValue(values.NewArrayValue("tags", values.NewStringValue("", "a")))
+
+	data, err := original.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+
+	var msg containerpb.Container
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("proto.Unmarshal failed: %v", err)
+	}
+	if msg.GetSourceId() != "svc" || msg.GetTargetSubId() != "2" || msg.GetMessageType() != "order" {
+		t.Errorf("Unexpected header: %v", &msg)
+	}
+	if len(msg.GetValues()) != 2 || msg.GetValues()[0].GetIntValue() != 3 {
+		t.Fatalf("Unexpected values: %v", msg.GetValues())
+	}
+	if tags := msg.GetValues()[1].GetArrayValue().GetValues(); len(tags) != 1 || tags[0].GetStringValue() != "a" {
+		t.Errorf("Unexpected array: %v", msg.GetValues()[1])
+	}
+
+	msg.Values = append(msg.Values, &containerpb.Value{
+		Name:    "ok",
+		Payload: &containerpb.Value_BoolValue{BoolValue: true},
+	})
+	data, err = proto.Marshal(&msg)
+	if err != nil {
+		t.Fatalf("proto.Marshal failed: %v", err)
+	}
+	restored := core.NewValueContainer()
+	if err := restored.FromProto(data); err != nil {
+		t.Fatalf("FromProto failed: %v", err)
+	}
+	if ok, _ := restored.GetValue("ok", 0).ToBool(); !ok {
+		t.Error("Expected value ok=true added through the generated types")
+	}
+}
+
+func TestProto_InvalidData(t *testing.T) {
+	testCases := map[string][]byte{
+		"TruncatedLength":  {0x0A, 0x05, 'a'},
+		"MalformedVarint":  {0x3A, 0x02, 0x30, 0xFF},
+		"MissingPayload":   {0x3A, 0x03, 0x0A, 0x01, 'x'},
+		"ShortOutOfRange":  {0x3A, 0x04, 0x20, 0x80, 0x80, 0x04},
+		"InvalidWireType":  {0x0B},
+		"ZeroFieldNumber":  {0x00, 0x01},
+		"TruncatedFixed32": {0x3A, 0x03, 0x65, 0x00, 0x00},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			container := core.NewValueContainer()
+			err := container.FromProto(data)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !errors.Is(err, core.ErrInvalidProto) {
+				t.Errorf("Expected ErrInvalidProto, got %v", err)
+			}
+		})
+	}
+
+	t.Run("UnknownFieldsSkipped", func(t *testing.T) {
+		data := append([]byte{0x78, 0x01, 0x82, 0x01, 0x01, 'z'}, loadProtoFixture(t, "proto_fixture")...)
+		container := core.NewValueContainer()
+		if err := container.FromProto(data); err != nil {
+			t.Fatalf("FromProto failed: %v", err)
+		}
+		if len(container.Values()) != 3 {
+			t.Errorf("Expected 3 values, got %d", len(container.Values()))
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := core.ParseSchemaJSON([]byte(`{
+  "message_type": "order",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "qty", "type": "int"},
+    {"name": "note", "type": "string", "optional": true}
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("ParseSchemaJSON failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		order    *core.ValueContainer
+		expected []error
+	}{
+		{"ValidMessage", core.NewValueContainerWithType("order",
+			values.NewStringValue("id", "A-100"),
+			values.NewInt32Value("qty", 3),
+			values.NewBoolValue("extra", true),
+		), nil},
+		{"OptionalFieldPresent", core.NewValueContainerWithType("order",
+			values.NewStringValue("id", "A-100"),
+			values.NewInt32Value("qty", 3),
+			values.NewStringValue("note", "gift"),
+		), nil},
+		{"MissingRequiredField", core.NewValueContainerWithType("order",
+			values.NewStringValue("id", "A-100"),
+		), []error{core.ErrMissingField}},
+		{"TypeMismatch", core.NewValueContainerWithType("order",
+			values.NewStringValue("id", "A-100"),
+			values.NewStringValue("qty", "3"),
+			values.NewInt32Value("note", 1),
+		), []error{core.ErrFieldTypeMismatch, core.ErrFieldTypeMismatch}},
+		// qty[1] has the wrong type even though qty[0] is valid
+		{"MessageTypeAndRepeatedFields", core.NewValueContainerWithType("refund",
+			values.NewStringValue("id", "A-100"),
+			values.NewInt32Value("qty", 3),
+			values.NewInt64Value("qty", 4),
+		), []error{core.ErrMessageTypeMismatch, core.ErrFieldTypeMismatch}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := schema.Validate(tt.order)
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("Expected %d errors, got %v", len(tt.expected), errs)
+			}
+			for i, want := range tt.expected {
+				if !errors.Is(errs[i], want) {
+					t.Errorf("Error %d: expected %v, got %v", i, want, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSchemaJSON_Errors(t *testing.T) {
+	if _, err := core.ParseSchemaJSON([]byte(`{"fields": [`)); err == nil {
+		t.Error("Expected an error for malformed JSON")
+	}
+	if _, err := core.ParseSchemaJSON([]byte(`{"fields": [{"name": "x", "type": "quaternion"}]}`)); !errors.Is(err, core.ErrUnknownValueType) {
+		t.Errorf("Expected ErrUnknownValueType, got %v", err)
+	}
+	if _, err := core.ParseSchemaJSON([]byte(`{"fields": [{"type": "int"}]}`)); err == nil {
+		t.Error("Expected an error for a field without a name")
+	}
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func sealedTestKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, 32)
+}
+
+func TestSealedContainerRoundTrip(t *testing.T) {
+	original := newSampleContainer()
+	key := sealedTestKey(0x42)
+
+	for _, format := range []core.SerializationFormat{core.FormatBinary, core.FormatJSON, core.FormatXML} {
+		t.Run(format.String(), func(t *testing.T) {
+			sealed, err := original.SealTo(key, format)
+			if err != nil {
+				t.Fatalf("SealTo failed: %v", err)
+			}
+			if bytes.Contains(sealed, []byte("braces")) {
+				t.Error("Sealed data should not contain the plaintext")
+			}
+
+			opened, gotFormat, err := core.OpenContainer(key, sealed)
+			if err != nil {
+				t.Fatalf("OpenContainer failed: %v", err)
+			}
+			if gotFormat != format {
+				t.Errorf("Expected format %s, got %s", format, gotFormat)
+			}
+			if !opened.Equal(original) {
+				t.Errorf("Round trip changed values: %v", original.Diff(opened))
+			}
+		})
+	}
+
+	// A fresh nonce makes every sealing of the same container different
+	first, _ := original.SealTo(key, core.FormatBinary)
+	second, _ := original.SealTo(key, core.FormatBinary)
+	if bytes.Equal(first, second) {
+		t.Error("Expected different ciphertexts for repeated sealing")
+	}
+}
+
+func TestSealedContainerWrongKey(t *testing.T) {
+	sealed, err := newSampleContainer().SealTo(sealedTestKey(0x42), core.FormatBinary)
+	if err != nil {
+		t.Fatalf("SealTo failed: %v", err)
+	}
+	if _, _, err := core.OpenContainer(sealedTestKey(0x43), sealed); !errors.Is(err, core.ErrSealedAuthentication) {
+		t.Errorf("Expected ErrSealedAuthentication, got %v", err)
+	}
+	if _, err := newSampleContainer().SealTo([]byte("short"), core.FormatBinary); err == nil {
+		t.Error("Expected an error for a key that is not 32 bytes")
+	}
+}
+
+func TestSealedContainerTampered(t *testing.T) {
+	key := sealedTestKey(0x42)
+	sealed, err := newSampleContainer().SealTo(key, core.FormatBinary)
+	if err != nil {
+		t.Fatalf("SealTo failed: %v", err)
+	}
+
+	// The nonce, the ciphertext and the tag are all authenticated
+	for _, i := range []int{0, 12, len(sealed) / 2, len(sealed) - 1} {
+		tampered := append([]byte(nil), sealed...)
+		tampered[i] ^= 0x01
+		if _, _, err := core.OpenContainer(key, tampered); !errors.Is(err, core.ErrSealedAuthentication) {
+			t.Errorf("Byte %d flipped: expected ErrSealedAuthentication, got %v", i, err)
+		}
+	}
+
+	if _, _, err := core.OpenContainer(key, sealed[:20]); !errors.Is(err, core.ErrTruncatedData) {
+		t.Errorf("Expected ErrTruncatedData for data shorter than nonce and tag, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+	_ "github.com/kcenon/go_container_system/container/wireprotocol"
+)
+
+func TestSelfTest_Passes(t *testing.T) {
+	if err := core.SelfTest(); err != nil {
+		t.Fatalf("SelfTest failed: %v", err)
+	}
+}
+
+func TestSelfTest_FaultInjectedCodecFails(t *testing.T) {
+	// A codec that corrupts the double value on decode
+	faulty := core.SelfTestCodec{
+		Name: "faulty",
+		Encode: func(c *core.ValueContainer) ([]byte, error) {
+			return c.SerializeBinary()
+		},
+		Decode: func(data []byte) (*core.ValueContainer, error) {
+			c := core.NewValueContainer()
+			if err := c.DeserializeBinary(data); err != nil {
+				return nil, err
+			}
+			c.ReplaceValue("double", 0, values.NewFloat64Value("double", 0.5))
+			c.RemoveValue("uuid")
+			return c, nil
+		},
+	}
+
+	err := core.SelfTestCodecs(faulty)
+	if !errors.Is(err, core.ErrSelfTestFailed) {
+		t.Fatalf("Expected ErrSelfTestFailed, got %v", err)
+	}
+	for _, want := range []string{"faulty: ~ double", "faulty: - uuid"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestSelfTest_DecodeErrorFails(t *testing.T) {
+	broken := core.SelfTestCodec{
+		Name: "broken",
+		Encode: func(c *core.ValueContainer) ([]byte, error) {
+			data, err := c.SerializeBinary()
+			return data[:len(data)/2], err
+		},
+		Decode: func(data []byte) (*core.ValueContainer, error) {
+			c := core.NewValueContainer()
+			return c, c.DeserializeBinary(data)
+		},
+	}
+
+	err := core.SelfTestCodecs(broken)
+	if !errors.Is(err, core.ErrSelfTestFailed) {
+		t.Fatalf("Expected ErrSelfTestFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "broken: decode") {
+		t.Errorf("Expected a decode failure, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestMarshalUnmarshal_AllFormats(t *testing.T) {
+	original := core.NewValueContainer()
+	original.SetSource("client", "session")
+	original.SetTarget("server", "handler")
+	original.SetMessageType("marshal_test")
+	original.AddValue(values.NewStringValue("name", "Alice"))
+	original.AddValue(values.NewInt32Value("age", 30))
+
+	testCases := []struct {
+		format core.SerializationFormat
+		name   string
+	}{
+		{core.FormatString, "string"},
+		{core.FormatJSON, "json"},
+		{core.FormatXML, "xml"},
+		{core.FormatMessagePack, "msgpack"},
+		{core.FormatBinary, "binary"},
+	}
+
+	// Every format restores at least the header
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.format.String() != tc.name {
+				t.Errorf("String(): expected %s, got %s", tc.name, tc.format.String())
+			}
+
+			data, err := original.Marshal(tc.format)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if len(data) == 0 {
+				t.Fatal("Marshal returned no data")
+			}
+
+			restored := core.NewValueContainer()
+			if err := restored.Unmarshal(data, tc.format); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if restored.Header() != original.Header() {
+				t.Errorf("Header mismatch: %+v vs %+v", restored.Header(), original.Header())
+			}
+		})
+	}
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		if _, err := original.Marshal(core.SerializationFormat(99)); err == nil {
+			t.Error("Expected Marshal error for unsupported format")
+		}
+		if err := core.NewValueContainer().Unmarshal(nil, core.SerializationFormat(99)); err == nil {
+			t.Error("Expected Unmarshal error for unsupported format")
+		}
+	})
+}
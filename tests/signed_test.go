@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestSignedContainerValid(t *testing.T) {
+	original := newSampleContainer()
+	key := []byte("shared-secret")
+
+	for _, format := range []core.SerializationFormat{core.FormatBinary, core.FormatJSON, core.FormatMessagePack} {
+		t.Run(format.String(), func(t *testing.T) {
+			signed, err := original.SerializeSigned(key, format)
+			if err != nil {
+				t.Fatalf("SerializeSigned failed: %v", err)
+			}
+			plain, _ := original.Marshal(format)
+			if !bytes.Equal(signed[:len(plain)], plain) || len(signed) != len(plain)+32 {
+				t.Errorf("Expected the readable payload followed by a 32-byte MAC")
+			}
+
+			restored := core.NewValueContainer()
+			if err := restored.DeserializeSigned(key, signed, format); err != nil {
+				t.Fatalf("DeserializeSigned failed: %v", err)
+			}
+			if !restored.Equal(original) {
+				t.Errorf("Round trip changed values: %v", original.Diff(restored))
+			}
+		})
+	}
+}
+
+func TestSignedContainerModifiedPayload(t *testing.T) {
+	key := []byte("shared-secret")
+	signed, err := newSampleContainer().SerializeSigned(key, core.FormatJSON)
+	if err != nil {
+		t.Fatalf("SerializeSigned failed: %v", err)
+	}
+
+	tampered := bytes.Replace(signed, []byte("125000"), []byte("925000"), 1)
+	if bytes.Equal(tampered, signed) {
+		t.Fatal("Test setup: amount not found in payload")
+	}
+	restored := core.NewValueContainer()
+	restored.AddValue(values.NewStringValue("untouched", "yes"))
+	if err := restored.DeserializeSigned(key, tampered, core.FormatJSON); !errors.Is(err, core.ErrSignatureInvalid) {
+		t.Errorf("Expected ErrSignatureInvalid, got %v", err)
+	}
+	if restored.GetValue("untouched", 0) == nil {
+		t.Error("A failed verification should leave the container unchanged")
+	}
+
+	// The MAC binds the format, so the payload cannot be reinterpreted
+	if err := core.NewValueContainer().DeserializeSigned(key, signed, core.FormatXML); !errors.Is(err, core.ErrSignatureInvalid) {
+		t.Errorf("Expected ErrSignatureInvalid for a different format, got %v", err)
+	}
+	if err := core.NewValueContainer().DeserializeSigned(key, signed[:10], core.FormatJSON); !errors.Is(err, core.ErrTruncatedData) {
+		t.Errorf("Expected ErrTruncatedData for data shorter than the MAC, got %v", err)
+	}
+}
+
+func TestSignedContainerWrongKey(t *testing.T) {
+	signed, err := newSampleContainer().SerializeSigned([]byte("shared-secret"), core.FormatBinary)
+	if err != nil {
+		t.Fatalf("SerializeSigned failed: %v", err)
+	}
+	if err := core.NewValueContainer().DeserializeSigned([]byte("other-secret"), signed, core.FormatBinary); !errors.Is(err, core.ErrSignatureInvalid) {
+		t.Errorf("Expected ErrSignatureInvalid, got %v", err)
+	}
+}
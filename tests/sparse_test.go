@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func newSparseDefaults() *core.ValueContainer {
+	defaults := core.NewValueContainer()
+	defaults.AddValue(values.NewStringValue("host", "localhost"))
+	defaults.AddValue(values.NewInt32Value("port", 8080))
+	defaults.AddValue(values.NewBoolValue("tls", false))
+	defaults.AddValue(values.NewInt32Value("timeout", 30))
+	defaults.AddValue(values.NewStringValue("log_level", "info"))
+	return defaults
+}
+
+func TestSparseSerialization(t *testing.T) {
+	defaults := newSparseDefaults()
+
+	config := core.NewValueContainer()
+	config.SetMessageType("config")
+	config.AddValue(values.NewStringValue("host", "localhost"))
+	config.AddValue(values.NewInt32Value("port", 9090))
+	config.AddValue(values.NewBoolValue("tls", false))
+	config.AddValue(values.NewInt32Value("timeout", 30))
+	config.AddValue(values.NewStringValue("log_level", "info"))
+	config.AddValue(values.NewStringValue("region", "ap-northeast-2"))
+
+	sparseData, err := config.SerializeSparse(defaults)
+	if err != nil {
+		t.Fatalf("SerializeSparse failed: %v", err)
+	}
+	fullData, err := config.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+	if len(sparseData) >= len(fullData) {
+		t.Errorf("Sparse form (%d bytes) should be smaller than full form (%d bytes)", len(sparseData), len(fullData))
+	}
+
+	t.Run("OmitsDefaults", func(t *testing.T) {
+		stored := core.NewValueContainer()
+		if err := stored.DeserializeBinary(sparseData); err != nil {
+			t.Fatalf("DeserializeBinary failed: %v", err)
+		}
+		names := make([]string, 0)
+		for _, value := range stored.Values() {
+			names = append(names, value.Name())
+		}
+		if len(names) != 2 || names[0] != "port" || names[1] != "region" {
+			t.Errorf("Expected only [port region] to be stored, got %v", names)
+		}
+	})
+
+	t.Run("ReconstructsFullContainer", func(t *testing.T) {
+		restored, err := core.DeserializeSparse(sparseData, defaults)
+		if err != nil {
+			t.Fatalf("DeserializeSparse failed: %v", err)
+		}
+		if restored.MessageType() != "config" {
+			t.Errorf("Header not restored: %s", restored.MessageType())
+		}
+		if changes := core.Changelog(config, restored); len(changes) != 0 {
+			t.Errorf("Restored container differs from original: %v", changes)
+		}
+
+		expectedOrder := []string{"host", "port", "tls", "timeout", "log_level", "region"}
+		for i, value := range restored.Values() {
+			if value.Name() != expectedOrder[i] {
+				t.Errorf("Values()[%d]: expected %s, got %s", i, expectedOrder[i], value.Name())
+			}
+		}
+
+		port, _ := restored.GetValue("port", 0).ToInt32()
+		if port != 9090 {
+			t.Errorf("Override lost: port=%d", port)
+		}
+	})
+
+	t.Run("DefaultsNotShared", func(t *testing.T) {
+		restored, _ := core.DeserializeSparse(sparseData, defaults)
+		if restored.GetValue("host", 0) == defaults.GetValue("host", 0) {
+			t.Error("Filled-in defaults should be copies")
+		}
+	})
+
+	t.Run("AllDefaults", func(t *testing.T) {
+		data, err := newSparseDefaults().SerializeSparse(defaults)
+		if err != nil {
+			t.Fatalf("SerializeSparse failed: %v", err)
+		}
+		restored, err := core.DeserializeSparse(data, defaults)
+		if err != nil {
+			t.Fatalf("DeserializeSparse failed: %v", err)
+		}
+		if len(restored.Values()) != 5 {
+			t.Errorf("Expected 5 values, got %d", len(restored.Values()))
+		}
+	})
+}
+
+func TestSparseSerialization_RepeatedNames(t *testing.T) {
+	defaults := core.NewValueContainer()
+	defaults.AddValue(values.NewStringValue("tag", "a"))
+	defaults.AddValue(values.NewInt32Value("port", 8080))
+	defaults.AddValue(values.NewStringValue("tag", "b"))
+
+	testCases := []struct {
+		name string
+		tags []string
+	}{
+		{"SecondDiffers", []string{"a", "x"}},
+		{"FirstDiffers", []string{"x", "b"}},
+		{"FewerOccurrences", []string{"a"}},
+		{"MoreOccurrences", []string{"a", "b", "c"}},
+		{"Unchanged", []string{"a", "b"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := core.NewValueContainer()
+			config.AddValue(values.NewInt32Value("port", 8080))
+			for _, tag := range tc.tags {
+				config.AddValue(values.NewStringValue("tag", tag))
+			}
+
+			data, err := config.SerializeSparse(defaults)
+			if err != nil {
+				t.Fatalf("SerializeSparse failed: %v", err)
+			}
+			restored, err := core.DeserializeSparse(data, defaults)
+			if err != nil {
+				t.Fatalf("DeserializeSparse failed: %v", err)
+			}
+
+			got := restored.GetValues("tag")
+			if len(got) != len(tc.tags) {
+				t.Fatalf("Expected %d tags, got %d", len(tc.tags), len(got))
+			}
+			for i, want := range tc.tags {
+				if s, _ := got[i].ToString(); s != want {
+					t.Errorf("tag[%d]: expected %q, got %q", i, want, s)
+				}
+			}
+			if port, _ := restored.GetValue("port", 0).ToInt32(); port != 8080 {
+				t.Errorf("Default lost: port=%d", port)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var errWriterFull = errors.New("writer full")
+
+// limitedWriter accepts up to limit bytes and fails on the write that exceeds it
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	remaining := w.limit - w.buf.Len()
+	if len(p) <= remaining {
+		return w.buf.Write(p)
+	}
+	w.buf.Write(p[:remaining])
+	return remaining, errWriterFull
+}
+
+func TestWriteTo(t *testing.T) {
+	container := newSampleContainer()
+
+	t.Run("MatchesSerializeArray", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := container.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("WriteTo failed: %v", err)
+		}
+		if n != int64(buf.Len()) {
+			t.Errorf("WriteTo reported %d bytes, buffer has %d", n, buf.Len())
+		}
+
+		expected, err := container.SerializeArray()
+		if err != nil {
+			t.Fatalf("SerializeArray failed: %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("WriteTo output differs from SerializeArray")
+		}
+	})
+
+	t.Run("PartialWriteError", func(t *testing.T) {
+		w := &limitedWriter{limit: 20}
+		n, err := container.WriteTo(w)
+		if !errors.Is(err, errWriterFull) {
+			t.Fatalf("Expected writer error, got %v", err)
+		}
+		if n != 20 {
+			t.Errorf("Expected 20 bytes reported before failure, got %d", n)
+		}
+	})
+}
+
+func TestWriteMessagePackTo(t *testing.T) {
+	container := newSampleContainer()
+
+	t.Run("Decodable", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := container.WriteMessagePackTo(&buf); err != nil {
+			t.Fatalf("WriteMessagePackTo failed: %v", err)
+		}
+
+		var decoded map[string]interface{}
+		if err := msgpack.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if decoded["message_type"] != "sample" || decoded["target_sub_id"] != "2" {
+			t.Errorf("Unexpected header: %v", decoded)
+		}
+		valueList, ok := decoded["values"].([]interface{})
+		if !ok || len(valueList) != len(container.Values()) {
+			t.Fatalf("Expected %d values, got %v", len(container.Values()), decoded["values"])
+		}
+		blob := valueList[4].(map[string]interface{})
+		if blob["name"] != "blob" || len(blob["data"].([]byte)) != 4096 {
+			t.Errorf("Unexpected blob value: name=%v", blob["name"])
+		}
+
+		restored := core.NewValueContainer()
+		if err := restored.FromMessagePack(buf.Bytes()); err != nil {
+			t.Fatalf("FromMessagePack failed: %v", err)
+		}
+		if restored.SourceID() != "client" || restored.MessageType() != "sample" {
+			t.Errorf("Header not restored: %s %s", restored.SourceID(), restored.MessageType())
+		}
+	})
+
+	t.Run("PartialWriteError", func(t *testing.T) {
+		w := &limitedWriter{limit: 2048}
+		if err := container.WriteMessagePackTo(w); !errors.Is(err, errWriterFull) {
+			t.Fatalf("Expected writer error, got %v", err)
+		}
+	})
+}
+
+func TestSaveToFileStreams(t *testing.T) {
+	container := newSampleContainer()
+	dir := t.TempDir()
+
+	textPath := filepath.Join(dir, "container.txt")
+	if err := container.SaveToFile(textPath); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+	var expected bytes.Buffer
+	container.WriteTo(&expected)
+	saved, err := os.ReadFile(textPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !bytes.Equal(saved, expected.Bytes()) {
+		t.Error("SaveToFile output differs from WriteTo")
+	}
+
+	msgpackPath := filepath.Join(dir, "container.msgpack")
+	if err := container.SaveToFileMessagePack(msgpackPath); err != nil {
+		t.Fatalf("SaveToFileMessagePack failed: %v", err)
+	}
+	restored := core.NewValueContainer()
+	if err := restored.LoadFromFileMessagePack(msgpackPath); err != nil {
+		t.Fatalf("LoadFromFileMessagePack failed: %v", err)
+	}
+	if restored.TargetID() != "server" {
+		t.Errorf("TargetID not restored: %s", restored.TargetID())
+	}
+
+	if err := container.SaveToFile(filepath.Join(dir, "missing", "x.txt")); err == nil {
+		t.Error("Expected error writing into a missing directory")
+	}
+}
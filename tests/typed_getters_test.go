@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func newTypedGetterContainer() *core.ValueContainer {
+	container := core.NewValueContainer()
+	container.AddValue(values.NewBoolValue("bool", true))
+	container.AddValue(values.NewInt16Value("i16", -16))
+	container.AddValue(values.NewUInt16Value("u16", 16))
+	container.AddValue(values.NewInt32Value("i32", -32))
+	container.AddValue(values.NewUInt32Value("u32", 32))
+	container.AddValue(values.NewInt64Value("i64", -64))
+	container.AddValue(values.NewUInt64Value("u64", 64))
+	container.AddValue(values.NewFloat32Value("f32", 1.5))
+	container.AddValue(values.NewFloat64Value("f64", 2.5))
+	container.AddValue(values.NewStringValue("str", "hello"))
+	container.AddValue(values.NewBytesValue("bytes", []byte{0xCA, 0xFE}))
+	return container
+}
+
+func TestTypedGetters_AllPrimitives(t *testing.T) {
+	c := newTypedGetterContainer()
+
+	if v, ok := core.GetBool(c, "bool"); !ok || !v {
+		t.Errorf("GetBool: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetInt16(c, "i16"); !ok || v != -16 {
+		t.Errorf("GetInt16: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetUInt16(c, "u16"); !ok || v != 16 {
+		t.Errorf("GetUInt16: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetInt32(c, "i32"); !ok || v != -32 {
+		t.Errorf("GetInt32: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetUInt32(c, "u32"); !ok || v != 32 {
+		t.Errorf("GetUInt32: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetInt64(c, "i64"); !ok || v != -64 {
+		t.Errorf("GetInt64: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetUInt64(c, "u64"); !ok || v != 64 {
+		t.Errorf("GetUInt64: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetFloat32(c, "f32"); !ok || v != 1.5 {
+		t.Errorf("GetFloat32: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetFloat64(c, "f64"); !ok || v != 2.5 {
+		t.Errorf("GetFloat64: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetString(c, "str"); !ok || v != "hello" {
+		t.Errorf("GetString: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetBytes(c, "bytes"); !ok || !bytes.Equal(v, []byte{0xCA, 0xFE}) {
+		t.Errorf("GetBytes: got (%v, %v)", v, ok)
+	}
+}
+
+func TestTypedGetters_MissingKey(t *testing.T) {
+	c := newTypedGetterContainer()
+
+	if v, ok := core.GetInt32(c, "missing"); ok || v != 0 {
+		t.Errorf("GetInt32 on missing key: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetString(c, "missing"); ok || v != "" {
+		t.Errorf("GetString on missing key: got (%q, %v)", v, ok)
+	}
+	if v, ok := core.GetBytes(c, "missing"); ok || v != nil {
+		t.Errorf("GetBytes on missing key: got (%v, %v)", v, ok)
+	}
+}
+
+func TestTypedGetters_TypeMismatch(t *testing.T) {
+	c := newTypedGetterContainer()
+
+	if v, ok := core.GetInt32(c, "str"); ok || v != 0 {
+		t.Errorf("GetInt32 on string: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetString(c, "i32"); ok || v != "" {
+		t.Errorf("GetString on int32: got (%q, %v)", v, ok)
+	}
+	if v, ok := core.GetBool(c, "f64"); ok || v {
+		t.Errorf("GetBool on float64: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetBytes(c, "str"); ok || v != nil {
+		t.Errorf("GetBytes on string: got (%v, %v)", v, ok)
+	}
+	if v, ok := core.GetInt16(c, "i64"); ok || v != 0 {
+		t.Errorf("GetInt16 on int64: got (%v, %v)", v, ok)
+	}
+}
@@ -8,7 +8,12 @@ All rights reserved.
 package tests
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kcenon/go_container_system/container/core"
 	"github.com/kcenon/go_container_system/container/values"
@@ -427,3 +432,408 @@ func BenchmarkValueStoreSerializeBinary(b *testing.B) {
 		store.SerializeBinary()
 	}
 }
+
+func TestValueStoreInsertionOrder(t *testing.T) {
+	store := core.NewValueStore()
+	expected := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		// Reverse-alphabetical keys make map or sorted order easy to detect
+		key := fmt.Sprintf("key_%02d", 49-i)
+		expected = append(expected, key)
+		store.Add(key, values.NewInt32Value(key, int32(i)))
+	}
+
+	t.Run("KeysValuesRange", func(t *testing.T) {
+		keys := store.Keys()
+		for i, key := range keys {
+			if key != expected[i] {
+				t.Fatalf("Keys()[%d]: expected %s, got %s", i, expected[i], key)
+			}
+		}
+
+		for i, value := range store.Values() {
+			if value.Name() != expected[i] {
+				t.Fatalf("Values()[%d]: expected %s, got %s", i, expected[i], value.Name())
+			}
+		}
+
+		i := 0
+		store.Range(func(key string, value core.Value) bool {
+			if key != expected[i] {
+				t.Fatalf("Range[%d]: expected %s, got %s", i, expected[i], key)
+			}
+			i++
+			return true
+		})
+	})
+
+	t.Run("StableSerialization", func(t *testing.T) {
+		firstJSON, err := store.Serialize()
+		if err != nil {
+			t.Fatalf("Serialize failed: %v", err)
+		}
+		firstBinary, err := store.SerializeBinary()
+		if err != nil {
+			t.Fatalf("SerializeBinary failed: %v", err)
+		}
+
+		for i := 0; i < 10; i++ {
+			jsonStr, _ := store.Serialize()
+			if jsonStr != firstJSON {
+				t.Fatal("JSON serialization is not stable")
+			}
+			binaryData, _ := store.SerializeBinary()
+			if !bytes.Equal(binaryData, firstBinary) {
+				t.Fatal("Binary serialization is not stable")
+			}
+		}
+
+		// JSON keys appear in insertion order
+		last := -1
+		for _, key := range expected {
+			pos := strings.Index(firstJSON, `"`+key+`"`)
+			if pos <= last {
+				t.Fatalf("Key %s out of insertion order in JSON", key)
+			}
+			last = pos
+		}
+	})
+
+	t.Run("OverwriteAndRemove", func(t *testing.T) {
+		store.Add(expected[0], values.NewInt32Value(expected[0], 100))
+		if store.Keys()[0] != expected[0] {
+			t.Error("Overwriting a key must keep its position")
+		}
+
+		store.Remove(expected[1])
+		keys := store.Keys()
+		if len(keys) != 49 || keys[1] != expected[2] {
+			t.Errorf("Unexpected order after Remove: %v", keys[:3])
+		}
+
+		store.Add(expected[1], values.NewInt32Value(expected[1], 1))
+		keys = store.Keys()
+		if keys[len(keys)-1] != expected[1] {
+			t.Errorf("Re-added key should be last, got %s", keys[len(keys)-1])
+		}
+	})
+}
+
+func TestValueStoreBinaryRoundTrip(t *testing.T) {
+	longValue, err := values.NewLongValue("long", -123456)
+	if err != nil {
+		t.Fatalf("NewLongValue failed: %v", err)
+	}
+	ulongValue, err := values.NewULongValue("ulong", 654321)
+	if err != nil {
+		t.Fatalf("NewULongValue failed: %v", err)
+	}
+
+	original := core.NewValueStore()
+	original.Add("null", values.NewNullValue("null"))
+	original.Add("bool", values.NewBoolValue("bool", true))
+	original.Add("short", values.NewInt16Value("short", -1234))
+	original.Add("ushort", values.NewUInt16Value("ushort", 65000))
+	original.Add("int", values.NewInt32Value("int", -123456789))
+	original.Add("uint", values.NewUInt32Value("uint", 4000000000))
+	original.Add("long", longValue)
+	original.Add("ulong", ulongValue)
+	original.Add("llong", values.NewInt64Value("llong", -9876543210123))
+	original.Add("ullong", values.NewUInt64Value("ullong", 18000000000000000000))
+	original.Add("float", values.NewFloat32Value("float", 3.25))
+	original.Add("double", values.NewFloat64Value("double", 2.718281828459045))
+	original.Add("string", values.NewStringValue("string", "héllo wörld"))
+	original.Add("bytes", values.NewBytesValue("bytes", []byte{0x00, 0xFF, 0x7F}))
+	original.Add("datetime", values.NewDateTimeValue("datetime", time.Unix(1700000000, 123).UTC()))
+
+	data, err := original.SerializeBinary()
+	if err != nil {
+		t.Fatalf("SerializeBinary failed: %v", err)
+	}
+
+	verify := func(t *testing.T, loaded *core.ValueStore) {
+		t.Helper()
+		if loaded.Size() != original.Size() {
+			t.Fatalf("Expected %d entries, got %d", original.Size(), loaded.Size())
+		}
+		keys := loaded.Keys()
+		for i, key := range original.Keys() {
+			if keys[i] != key {
+				t.Errorf("Keys()[%d]: expected %s, got %s", i, key, keys[i])
+			}
+			want := original.Get(key)
+			got := loaded.Get(key)
+			if got == nil {
+				t.Errorf("Missing key %s", key)
+				continue
+			}
+			if got.Type() != want.Type() {
+				t.Errorf("%s: expected type %s, got %s", key, want.Type().TypeName(), got.Type().TypeName())
+			}
+			if got.Name() != key {
+				t.Errorf("%s: unexpected value name %q", key, got.Name())
+			}
+			if !bytes.Equal(got.Data(), want.Data()) {
+				t.Errorf("%s: payload mismatch: expected %v, got %v", key, want.Data(), got.Data())
+			}
+			wantStr, _ := want.ToString()
+			gotStr, _ := got.ToString()
+			if gotStr != wantStr {
+				t.Errorf("%s: expected %q, got %q", key, wantStr, gotStr)
+			}
+		}
+	}
+
+	t.Run("LoadValueStore", func(t *testing.T) {
+		loaded, err := core.LoadValueStore(data)
+		if err != nil {
+			t.Fatalf("LoadValueStore failed: %v", err)
+		}
+		verify(t, loaded)
+	})
+
+	t.Run("DeserializeBinaryDataReplacesContents", func(t *testing.T) {
+		store := core.NewValueStore()
+		store.EnableThreadSafety()
+		store.Add("stale", values.NewStringValue("stale", "old"))
+
+		if err := store.DeserializeBinaryData(data); err != nil {
+			t.Fatalf("DeserializeBinaryData failed: %v", err)
+		}
+		if store.Contains("stale") {
+			t.Error("Existing entries should be replaced")
+		}
+		verify(t, store)
+	})
+
+	t.Run("InvalidDataLeavesStoreUnchanged", func(t *testing.T) {
+		store := core.NewValueStore()
+		store.Add("keep", values.NewInt32Value("keep", 1))
+
+		if err := store.DeserializeBinaryData(data[:len(data)-1]); err == nil {
+			t.Fatal("Expected error for truncated data")
+		}
+		if store.Size() != 1 || !store.Contains("keep") {
+			t.Error("Store should be unchanged after a failed load")
+		}
+	})
+}
+
+func TestValueStoreLegacyBinary(t *testing.T) {
+	// Version 0 layout: no version byte, then [count:4] and
+	// [key_len:4][key][type:1][value_len:4][value] entries
+	legacy := []byte{
+		0x02, 0x00, 0x00, 0x00, // count = 2
+		0x02, 0x00, 0x00, 0x00, 'i', 'd', // key "id"
+		byte(core.IntValue), 0x04, 0x00, 0x00, 0x00, 0x2A, 0x00, 0x00, 0x00, // int 42
+		0x04, 0x00, 0x00, 0x00, 'n', 'a', 'm', 'e', // key "name"
+		byte(core.StringValue), 0x03, 0x00, 0x00, 0x00, 'b', 'o', 'b', // string "bob"
+	}
+
+	verify := func(t *testing.T, store *core.ValueStore) {
+		t.Helper()
+		if store.Size() != 2 {
+			t.Fatalf("Expected 2 entries, got %d", store.Size())
+		}
+		if id, _ := store.Get("id").ToInt32(); id != 42 {
+			t.Errorf("id: expected 42, got %d", id)
+		}
+		if name, _ := store.Get("name").ToString(); name != "bob" {
+			t.Errorf("name: expected bob, got %q", name)
+		}
+	}
+
+	t.Run("RejectedByDefault", func(t *testing.T) {
+		if _, err := core.LoadValueStore(legacy); err == nil {
+			t.Error("Expected legacy data to be rejected without WithLegacyCompat")
+		}
+	})
+
+	t.Run("MissingVersionByte", func(t *testing.T) {
+		store, err := core.LoadValueStore(legacy, core.WithLegacyCompat())
+		if err != nil {
+			t.Fatalf("LoadValueStore failed: %v", err)
+		}
+		verify(t, store)
+	})
+
+	t.Run("UnknownVersionByte", func(t *testing.T) {
+		store := core.NewValueStore()
+		if err := store.DeserializeBinaryData(append([]byte{0x07}, legacy...), core.WithLegacyCompat()); err != nil {
+			t.Fatalf("DeserializeBinaryData failed: %v", err)
+		}
+		verify(t, store)
+	})
+
+	t.Run("CurrentFormatUnaffected", func(t *testing.T) {
+		current := core.NewValueStore()
+		current.Add("id", values.NewInt32Value("id", 42))
+		current.Add("name", values.NewStringValue("name", "bob"))
+		data, err := current.SerializeBinary()
+		if err != nil {
+			t.Fatalf("SerializeBinary failed: %v", err)
+		}
+		store, err := core.LoadValueStore(data, core.WithLegacyCompat())
+		if err != nil {
+			t.Fatalf("LoadValueStore failed: %v", err)
+		}
+		verify(t, store)
+	})
+
+	t.Run("Garbage", func(t *testing.T) {
+		if _, err := core.LoadValueStore([]byte{0x07, 0xFF, 0xFF, 0xFF, 0xFF}, core.WithLegacyCompat()); err == nil {
+			t.Error("Expected error for unparseable data")
+		}
+	})
+}
+
+func TestValueContainerToStoreUniqueNames(t *testing.T) {
+	container := core.NewValueContainerWithType("sensor")
+	container.AddValue(values.NewStringValue("device", "probe-1"))
+	container.AddValue(values.NewFloat64Value("celsius", 21.5))
+	container.AddValue(values.NewBoolValue("online", true))
+
+	store := container.ToStore()
+	if store.Size() != 3 {
+		t.Fatalf("Expected 3 entries, got %d", store.Size())
+	}
+	if got := strings.Join(store.Keys(), ","); got != "device,celsius,online" {
+		t.Errorf("Expected keys in container order, got %s", got)
+	}
+	if store.Get("celsius") != container.GetValue("celsius", 0) {
+		t.Error("Store should hold the container's values")
+	}
+
+	restored := core.StoreToContainer(store, "sensor")
+	if restored.MessageType() != "sensor" {
+		t.Errorf("Expected message type 'sensor', got '%s'", restored.MessageType())
+	}
+	if diffs := container.Diff(restored); len(diffs) != 0 {
+		t.Errorf("Round trip changed values: %v", diffs)
+	}
+}
+
+func TestValueContainerToStoreDuplicateNames(t *testing.T) {
+	container := core.NewValueContainerWithType("readings")
+	container.AddValue(values.NewInt32Value("reading", 1))
+	container.AddValue(values.NewStringValue("unit", "kPa"))
+	container.AddValue(values.NewInt32Value("reading", 2))
+	container.AddValue(values.NewInt32Value("reading#1", 99))
+	container.AddValue(values.NewInt32Value("reading", 3))
+
+	store := container.ToStore()
+	if got := strings.Join(store.Keys(), ","); got != "reading,unit,reading#1,reading#1#1,reading#2" {
+		t.Errorf("Unexpected keys %s", got)
+	}
+	if v, _ := store.Get("reading#2").ToInt32(); v != 3 {
+		t.Errorf("Expected third reading under 'reading#2', got %d", v)
+	}
+	if store.Get("reading#2").Name() != "reading" {
+		t.Errorf("Suffixed entries should keep their value name, got %q", store.Get("reading#2").Name())
+	}
+
+	restored := core.StoreToContainer(store, "readings")
+	if restored.Count("reading") != 3 {
+		t.Errorf("Expected 3 'reading' values after round trip, got %d", restored.Count("reading"))
+	}
+	if diffs := container.Diff(restored); len(diffs) != 0 {
+		t.Errorf("Round trip changed values: %v", diffs)
+	}
+}
+
+func TestStoreToContainerNamesUnnamedValues(t *testing.T) {
+	store := core.NewValueStore()
+	store.Add("count", values.NewInt32Value("", 7))
+
+	container := core.StoreToContainer(store, "")
+	if v, err := container.GetValue("count", 0).ToInt32(); err != nil || v != 7 {
+		t.Errorf("Expected unnamed value under its key 'count', got %d (%v)", v, err)
+	}
+}
+
+func TestValueStoreAddPathThreeLevels(t *testing.T) {
+	store := core.NewValueStore()
+	if err := store.AddPath("db.primary.host", values.NewStringValue("", "10.0.0.1")); err != nil {
+		t.Fatalf("AddPath failed: %v", err)
+	}
+	if err := store.AddPath("db.primary.port", values.NewInt32Value("port", 5432)); err != nil {
+		t.Fatalf("AddPath failed: %v", err)
+	}
+	if err := store.AddPath("db.replica.host", values.NewStringValue("host", "10.0.0.2")); err != nil {
+		t.Fatalf("AddPath failed: %v", err)
+	}
+
+	if store.Size() != 1 {
+		t.Errorf("Expected a single top-level key, got %v", store.Keys())
+	}
+	db := store.Get("db")
+	if db == nil || db.Type() != core.ContainerValue {
+		t.Fatalf("Expected container under 'db', got %v", db)
+	}
+	if db.ChildCount() != 2 {
+		t.Errorf("Expected 2 children of 'db', got %d", db.ChildCount())
+	}
+
+	host := store.GetPath("db.primary.host")
+	if host == nil {
+		t.Fatal("GetPath returned nil for db.primary.host")
+	}
+	if s, _ := host.ToString(); s != "10.0.0.1" || host.Name() != "host" {
+		t.Errorf("Expected host '10.0.0.1', got %q named %q", s, host.Name())
+	}
+	if port, _ := store.GetPath("db.primary.port").ToInt32(); port != 5432 {
+		t.Errorf("Expected port 5432, got %d", port)
+	}
+	if s, _ := store.GetPath("db.replica.host").ToString(); s != "10.0.0.2" {
+		t.Errorf("Expected replica host '10.0.0.2', got %q", s)
+	}
+
+	// Adding to an existing path replaces the leaf
+	if err := store.AddPath("db.primary.port", values.NewInt32Value("port", 6432)); err != nil {
+		t.Fatalf("AddPath failed: %v", err)
+	}
+	if n := store.GetPath("db.primary").ChildCount(); n != 2 {
+		t.Errorf("Expected replaced leaf, got %d children", n)
+	}
+	if port, _ := store.GetPath("db.primary.port").ToInt32(); port != 6432 {
+		t.Errorf("Expected port 6432, got %d", port)
+	}
+
+	for _, missing := range []string{"db.standby", "db.primary.host.extra", "cache", "db..host", ""} {
+		if v := store.GetPath(missing); v != nil {
+			t.Errorf("GetPath(%q) should return nil, got %v", missing, v)
+		}
+	}
+}
+
+func TestValueStoreAddPathConflict(t *testing.T) {
+	store := core.NewValueStore()
+	store.Add("db", values.NewStringValue("db", "sqlite"))
+	if err := store.AddPath("app.name", values.NewStringValue("name", "demo")); err != nil {
+		t.Fatalf("AddPath failed: %v", err)
+	}
+	if err := store.AddPath("app.name.short", values.NewStringValue("short", "d")); !errors.Is(err, core.ErrPathNotContainer) {
+		t.Errorf("Expected ErrPathNotContainer for intermediate string, got %v", err)
+	}
+
+	err := store.AddPath("db.primary.host", values.NewStringValue("host", "x"))
+	var pathErr *core.PathError
+	if !errors.As(err, &pathErr) || !errors.Is(err, core.ErrPathNotContainer) {
+		t.Fatalf("Expected *PathError wrapping ErrPathNotContainer, got %v", err)
+	}
+	if pathErr.Segment != "db" {
+		t.Errorf("Expected conflict at segment 'db', got %q", pathErr.Segment)
+	}
+
+	// The store is unchanged
+	if s, _ := store.Get("db").ToString(); s != "sqlite" {
+		t.Errorf("Conflicting AddPath changed 'db' to %q", s)
+	}
+	if n := store.GetPath("app").ChildCount(); n != 1 {
+		t.Errorf("Conflicting AddPath changed 'app', got %d children", n)
+	}
+
+	if err := store.AddPath("a..b", values.NewStringValue("b", "x")); !errors.Is(err, core.ErrInvalidPath) {
+		t.Errorf("Expected ErrInvalidPath for empty segment, got %v", err)
+	}
+}
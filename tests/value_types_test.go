@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+)
+
+func TestValueTypeNumericClassification(t *testing.T) {
+	testCases := []struct {
+		vtype    core.ValueType
+		integer  bool
+		float    bool
+		signed   bool
+		bitWidth int
+	}{
+		{core.NullValue, false, false, false, 0},
+		{core.BoolValue, false, false, false, 0},
+		{core.ShortValue, true, false, true, 16},
+		{core.UShortValue, true, false, false, 16},
+		{core.IntValue, true, false, true, 32},
+		{core.UIntValue, true, false, false, 32},
+		{core.LongValue, true, false, true, 32},
+		{core.ULongValue, true, false, false, 32},
+		{core.LLongValue, true, false, true, 64},
+		{core.ULLongValue, true, false, false, 64},
+		{core.FloatValue, false, true, true, 32},
+		{core.DoubleValue, false, true, true, 64},
+		{core.StringValue, false, false, false, 0},
+		{core.BytesValue, false, false, false, 0},
+		{core.ContainerValue, false, false, false, 0},
+		{core.ArrayValue, false, false, false, 0},
+		{core.DateTimeValue, false, false, false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.vtype.TypeName(), func(t *testing.T) {
+			if got := tc.vtype.IsInteger(); got != tc.integer {
+				t.Errorf("IsInteger() = %v, expected %v", got, tc.integer)
+			}
+			if got := tc.vtype.IsFloat(); got != tc.float {
+				t.Errorf("IsFloat() = %v, expected %v", got, tc.float)
+			}
+			if got := tc.vtype.IsNumeric(); got != (tc.integer || tc.float) {
+				t.Errorf("IsNumeric() = %v, expected %v", got, tc.integer || tc.float)
+			}
+			if got := tc.vtype.IsSigned(); got != tc.signed {
+				t.Errorf("IsSigned() = %v, expected %v", got, tc.signed)
+			}
+			if got := tc.vtype.BitWidth(); got != tc.bitWidth {
+				t.Errorf("BitWidth() = %d, expected %d", got, tc.bitWidth)
+			}
+		})
+	}
+}
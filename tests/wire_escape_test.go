@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+	"github.com/kcenon/go_container_system/container/wireprotocol"
+)
+
+// TestCppWireEscapesDelimiters round-trips names and string data holding
+// every character that delimits the C++ wire format
+func TestCppWireEscapesDelimiters(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"comma", "a,b"},
+		{"brackets", "[x]"},
+		{"semicolon", "end];"},
+		{"braces", "{{nested}};"},
+		{"percent", "100%25 %zz %"},
+		{"newline", "line1\nline2\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := core.NewValueContainer()
+			c.SetMessageType("type;" + tt.data)
+			c.AddValue(values.NewStringValue(tt.data, tt.data))
+			c.AddValue(values.NewInt32Value("after", 7))
+
+			wire, err := wireprotocol.SerializeCppWire(c)
+			if err != nil {
+				t.Fatalf("SerializeCppWire failed: %v", err)
+			}
+			if strings.ContainsAny(wire, "\r\n") {
+				t.Errorf("wire output contains a line break: %q", wire)
+			}
+
+			restored, err := wireprotocol.DeserializeCppWire(wire)
+			if err != nil {
+				t.Fatalf("DeserializeCppWire failed: %v", err)
+			}
+			if got := restored.MessageType(); got != "type;"+tt.data {
+				t.Errorf("MessageType = %q, want %q", got, "type;"+tt.data)
+			}
+			v := restored.GetValue(tt.data, 0)
+			if v == nil {
+				t.Fatalf("value %q not found in %q", tt.data, wire)
+			}
+			if got, _ := v.ToString(); got != tt.data {
+				t.Errorf("data = %q, want %q", got, tt.data)
+			}
+			if after := restored.GetValue("after", 0); after == nil {
+				t.Errorf("value following the escaped one was lost: %q", wire)
+			}
+		})
+	}
+}
+
+// TestCppWireEscapesArrayElements checks escaping inside array cells
+func TestCppWireEscapesArrayElements(t *testing.T) {
+	arr := values.NewArrayValue("list[0]",
+		values.NewStringValue("", "x];[y"),
+		values.NewStringValue("", "a,b,c"),
+	)
+	c := core.NewValueContainer()
+	c.AddValue(arr)
+
+	wire, err := wireprotocol.SerializeCppWire(c)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	restored, err := wireprotocol.DeserializeCppWire(wire)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+
+	v := restored.GetValue("list[0]", 0)
+	if v == nil {
+		t.Fatalf("array not found in %q", wire)
+	}
+	elems := v.(*values.ArrayValue).Elements()
+	if len(elems) != 2 {
+		t.Fatalf("element count = %d, want 2", len(elems))
+	}
+	for i, want := range []string{"x];[y", "a,b,c"} {
+		if got, _ := elems[i].ToString(); got != want {
+			t.Errorf("element %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+// TestCppWirePlainStringsUnchanged checks that data without delimiters is
+// written as before
+func TestCppWirePlainStringsUnchanged(t *testing.T) {
+	c := core.NewValueContainer()
+	c.AddValue(values.NewStringValue("greeting", "hello world"))
+
+	wire, err := wireprotocol.SerializeCppWire(c)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	if !strings.Contains(wire, "[greeting,string_value,hello world];") {
+		t.Errorf("unexpected encoding: %q", wire)
+	}
+	if strings.Contains(wire, "[8,") {
+		t.Errorf("frame without special characters carries the escaping marker: %q", wire)
+	}
+}
+
+// TestCppWireMarksEscapedFrames checks that an escaped frame is flagged in
+// its header
+func TestCppWireMarksEscapedFrames(t *testing.T) {
+	c := core.NewValueContainer()
+	c.AddValue(values.NewStringValue("path", "a;b"))
+
+	wire, err := wireprotocol.SerializeCppWire(c)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	if !strings.Contains(wire, "[8,percent];}};") || !strings.Contains(wire, "[path,string_value,a%3Bb];") {
+		t.Errorf("unexpected encoding: %q", wire)
+	}
+}
+
+// TestCppWireReadsUnmarkedFramesVerbatim checks that percent sequences in
+// frames without the escaping marker, as written by C++ and older
+// versions, are not decoded
+func TestCppWireReadsUnmarkedFramesVerbatim(t *testing.T) {
+	wire := "@header={{[5,a%3Bb];[6,1.0.0.0];}};@data={{[x%2Cy,string_value,a%20b%3Bc];}};"
+
+	restored, err := wireprotocol.DeserializeCppWire(wire)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+	if got := restored.MessageType(); got != "a%3Bb" {
+		t.Errorf("MessageType = %q, want %q", got, "a%3Bb")
+	}
+	v := restored.GetValue("x%2Cy", 0)
+	if v == nil {
+		t.Fatalf("value x%%2Cy not found")
+	}
+	if got, _ := v.ToString(); got != "a%20b%3Bc" {
+		t.Errorf("data = %q, want %q", got, "a%20b%3Bc")
+	}
+}
+
+// TestCppWireSplitJoinKeepsEscaping rewrites the routing of an escaped
+// frame and checks that its payload is still unescaped
+func TestCppWireSplitJoinKeepsEscaping(t *testing.T) {
+	c := core.NewValueContainerFull("client", "c1", "broker", "b1", "order")
+	c.AddValue(values.NewStringValue("note", "x];[y"))
+
+	wire, err := wireprotocol.SerializeCppWire(c)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	header, payload, err := wireprotocol.SplitHeaderPayload([]byte(wire), wireprotocol.FormatCppWire)
+	if err != nil {
+		t.Fatalf("SplitHeaderPayload failed: %v", err)
+	}
+	if !header.WireEscaped {
+		t.Fatal("Expected WireEscaped on the split header")
+	}
+
+	header.TargetID = "warehouse"
+	rejoined := wireprotocol.JoinHeaderPayload(header, payload, wireprotocol.FormatCppWire)
+	restored, err := wireprotocol.DeserializeCppWire(string(rejoined))
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+	if restored.TargetID() != "warehouse" {
+		t.Errorf("TargetID = %q, want warehouse", restored.TargetID())
+	}
+	if got, _ := restored.GetValue("note", 0).ToString(); got != "x];[y" {
+		t.Errorf("note = %q, want %q", got, "x];[y")
+	}
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+	"github.com/kcenon/go_container_system/container/wireprotocol"
+)
+
+// TestCppWireFloatFormatting compares float and double data against the
+// strings C++ std::to_string produces for the same values
+func TestCppWireFloatFormatting(t *testing.T) {
+	tests := []struct {
+		name  string
+		value core.Value
+		want  string
+	}{
+		{"zero", values.NewFloat64Value("v", 0.0), "0.000000"},
+		{"negative_zero", values.NewFloat64Value("v", math.Copysign(0, -1)), "-0.000000"},
+		{"large", values.NewFloat64Value("v", 1e20), "100000000000000000000.000000"},
+		{"fraction", values.NewFloat64Value("v", 1500.75), "1500.750000"},
+		{"nan", values.NewFloat64Value("v", math.NaN()), "nan"},
+		{"inf", values.NewFloat64Value("v", math.Inf(1)), "inf"},
+		{"negative_inf", values.NewFloat64Value("v", math.Inf(-1)), "-inf"},
+		{"float_zero", values.NewFloat32Value("v", 0), "0.000000"},
+		{"float_fraction", values.NewFloat32Value("v", 3.25), "3.250000"},
+		{"float_large", values.NewFloat32Value("v", 1e20), "100000002004087734272.000000"},
+		{"float_nan", values.NewFloat32Value("v", float32(math.NaN())), "nan"},
+		// Beyond six decimals std::to_string loses the value, so the
+		// shortest exact fixed notation is written instead
+		{"small", values.NewFloat64Value("v", 1e-7), "0.0000001"},
+		{"many_digits", values.NewFloat64Value("v", 2.71828182845), "2.71828182845"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := core.NewValueContainer()
+			container.AddValue(tt.value)
+			wireData, err := wireprotocol.SerializeCppWire(container)
+			if err != nil {
+				t.Fatalf("SerializeCppWire failed: %v", err)
+			}
+			field := fmt.Sprintf("[v,%s_value,%s];", tt.value.Type().TypeName(), tt.want)
+			if !strings.Contains(wireData, field) {
+				t.Fatalf("Expected %s in %s", field, wireData)
+			}
+
+			restored, err := wireprotocol.DeserializeCppWire(wireData)
+			if err != nil {
+				t.Fatalf("DeserializeCppWire failed: %v", err)
+			}
+			want, _ := tt.value.ToFloat64()
+			got, _ := restored.GetValue("v", 0).ToFloat64()
+			if math.IsNaN(want) {
+				if !math.IsNaN(got) {
+					t.Errorf("Expected NaN, got %v", got)
+				}
+			} else if got != want || math.Signbit(got) != math.Signbit(want) {
+				t.Errorf("Expected %v after round trip, got %v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+	"github.com/kcenon/go_container_system/container/wireprotocol"
+)
+
+// TestCppWireNonWordNames checks that names outside [A-Za-z0-9_] survive
+// a text wire round trip
+func TestCppWireNonWordNames(t *testing.T) {
+	names := []string{"이름", "größe", "user-id", "first name", "a.b/c", "🍀"}
+
+	c := core.NewValueContainer()
+	for i, name := range names {
+		c.AddValue(values.NewInt32Value(name, int32(i)))
+	}
+
+	wire, err := wireprotocol.SerializeCppWire(c)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	restored, err := wireprotocol.DeserializeCppWire(wire)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+
+	got := restored.Values()
+	if len(got) != len(names) {
+		t.Fatalf("value count = %d, want %d (wire %q)", len(got), len(names), wire)
+	}
+	for i, name := range names {
+		if got[i].Name() != name {
+			t.Errorf("value %d name = %q, want %q", i, got[i].Name(), name)
+		}
+		if n, _ := got[i].ToInt32(); n != int32(i) {
+			t.Errorf("value %q = %d, want %d", name, n, i)
+		}
+	}
+}
+
+// TestCppWireDuplicateNames checks that values sharing a name are all kept,
+// in order
+func TestCppWireDuplicateNames(t *testing.T) {
+	wire := "@header={{[5,data_container];[6,1.0.0.0];}};" +
+		"@data={{[tag,string_value,first];[tag,string_value,second];[other,int_value,3];}};"
+
+	restored, err := wireprotocol.DeserializeCppWire(wire)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+
+	tags := restored.GetValues("tag")
+	if len(tags) != 2 {
+		t.Fatalf("GetValues(tag) returned %d values, want 2", len(tags))
+	}
+	for i, want := range []string{"first", "second"} {
+		if s, _ := tags[i].ToString(); s != want {
+			t.Errorf("tag[%d] = %q, want %q", i, s, want)
+		}
+	}
+	if restored.GetValue("other", 0) == nil {
+		t.Error("value after the duplicates was lost")
+	}
+
+	again, err := wireprotocol.SerializeCppWire(restored)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	roundTrip, err := wireprotocol.DeserializeCppWire(again)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+	if n := len(roundTrip.GetValues("tag")); n != 2 {
+		t.Errorf("round trip kept %d tag values, want 2", n)
+	}
+}
+
+// TestCppWireRejectsUnknownTypes checks that a cell with an unknown type
+// name fails the parse instead of being dropped, at top level and nested
+func TestCppWireRejectsUnknownTypes(t *testing.T) {
+	for _, data := range []string{
+		"[a,int_value,1];[b,future_value,xyz];[c,int_value,3];",
+		"[box,container_value,2];[a,int_value,1];[b,future_value,xyz];",
+		"[list,array_value,1];[,future_value,xyz];",
+	} {
+		wire := "@header={{[5,data_container];[6,1.0.0.0];}};@data={{" + data + "}};"
+		if _, err := wireprotocol.DeserializeCppWire(wire); !errors.Is(err, core.ErrUnknownValueType) {
+			t.Errorf("%s: expected ErrUnknownValueType, got %v", data, err)
+		}
+	}
+}
+
+// TestCppWireExtendedTypes checks that datetime, UUID, decimal and map values
+// are written with their own type names and read back, not dropped
+func TestCppWireExtendedTypes(t *testing.T) {
+	id, err := values.NewUUIDValueFromString("id", "123e4567-e89b-12d3-a456-426614174000")
+	if err != nil {
+		t.Fatalf("NewUUIDValueFromString failed: %v", err)
+	}
+	price, err := values.NewDecimalValueFromString("price", "-12.50")
+	if err != nil {
+		t.Fatalf("NewDecimalValueFromString failed: %v", err)
+	}
+	attrs := values.NewMapValue("attrs")
+	attrs.Set("color, shade", values.NewStringValue("", "red"))
+	attrs.Set("size", values.NewInt32Value("n", 42))
+
+	c := core.NewValueContainer()
+	c.AddValue(values.NewDateTimeValue("at", time.Unix(1700000000, 123456789).UTC()))
+	c.AddValue(id)
+	c.AddValue(price)
+	c.AddValue(attrs)
+
+	wire, err := wireprotocol.SerializeCppWire(c)
+	if err != nil {
+		t.Fatalf("SerializeCppWire failed: %v", err)
+	}
+	for _, cell := range []string{"[at,datetime_value,1700000000123456789];",
+		"[id,uuid_value,123e4567-e89b-12d3-a456-426614174000];",
+		"[price,decimal_value,-12.50];", "[attrs,map_value,2];"} {
+		if !strings.Contains(wire, cell) {
+			t.Errorf("wire %q is missing %q", wire, cell)
+		}
+	}
+
+	restored, err := wireprotocol.DeserializeCppWire(wire)
+	if err != nil {
+		t.Fatalf("DeserializeCppWire failed: %v", err)
+	}
+	if diffs := c.Diff(restored); len(diffs) != 0 {
+		t.Errorf("round trip changed values: %v (wire %q)", diffs, wire)
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+	"github.com/kcenon/go_container_system/container/wireprotocol"
+)
+
+func TestCppWireStreamThroughPipe(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		for id := int32(1); id <= 3; id++ {
+			if _, err := wireprotocol.WriteCppWire(pw, newSampleContainer(values.NewInt32Value("frame", id))); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.Close()
+	}()
+
+	r := bufio.NewReader(pr)
+	for id := int32(1); id <= 3; id++ {
+		restored, err := wireprotocol.ReadCppWire(r)
+		if err != nil {
+			t.Fatalf("Frame %d: ReadCppWire failed: %v", id, err)
+		}
+		want := newSampleContainer(values.NewInt32Value("frame", id))
+		if diffs := want.Diff(restored); len(diffs) > 0 {
+			t.Errorf("Frame %d: round trip changed values: %v", id, diffs)
+		}
+		if restored.MessageType() != "sample" {
+			t.Errorf("Frame %d: expected message type sample, got %q", id, restored.MessageType())
+		}
+	}
+	if _, err := wireprotocol.ReadCppWire(r); err != io.EOF {
+		t.Errorf("Expected io.EOF after the last frame, got %v", err)
+	}
+}
+
+func TestCppWireWriteMatchesSerialize(t *testing.T) {
+	container := newSampleContainer(values.NewInt32Value("frame", 7))
+	var sb strings.Builder
+	n, err := wireprotocol.WriteCppWire(&sb, container)
+	if err != nil {
+		t.Fatalf("WriteCppWire failed: %v", err)
+	}
+	expected, _ := wireprotocol.SerializeCppWire(container)
+	if sb.String() != expected || n != len(expected) {
+		t.Errorf("WriteCppWire wrote %d bytes %q, expected %q", n, sb.String(), expected)
+	}
+}
+
+func TestCppWireReadTruncatedFrame(t *testing.T) {
+	frame, _ := wireprotocol.SerializeCppWire(newSampleContainer(values.NewInt32Value("frame", 1)))
+	for _, cut := range []int{1, strings.Index(frame, "@data"), len(frame) - 1} {
+		// An unbuffered reader exercises the single-byte fallback
+		r := io.MultiReader(strings.NewReader(frame[:cut]))
+		if _, err := wireprotocol.ReadCppWire(r); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Cut at %d: expected io.ErrUnexpectedEOF, got %v", cut, err)
+		}
+	}
+}
+
+func TestCppWireReadFrameLimit(t *testing.T) {
+	frame, _ := wireprotocol.SerializeCppWire(newSampleContainer(values.NewInt32Value("frame", 1)))
+
+	if _, err := wireprotocol.ReadCppWireLimit(strings.NewReader(frame), len(frame)-1); !errors.Is(err, core.ErrFrameTooLarge) {
+		t.Errorf("Expected core.ErrFrameTooLarge one byte under the frame size, got %v", err)
+	}
+	if _, err := wireprotocol.ReadCppWireLimit(strings.NewReader(frame), len(frame)); err != nil {
+		t.Errorf("Frame of exactly the limit should be read, got %v", err)
+	}
+
+	// A stream that never closes its data section stops at the limit
+	endless := io.MultiReader(strings.NewReader("@header={{}};@data={{"), strings.NewReader(strings.Repeat("}}", 1<<12)))
+	if _, err := wireprotocol.ReadCppWireLimit(endless, 1024); !errors.Is(err, core.ErrFrameTooLarge) {
+		t.Errorf("Expected core.ErrFrameTooLarge for an unterminated frame, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestFromXML_RoundTrip(t *testing.T) {
+	long, _ := values.NewLongValue("long", -2147483648)
+	ulong, _ := values.NewULongValue("ulong", 4294967295)
+	id, _ := values.NewUUIDValueFromString("id", "123e4567-e89b-12d3-a456-426614174000")
+
+	original := core.NewValueContainerFull("client", "1", "server", "2", "all")
+	original.AddValue(values.NewNullValue("nothing"))
+	original.AddValue(values.NewBoolValue("flag", true))
+	original.AddValue(values.NewInt16Value("short", -12))
+	original.AddValue(values.NewUInt16Value("ushort", 65535))
+	original.AddValue(values.NewInt32Value("age", 42))
+	original.AddValue(values.NewUInt32Value("uint", 4000000000))
+	original.AddValue(long)
+	original.AddValue(ulong)
+	original.AddValue(values.NewInt64Value("llong", math.MinInt64))
+	original.AddValue(values.NewUInt64Value("ullong", math.MaxUint64))
+	original.AddValue(values.NewFloat32Value("float", 1.5))
+	original.AddValue(values.NewFloat64Value("double", math.Inf(1)))
+	original.AddValue(values.NewStringValue("text", "a <b> & \"c\""))
+	original.AddValue(values.NewBytesValue("blob", []byte{0x00, 0xff, 0x10}))
+	original.AddValue(values.NewDateTimeValue("when", time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)))
+	original.AddValue(id)
+	original.AddValue(values.NewDecimalValue("price", big.NewInt(-12345), 3))
+	original.AddValue(values.NewContainerValue("customer",
+		values.NewStringValue("name", "Kim"),
+		values.NewArrayValue("tags", values.NewStringValue("", "vip"), values.NewInt32Value("", 7)),
+	))
+
+	xmlStr, err := original.ToXML()
+	if err != nil {
+		t.Fatalf("ToXML failed: %v", err)
+	}
+	if strings.Contains(xmlStr, "&lt;value") {
+		t.Errorf("Values should be nested elements, not escaped strings:\n%s", xmlStr)
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.FromXML(xmlStr); err != nil {
+		t.Fatalf("FromXML failed: %v\n%s", err, xmlStr)
+	}
+	if !restored.Equal(original) {
+		for _, diff := range original.Diff(restored) {
+			t.Errorf("Round-trip difference: %s", diff)
+		}
+		t.Fatalf("Restored container should equal the original:\n%s", xmlStr)
+	}
+	if age, err := restored.GetValue("age", 0).ToInt32(); err != nil || age != 42 {
+		t.Errorf("Expected age 42, got %d (%v)", age, err)
+	}
+}
+
+func TestFromXML_UnmarshalFormatXML(t *testing.T) {
+	original := core.NewValueContainerWithType("greeting")
+	original.AddValue(values.NewStringValue("name", "Bob"))
+
+	data, err := original.Marshal(core.FormatXML)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	restored := core.NewValueContainer()
+	if err := restored.Unmarshal(data, core.FormatXML); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !restored.Equal(original) {
+		t.Error("Unmarshal(FormatXML) should restore header and values")
+	}
+}
+
+func TestFromXML_Malformed(t *testing.T) {
+	c := core.NewValueContainer()
+	c.AddValue(values.NewStringValue("keep", "me"))
+
+	if err := c.FromXML("<container><values><value name=\"x\" type=\"int\">1</values>"); err == nil {
+		t.Error("Expected an error for malformed XML")
+	}
+	if err := c.FromXML(`<container><values><value name="x" type="int">abc</value></values></container>`); err == nil {
+		t.Error("Expected an error for a non-numeric int")
+	}
+	err := c.FromXML(`<container><values><value name="x" type="quaternion">1</value></values></container>`)
+	if !errors.Is(err, core.ErrUnknownValueType) {
+		t.Errorf("Expected ErrUnknownValueType, got %v", err)
+	}
+	if len(c.GetValues("keep")) != 1 {
+		t.Error("A failed FromXML should leave the container unchanged")
+	}
+}
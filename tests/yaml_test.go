@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kcenon/go_container_system/container/core"
+	"github.com/kcenon/go_container_system/container/values"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+	// Values YAML would otherwise retype: booleans, octal-looking and
+	// multi-line text, 64-bit extremes, the smallest float and raw bytes
+	original := newSampleContainer(
+		values.NewStringValue("title", "yes"),
+		values.NewStringValue("multiline", "line one\nline two: 2"),
+		values.NewStringValue("numeric_text", "0123"),
+		values.NewInt64Value("max", math.MaxInt64),
+		values.NewUInt64Value("umax", math.MaxUint64),
+		values.NewFloat64Value("tiny", math.SmallestNonzeroFloat64),
+		values.NewBoolValue("enabled", true),
+		values.NewBytesValue("raw", []byte{0x00, 0xFF, 0xFE}),
+		values.NewDateTimeValue("when", time.Unix(1700000000, 5).UTC()),
+		values.NewNullValue("nothing"),
+	)
+	original.SetCreatedAt(time.UnixMilli(1700000000123).UTC())
+
+	data, err := original.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	text := string(data)
+
+	for _, want := range []string{
+		"\nmessage_type: sample\n",
+		"\nsource_id: client\n",
+		"\nvalues:\n",
+		"encoding: base64",
+		"data: AP/+",
+		"data: 9223372036854775807",
+	} {
+		if !strings.Contains("\n"+text, want) {
+			t.Errorf("YAML missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, ": {") || strings.Contains(text, "- {") {
+		t.Errorf("YAML should use block style, not flow style:\n%s", text)
+	}
+
+	restored := core.NewValueContainer()
+	if err := restored.FromYAML(data); err != nil {
+		t.Fatalf("FromYAML failed: %v\n%s", err, data)
+	}
+	if !restored.Equal(original) {
+		t.Errorf("YAML round trip changed the container:\n%s", data)
+	}
+
+	raw, ok := restored.GetValue("raw", 0).(*values.BytesValue)
+	if !ok {
+		t.Fatalf("Expected *values.BytesValue, got %T", restored.GetValue("raw", 0))
+	}
+	if !bytes.Equal(raw.Data(), []byte{0x00, 0xFF, 0xFE}) {
+		t.Errorf("raw = %x, want 00fffe", raw.Data())
+	}
+}
+
+func TestFromYAML_HandWritten(t *testing.T) {
+	doc := `
+source_id: editor
+message_type: manual
+version: 1.0.0.0
+values:
+  - name: retries
+    type: int
+    data: 3
+  - name: label
+    type: string
+    data: hello
+`
+	c := core.NewValueContainer()
+	if err := c.FromYAML([]byte(doc)); err != nil {
+		t.Fatalf("FromYAML failed: %v", err)
+	}
+	if c.MessageType() != "manual" || c.SourceID() != "editor" {
+		t.Errorf("header = %+v", c.Header())
+	}
+	if n, _ := c.GetValue("retries", 0).ToInt32(); n != 3 {
+		t.Errorf("retries = %d, want 3", n)
+	}
+	if s, _ := c.GetValue("label", 0).ToString(); s != "hello" {
+		t.Errorf("label = %q, want hello", s)
+	}
+
+	if err := core.NewValueContainer().FromYAML([]byte("values: [")); err == nil {
+		t.Error("Expected an error for malformed YAML")
+	}
+}
+
+func TestToYAML_RejectsNonFiniteFloats(t *testing.T) {
+	c := core.NewValueContainer()
+	c.AddValue(values.NewFloat64Value("inf", math.Inf(-1)))
+	if _, err := c.ToYAML(); !errors.Is(err, core.ErrNonFiniteFloat) {
+		t.Errorf("Expected ErrNonFiniteFloat, got %v", err)
+	}
+}
+
+func TestFromYAML_RejectsAliases(t *testing.T) {
+	// "Billion laughs": each level references the previous one nine times
+	var doc strings.Builder
+	doc.WriteString("a0: &a0 [x, x, x, x, x, x, x, x, x]\n")
+	for i := 1; i <= 8; i++ {
+		fmt.Fprintf(&doc, "a%d: &a%d [*a%d, *a%d, *a%d, *a%d, *a%d, *a%d, *a%d, *a%d, *a%d]\n",
+			i, i, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1)
+	}
+	doc.WriteString("values: *a8\n")
+
+	if err := core.NewValueContainer().FromYAML([]byte(doc.String())); err == nil {
+		t.Error("Expected an error for a document using aliases")
+	}
+}